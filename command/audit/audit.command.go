@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/command/flags"
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+var (
+	params auditParams
+)
+
+// Returns a Cobra command for querying the audit trail of markets handled by a running SX Reporter node.
+func GetCommand() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use: "audit",
+		Short: "Displays the audit trail of a market handled by the SX Reporter node, " +
+			"from the MQ message and verify API response to the tx receipts, or exports it as JSON lines",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(auditCmd)
+
+	return auditCmd
+}
+
+// Configures flags for the given Cobra command.
+func setFlags(cmd *cobra.Command) {
+	flags.SetOperatorAddrFlag(cmd, &params.operatorAddr)
+	flags.SetOperatorTokenFileFlag(cmd, &params.operatorTokenFile)
+
+	cmd.Flags().StringVar(
+		&params.marketHash,
+		marketHashFlag,
+		"",
+		"the hash of the market to query",
+	)
+
+	cmd.Flags().StringVar(
+		&params.exportPath,
+		exportFlag,
+		"",
+		"the file the audit trail is exported to as JSON lines, the whole trail is exported if no market hash is passed in",
+	)
+}
+
+// It validates the audit trail parameters.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Queries the operator API for the audit trail and writes it to the outputter, or exports it to the given file.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if params.exportPath != "" {
+		result, err := exportAuditTrail()
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		outputter.SetCommandResult(result)
+
+		return
+	}
+
+	result := &AuditTrailResult{}
+	if err := helper.OperatorGet(params.operatorAddr, params.operatorTokenFile, params.getPath(), result); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// Downloads the audit trail as JSON lines into the export file.
+func exportAuditTrail() (*AuditExportResult, error) {
+	file, err := os.OpenFile(params.exportPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if err := helper.OperatorDownload(params.operatorAddr, params.operatorTokenFile, params.getPath(), file); err != nil {
+		return nil, err
+	}
+
+	return &AuditExportResult{
+		MarketHash: params.marketHash,
+		Path:       params.exportPath,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"errors"
+	"net/url"
+)
+
+// Defining flag names for the audit trail parameters.
+const (
+	marketHashFlag = "market-hash"
+	exportFlag     = "export"
+)
+
+var (
+	errMissingMarketHash = errors.New("no market hash passed in, it is only optional when exporting with --" + exportFlag)
+)
+
+// Holds the parameters of an audit trail query.
+type auditParams struct {
+	operatorAddr      string
+	operatorTokenFile string
+	marketHash        string
+	exportPath        string
+}
+
+// Checks that a market hash is provided unless the whole audit trail is exported.
+func (ap *auditParams) validateFlags() error {
+	if ap.marketHash == "" && ap.exportPath == "" {
+		return errMissingMarketHash
+	}
+
+	return nil
+}
+
+// Returns the operator API path of the audit trail query or export.
+func (ap *auditParams) getPath() string {
+	path := "/v1/audit"
+	if ap.exportPath != "" {
+		path += "/export"
+	}
+
+	if ap.marketHash == "" {
+		return path
+	}
+
+	return path + "?" + url.Values{"marketHash": {ap.marketHash}}.Encode()
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	MarketHash string    `json:"marketHash"`
+	Event      string    `json:"event"`
+	Function   string    `json:"function,omitempty"`
+	Key        string    `json:"key,omitempty"`
+	Outcome    *int32    `json:"outcome,omitempty"`
+	Request    string    `json:"request,omitempty"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Response   string    `json:"response,omitempty"`
+	TxHash     string    `json:"txHash,omitempty"`
+	Nonce      *uint64   `json:"nonce,omitempty"`
+	GasUsed    uint64    `json:"gasUsed,omitempty"`
+	Status     *uint64   `json:"status,omitempty"`
+	Block      uint64    `json:"block,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Error      string    `json:"error,omitempty"`
+
+	Disagreement *Disagreement `json:"disagreement,omitempty"`
+}
+
+type Disagreement struct {
+	ProposedOutcome *int32 `json:"proposedOutcome,omitempty"`
+	VerifiedOutcome *int32 `json:"verifiedOutcome,omitempty"`
+	VotedOutcome    *int32 `json:"votedOutcome,omitempty"`
+	ReportedOutcome *int32 `json:"reportedOutcome,omitempty"`
+}
+
+// Returns the known outcomes of the disagreement.
+func (d *Disagreement) details() []string {
+	details := make([]string, 0, 4)
+
+	for _, outcome := range []struct {
+		name  string
+		value *int32
+	}{
+		{"proposed", d.ProposedOutcome},
+		{"verified", d.VerifiedOutcome},
+		{"voted", d.VotedOutcome},
+		{"reported", d.ReportedOutcome},
+	} {
+		if outcome.value != nil {
+			details = append(details, fmt.Sprintf("%s=%d", outcome.name, *outcome.value))
+		}
+	}
+
+	return details
+}
+
+// Returns the event specific details of the entry.
+func (e *AuditEntry) details() string {
+	details := make([]string, 0)
+
+	if e.Outcome != nil {
+		details = append(details, fmt.Sprintf("outcome=%d", *e.Outcome))
+	}
+
+	if e.Request != "" {
+		details = append(details, fmt.Sprintf("request=%s", e.Request))
+	}
+
+	if e.StatusCode != 0 {
+		details = append(details, fmt.Sprintf("statusCode=%d", e.StatusCode))
+	}
+
+	if e.TxHash != "" {
+		details = append(details, fmt.Sprintf("txHash=%s", e.TxHash))
+	}
+
+	if e.Nonce != nil {
+		details = append(details, fmt.Sprintf("nonce=%d", *e.Nonce))
+	}
+
+	if e.Status != nil {
+		details = append(details, fmt.Sprintf("status=%d gasUsed=%d block=%d", *e.Status, e.GasUsed, e.Block))
+	}
+
+	if e.Disagreement != nil {
+		details = append(details, e.Disagreement.details()...)
+	}
+
+	if e.Reason != "" {
+		details = append(details, fmt.Sprintf("reason=%q", e.Reason))
+	}
+
+	if e.Error != "" {
+		details = append(details, fmt.Sprintf("error=%q", e.Error))
+	}
+
+	return strings.Join(details, " ")
+}
+
+type AuditTrailResult struct {
+	MarketHash string        `json:"marketHash"`
+	Entries    []*AuditEntry `json:"entries"`
+}
+
+func (r *AuditTrailResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("\n[AUDIT TRAIL %s]\n", r.MarketHash))
+
+	if len(r.Entries) == 0 {
+		buffer.WriteString("No entries recorded for the market\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, 0, len(r.Entries)+1)
+	rows = append(rows, "Time|Event|Function|Key|Details")
+
+	for _, entry := range r.Entries {
+		rows = append(rows, fmt.Sprintf(
+			"%s|%s|%s|%s|%s",
+			entry.Time.Format(time.RFC3339),
+			entry.Event,
+			entry.Function,
+			entry.Key,
+			entry.details(),
+		))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type AuditExportResult struct {
+	MarketHash string `json:"marketHash,omitempty"`
+	Path       string `json:"path"`
+}
+
+func (r *AuditExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	marketHash := r.MarketHash
+	if marketHash == "" {
+		marketHash = "all markets"
+	}
+
+	vals := []string{
+		fmt.Sprintf("Market hash|%s", marketHash),
+		fmt.Sprintf("Exported to|%s", r.Path),
+	}
+
+	buffer.WriteString("\n[AUDIT TRAIL EXPORT]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
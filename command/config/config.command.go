@@ -0,0 +1,21 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Returns a Cobra command group for generating, validating and printing the node configuration.
+func GetCommand() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Top level command for generating, validating and printing the node configuration. Only accepts subcommands.",
+	}
+
+	configCmd.AddCommand(
+		getInitCommand(),
+		getValidateCommand(),
+		getShowCommand(),
+	)
+
+	return configCmd
+}
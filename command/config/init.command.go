@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/infra/server"
+)
+
+var (
+	initOutputPath string
+	initForce      bool
+)
+
+// Returns a Cobra command for writing an annotated config template.
+func getInitCommand() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Writes an annotated YAML config template documenting every option",
+		Run:   runInitCommand,
+	}
+
+	initCmd.Flags().StringVar(
+		&initOutputPath,
+		outputFlag,
+		defaultConfigPath,
+		"the path the config template is written to",
+	)
+
+	initCmd.Flags().BoolVar(
+		&initForce,
+		forceFlag,
+		false,
+		"overwrite the file if it already exists",
+	)
+
+	return initCmd
+}
+
+// Writes the config template to the output path and writes the path to the outputter.
+func runInitCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if initForce {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	// the config may be edited to hold credentials, such as the AMQP URI
+	file, err := os.OpenFile(initOutputPath, flag, 0600)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("unable to create config file (%s), %w", initOutputPath, err))
+
+		return
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(server.ConfigTemplate); err != nil {
+		outputter.SetError(fmt.Errorf("unable to write config file (%s), %w", initOutputPath, err))
+
+		return
+	}
+
+	outputter.SetCommandResult(&InitResult{
+		Path: initOutputPath,
+	})
+}
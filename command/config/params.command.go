@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command/flags"
+	"github.com/sx-network/sx-reporter/infra/server"
+)
+
+// Defining flag names shared by the config subcommands.
+const (
+	outputFlag  = "output"
+	forceFlag   = "force"
+	offlineFlag = "offline"
+)
+
+// Default path of the config file written by the init command.
+const defaultConfigPath = "config.yaml"
+
+// Registers the --config flag, marking it required.
+func setConfigPathFlag(cmd *cobra.Command, configPath *string) {
+	flags.SetConfigPathFlag(cmd, configPath)
+
+	_ = cmd.MarkFlagRequired(flags.ConfigPathFlag)
+}
+
+// Loads the config at the path with the SXR_ environment variable overrides and defaults applied,
+// as done by the server command, and reads the secrets manager config it references.
+func loadConfig(path string) (*server.YAMLServerConfig, error) {
+	config, err := server.LoadConfig(path, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.InitRawParams(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"github.com/sx-network/sx-reporter/infra/server"
+	"gopkg.in/yaml.v3"
+)
+
+type InitResult struct {
+	Path string `json:"path"`
+}
+
+func (r *InitResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CONFIG INIT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Config|%s", r.Path),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type ValidateResult struct {
+	Path       string `json:"path"`
+	RPCChecked bool   `json:"rpcChecked"`
+	ChainID    string `json:"chainId,omitempty"`
+}
+
+func (r *ValidateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Config|%s", r.Path),
+		"Valid|true",
+	}
+
+	if r.RPCChecked {
+		vals = append(vals, fmt.Sprintf("Chain ID|%s", r.ChainID))
+	} else {
+		vals = append(vals, "JSON-RPC checks|skipped")
+	}
+
+	buffer.WriteString("\n[CONFIG VALIDATE]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type ShowResult struct {
+	Config  *server.YAMLServerConfig      `json:"config"`
+	Secrets *secrets.SecretsManagerConfig `json:"secrets,omitempty"`
+}
+
+func (r *ShowResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	data, err := yaml.Marshal(r.Config)
+	if err != nil {
+		return err.Error()
+	}
+
+	buffer.WriteString("\n[CONFIG]\n")
+	buffer.Write(data)
+
+	if r.Secrets != nil {
+		vals := []string{
+			fmt.Sprintf("Type|%s", r.Secrets.Type),
+			fmt.Sprintf("Server URL|%s", r.Secrets.ServerURL),
+			fmt.Sprintf("Name|%s", r.Secrets.Name),
+			fmt.Sprintf("Namespace|%s", r.Secrets.Namespace),
+			fmt.Sprintf("Token|%s", r.Secrets.Token),
+		}
+
+		extraKeys := make([]string, 0, len(r.Secrets.Extra))
+		for key := range r.Secrets.Extra {
+			extraKeys = append(extraKeys, key)
+		}
+
+		sort.Strings(extraKeys)
+
+		for _, key := range extraKeys {
+			vals = append(vals, fmt.Sprintf("Extra %s|%v", key, r.Secrets.Extra[key]))
+		}
+
+		buffer.WriteString("\n[SECRETS MANAGER]\n")
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
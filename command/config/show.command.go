@@ -0,0 +1,42 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/infra/server"
+)
+
+var (
+	showConfigPath string
+)
+
+// Returns a Cobra command for printing the effective config.
+func getShowCommand() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Prints the effective config, merging the config file, SXR_ environment variables and defaults, with secrets redacted", //nolint:lll
+		Run:   runShowCommand,
+	}
+
+	setConfigPathFlag(showCmd, &showConfigPath)
+
+	return showCmd
+}
+
+// Loads the config and writes its redacted form to the outputter.
+func runShowCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	config, err := loadConfig(showConfigPath)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&ShowResult{
+		Config:  config.Redacted(),
+		Secrets: server.RedactedSecretsConfig(config.SecretsConfig),
+	})
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+)
+
+var (
+	validateConfigPath string
+	validateOffline    bool
+)
+
+// Returns a Cobra command for validating a config without starting the node.
+func getValidateCommand() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Parses and checks the config as the server would, including JSON-RPC reachability unless offline",
+		Run:   runValidateCommand,
+	}
+
+	setConfigPathFlag(validateCmd, &validateConfigPath)
+
+	validateCmd.Flags().BoolVar(
+		&validateOffline,
+		offlineFlag,
+		false,
+		"skip the JSON-RPC endpoint reachability checks",
+	)
+
+	return validateCmd
+}
+
+// Validates the config and checks its JSON-RPC endpoints, writing the outcome to the outputter.
+func runValidateCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	config, err := loadConfig(validateConfigPath)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := config.Validate(); err != nil {
+		outputter.SetError(fmt.Errorf("invalid config %s:\n%w", validateConfigPath, err))
+
+		return
+	}
+
+	result := &ValidateResult{
+		Path:       validateConfigPath,
+		RPCChecked: !validateOffline,
+	}
+
+	if !validateOffline {
+		chainID, err := config.CheckRPCEndpoints()
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		result.ChainID = chainID.String()
+	}
+
+	outputter.SetCommandResult(result)
+}
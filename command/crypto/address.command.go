@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	helperCrypto "github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+)
+
+var (
+	addressKeyParams keyParams
+	addressPublicKey string
+)
+
+var (
+	errAddressSource = errors.New(
+		"exactly one of --" + keyFileFlag + ", --" + keystoreFlag + " or --" + publicKeyFlag + " must be passed in",
+	)
+)
+
+// Returns a Cobra command for deriving the address of a private or public key.
+func getAddressCommand() *cobra.Command {
+	addressCmd := &cobra.Command{
+		Use:   "address",
+		Short: "Derives the address and public key of a private key, or the address of a public key",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if addressPublicKey == "" {
+				if err := addressKeyParams.validateFlags(); err != nil {
+					return errAddressSource
+				}
+
+				return nil
+			}
+
+			if addressKeyParams.keyFile != "" || addressKeyParams.keystorePath != "" {
+				return errAddressSource
+			}
+
+			return nil
+		},
+		Run: runAddressCommand,
+	}
+
+	setKeyFlags(addressCmd, &addressKeyParams)
+
+	addressCmd.Flags().StringVar(
+		&addressPublicKey,
+		publicKeyFlag,
+		"",
+		"the hex encoded compressed or uncompressed secp256k1 public key",
+	)
+
+	return addressCmd
+}
+
+// Derives the address from the key source or public key and writes it to the outputter.
+func runAddressCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	var (
+		publicKey *ecdsa.PublicKey
+		err       error
+	)
+
+	if addressPublicKey != "" {
+		publicKey, err = parsePublicKey(addressPublicKey)
+	} else {
+		var key *ecdsa.PrivateKey
+		if key, err = addressKeyParams.loadKey(); err == nil {
+			publicKey = &key.PublicKey
+
+			defer helperCrypto.ZeroKey(key)
+		}
+	}
+
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&AddressResult{
+		Address:   helperCrypto.PubKeyToAddress(publicKey).String(),
+		PublicKey: hex.EncodeToHex(helperCrypto.MarshalPublicKey(publicKey)),
+	})
+}
+
+// Parses a hex encoded compressed (33B) or uncompressed (65B) secp256k1 public key.
+func parsePublicKey(input string) (*ecdsa.PublicKey, error) {
+	buf, err := hex.DecodeHex(strings.TrimSpace(input))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode public key, %w", err)
+	}
+
+	var publicKey *ecdsa.PublicKey
+
+	switch len(buf) {
+	case 33:
+		publicKey, err = ethcrypto.DecompressPubkey(buf)
+	case 65:
+		publicKey, err = ethcrypto.UnmarshalPubkey(buf)
+	default:
+		return nil, fmt.Errorf("invalid public key length (%dB), should be 33B or 65B", len(buf))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key, %w", err)
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,180 @@
+package crypto
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/hex"
+)
+
+const (
+	// Private key and address of the web3.js accounts documentation.
+	testKey     = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testAddress = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+	// Signature of "Some data" by the test key, as documented for web3.eth.accounts.sign.
+	testMessageSignature = "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd" +
+		"6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+
+	// Private key keccak256("cow") and address of the EIP-712 example.
+	testTypedDataKey     = "c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4"
+	testTypedDataAddress = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+	// Signature of the EIP-712 example mail, as given by the EIP.
+	testTypedDataSignature = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+	// EIP-712 example mail from the EIP.
+	testTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+)
+
+// Writes the content to a file in a temporary directory and returns its path.
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		message       *messageParams
+		wantScheme    string
+		wantAddress   string
+		wantSignature string
+	}{
+		{
+			"eip191 message",
+			testKey,
+			&messageParams{message: "Some data"},
+			eip191,
+			testAddress,
+			testMessageSignature,
+		},
+		{
+			"eip191 hex message",
+			testKey,
+			&messageParams{message: "0x536f6d652064617461", hexMessage: true},
+			eip191,
+			testAddress,
+			testMessageSignature,
+		},
+		{
+			"eip712 typed data",
+			testTypedDataKey,
+			&messageParams{typedDataFile: writeTestFile(t, "mail.json", testTypedData)},
+			eip712,
+			testTypedDataAddress,
+			testTypedDataSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyParams := &keyParams{keyFile: writeTestFile(t, "key", tt.key+"\n")}
+
+			signed, err := signMessage(keyParams, tt.message)
+			if err != nil {
+				t.Fatalf("signMessage() error = %v", err)
+			}
+
+			if signed.Scheme != tt.wantScheme || signed.Address != tt.wantAddress || signed.Signature != tt.wantSignature {
+				t.Fatalf("signMessage() = %+v, want %s signature %s by %s", signed, tt.wantScheme, tt.wantSignature, tt.wantAddress)
+			}
+
+			signature, err := hex.DecodeHex(signed.Signature)
+			if err != nil || (signature[64] != 27 && signature[64] != 28) {
+				t.Fatalf("signature V = %d, %v, want 27 or 28 as wallets use", signature[64], err)
+			}
+
+			verified, err := verifyMessage(tt.message, signed.Signature, tt.wantAddress)
+			if err != nil || !verified.Valid || verified.Recovered != tt.wantAddress || verified.Hash != signed.Hash {
+				t.Fatalf("verifyMessage() = %+v, %v, want a valid signature by %s", verified, err, tt.wantAddress)
+			}
+
+			// the recovery ID is also accepted as 0 or 1
+			signature[64] -= 27
+
+			if verified, err := verifyMessage(tt.message, hex.EncodeToHex(signature), tt.wantAddress); err != nil || !verified.Valid {
+				t.Errorf("verifyMessage() = %+v, %v with V = %d, want a valid signature", verified, err, signature[64])
+			}
+
+			// the signature does not verify against another address
+			if verified, err := verifyMessage(tt.message, signed.Signature, "0x00000000000000000000000000000000000000aa"); err != nil ||
+				verified.Valid {
+				t.Errorf("verifyMessage() = %+v, %v for another address, want an invalid signature", verified, err)
+			}
+		})
+	}
+}
+
+func TestVerifyMessageTampered(t *testing.T) {
+	// a signature of another message recovers another signer
+	verified, err := verifyMessage(&messageParams{message: "Other data"}, testMessageSignature, testAddress)
+	if err != nil || verified.Valid || strings.EqualFold(verified.Recovered, testAddress) {
+		t.Errorf("verifyMessage() = %+v, %v for another message, want an invalid signature", verified, err)
+	}
+
+	for _, signature := range []string{"", "0x1234", "not hex", testMessageSignature + "00"} {
+		if _, err := verifyMessage(&messageParams{message: "Some data"}, signature, testAddress); !errors.Is(err, errInvalidSignature) {
+			t.Errorf("verifyMessage(%q) error = %v, want %v", signature, err, errInvalidSignature)
+		}
+	}
+}
+
+func TestMessageParamsValidateFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  *messageParams
+		wantErr error
+	}{
+		{"no message", &messageParams{}, errInvalidMessageSource},
+		{"message", &messageParams{message: "Some data"}, nil},
+		{"typed data", &messageParams{typedDataFile: "mail.json"}, nil},
+		{"message and typed data", &messageParams{message: "Some data", typedDataFile: "mail.json"}, errInvalidMessageSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validateFlags(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateFlags() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
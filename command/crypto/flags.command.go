@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+// Sets the flags of the private key sources.
+func setKeyFlags(cmd *cobra.Command, params *keyParams) {
+	cmd.Flags().StringVar(
+		&params.keyFile,
+		keyFileFlag,
+		"",
+		"the path to a file holding the hex encoded private key, or - to read it from stdin",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keystorePath,
+		keystoreFlag,
+		"",
+		"the path to an encrypted JSON keystore file holding the private key",
+	)
+
+	setPassphraseFileFlag(cmd, &params.passphraseFile)
+
+	cmd.MarkFlagsMutuallyExclusive(keyFileFlag, keystoreFlag)
+}
+
+// Sets the keystore passphrase file flag.
+func setPassphraseFileFlag(cmd *cobra.Command, passphraseFile *string) {
+	cmd.Flags().StringVar(
+		passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the keystore passphrase file, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+}
+
+// Sets the flags of the message sources.
+func setMessageFlags(cmd *cobra.Command, params *messageParams) {
+	cmd.Flags().StringVar(
+		&params.message,
+		messageFlag,
+		"",
+		"the message, signed following EIP-191 personal_sign",
+	)
+
+	cmd.Flags().StringVar(
+		&params.messageFile,
+		messageFileFlag,
+		"",
+		"the path to a file holding the message, or - to read it from stdin",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.hexMessage,
+		hexMessageFlag,
+		false,
+		"the flag indicating whether the message is hex encoded bytes",
+	)
+
+	cmd.Flags().StringVar(
+		&params.typedDataFile,
+		typedDataFlag,
+		"",
+		"the path to a JSON file holding EIP-712 typed data, signed following EIP-712",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(messageFlag, messageFileFlag, typedDataFlag)
+	cmd.MarkFlagsMutuallyExclusive(hexMessageFlag, typedDataFlag)
+}
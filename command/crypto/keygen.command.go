@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	helperCrypto "github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+var (
+	keygenParams keyParams
+)
+
+var (
+	errKeygenOutput = errors.New("at most one of --" + keyFileFlag + " or --" + keystoreFlag + " can be passed in")
+)
+
+// Returns a Cobra command for generating a new ECDSA key.
+func getKeygenCommand() *cobra.Command {
+	keygenCmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generates a new ECDSA private key, printing it unless it is written to a key file or keystore",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if keygenParams.keyFile != "" && keygenParams.keystorePath != "" {
+				return errKeygenOutput
+			}
+
+			return nil
+		},
+		Run: runKeygenCommand,
+	}
+
+	keygenCmd.Flags().StringVar(
+		&keygenParams.keyFile,
+		keyFileFlag,
+		"",
+		"the path of a new file the hex encoded private key is written to",
+	)
+
+	keygenCmd.Flags().StringVar(
+		&keygenParams.keystorePath,
+		keystoreFlag,
+		"",
+		"the path of a new encrypted JSON keystore file the private key is written to",
+	)
+
+	setPassphraseFileFlag(keygenCmd, &keygenParams.passphraseFile)
+
+	return keygenCmd
+}
+
+// Generates the key and writes it to the key file, the keystore file or the output.
+func runKeygenCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	key, encodedKey, err := helperCrypto.GenerateAndEncodeECDSAPrivateKey()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer helperCrypto.ZeroKey(key)
+
+	result := &KeygenResult{
+		Address:   helperCrypto.PubKeyToAddress(&key.PublicKey).String(),
+		PublicKey: hex.EncodeToHex(helperCrypto.MarshalPublicKey(&key.PublicKey)),
+	}
+
+	switch {
+	case keygenParams.keystorePath != "":
+		passphrase, err := keystore.ReadPassphrase(keygenParams.passphraseFile, true)
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		if err := keystore.WriteKeystoreFile(keygenParams.keystorePath, encodedKey, passphrase); err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		result.Location = keygenParams.keystorePath
+	case keygenParams.keyFile != "":
+		if err := writeKeyFile(keygenParams.keyFile, encodedKey); err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		result.Location = keygenParams.keyFile
+	default:
+		result.PrivateKey = "0x" + string(encodedKey)
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// Writes the hex encoded key to a new file readable only by the current user.
+func writeKeyFile(path string, encodedKey []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create key file (%s), %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(encodedKey); err != nil {
+		return fmt.Errorf("unable to write key file (%s), %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/sx-network/sx-reporter/command/helper"
+	helperCrypto "github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+// Defining flag names shared by the crypto subcommands.
+const (
+	keyFileFlag        = "key-file"
+	keystoreFlag       = "keystore"
+	passphraseFileFlag = "passphrase-file"
+	publicKeyFlag      = "public-key"
+	messageFlag        = "message"
+	messageFileFlag    = "message-file"
+	hexMessageFlag     = "hex"
+	typedDataFlag      = "typed-data"
+	signatureFlag      = "signature"
+	addressFlag        = "address"
+)
+
+// Constants representing the supported signature schemes.
+const (
+	// eip191 pertains to personal_sign messages
+	eip191 = "eip191"
+	// eip712 pertains to typed structured data
+	eip712 = "eip712"
+)
+
+// signatureLength is the length of a [R || S || V] signature
+const signatureLength = 65
+
+// Errors related to missing or conflicting key and message sources.
+var (
+	errInvalidKeySource = errors.New(
+		"exactly one of --" + keyFileFlag + " or --" + keystoreFlag + " must be passed in",
+	)
+	errInvalidMessageSource = errors.New(
+		"exactly one of --" + messageFlag + ", --" + messageFileFlag + " or --" + typedDataFlag + " must be passed in",
+	)
+	errInvalidSignature = fmt.Errorf("invalid signature, should be %dB [R || S || V]", signatureLength)
+)
+
+// Holds the source of a private key, either a hex encoded key file or an encrypted JSON keystore.
+type keyParams struct {
+	keyFile        string
+	keystorePath   string
+	passphraseFile string
+}
+
+// Checks that exactly one key source is provided.
+func (kp *keyParams) validateFlags() error {
+	if (kp.keyFile == "") == (kp.keystorePath == "") {
+		return errInvalidKeySource
+	}
+
+	return nil
+}
+
+// Loads the private key from the key source.
+func (kp *keyParams) loadKey() (*ecdsa.PrivateKey, error) {
+	var (
+		encodedKey []byte
+		err        error
+	)
+
+	if kp.keystorePath != "" {
+		passphrase, err := keystore.ReadPassphrase(kp.passphraseFile, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if encodedKey, err = keystore.ReadKeystoreFile(kp.keystorePath, passphrase); err != nil {
+			return nil, err
+		}
+	} else {
+		buf, err := helper.ReadFile(kp.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedKey = []byte(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(string(buf))), "0x"))
+
+		helperCrypto.ZeroBytes(buf)
+	}
+
+	defer helperCrypto.ZeroBytes(encodedKey)
+
+	key, err := helperCrypto.BytesToECDSAPrivateKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key, %w", err)
+	}
+
+	return key, nil
+}
+
+// Holds the message to sign or verify, either a personal_sign message or EIP-712 typed data.
+type messageParams struct {
+	message       string
+	messageFile   string
+	hexMessage    bool
+	typedDataFile string
+}
+
+// Checks that exactly one message source is provided.
+func (mp *messageParams) validateFlags() error {
+	sources := 0
+
+	for _, source := range []string{mp.message, mp.messageFile, mp.typedDataFile} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return errInvalidMessageSource
+	}
+
+	return nil
+}
+
+// Returns the signature scheme and the hash to sign, following EIP-712 for typed data
+// and EIP-191 personal_sign for any other message.
+func (mp *messageParams) hash() (string, []byte, error) {
+	if mp.typedDataFile != "" {
+		buf, err := helper.ReadFile(mp.typedDataFile)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var typedData apitypes.TypedData
+		if err := json.Unmarshal(buf, &typedData); err != nil {
+			return "", nil, fmt.Errorf("unable to parse EIP-712 typed data, %w", err)
+		}
+
+		hash, _, err := apitypes.TypedDataAndHash(typedData)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to hash EIP-712 typed data, %w", err)
+		}
+
+		return eip712, hash, nil
+	}
+
+	message := []byte(mp.message)
+
+	if mp.messageFile != "" {
+		buf, err := helper.ReadFile(mp.messageFile)
+		if err != nil {
+			return "", nil, err
+		}
+
+		message = buf
+	}
+
+	if mp.hexMessage {
+		decoded, err := hex.DecodeHex(strings.TrimSpace(string(message)))
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to decode hex message, %w", err)
+		}
+
+		message = decoded
+	}
+
+	return eip191, accounts.TextHash(message), nil
+}
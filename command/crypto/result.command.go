@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type KeygenResult struct {
+	Address    string `json:"address"`
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key,omitempty"`
+	Location   string `json:"location,omitempty"`
+}
+
+func (r *KeygenResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+		fmt.Sprintf("Public key|%s", r.PublicKey),
+	}
+
+	if r.PrivateKey != "" {
+		vals = append(vals, fmt.Sprintf("Private key|%s", r.PrivateKey))
+	}
+
+	if r.Location != "" {
+		vals = append(vals, fmt.Sprintf("Written to|%s", r.Location))
+	}
+
+	buffer.WriteString("\n[CRYPTO KEYGEN]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type AddressResult struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+func (r *AddressResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CRYPTO ADDRESS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+		fmt.Sprintf("Public key|%s", r.PublicKey),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type SignResult struct {
+	Scheme    string `json:"scheme"`
+	Address   string `json:"address"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+func (r *SignResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CRYPTO SIGN]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Scheme|%s", r.Scheme),
+		fmt.Sprintf("Signer (address)|%s", r.Address),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("Signature|%s", r.Signature),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type VerifyResult struct {
+	Scheme    string `json:"scheme"`
+	Hash      string `json:"hash"`
+	Recovered string `json:"recovered"`
+	Valid     bool   `json:"valid"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CRYPTO VERIFY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Scheme|%s", r.Scheme),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("Recovered signer (address)|%s", r.Recovered),
+		fmt.Sprintf("Valid|%t", r.Valid),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	helperCrypto "github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+)
+
+var (
+	signKeyParams     keyParams
+	signMessageParams messageParams
+)
+
+// Returns a Cobra command for signing a message or EIP-712 typed data.
+func getSignCommand() *cobra.Command {
+	signCmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Signs a message following EIP-191 personal_sign, or typed data following EIP-712",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := signKeyParams.validateFlags(); err != nil {
+				return err
+			}
+
+			return signMessageParams.validateFlags()
+		},
+		Run: runSignCommand,
+	}
+
+	setKeyFlags(signCmd, &signKeyParams)
+	setMessageFlags(signCmd, &signMessageParams)
+
+	return signCmd
+}
+
+// Signs the message hash and writes the [R || S || V] signature, with V being 27 or 28, to the outputter.
+func runSignCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := signMessage(&signKeyParams, &signMessageParams)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// Signs the message hash with the key, returning the [R || S || V] signature with V being 27 or 28 as wallets do.
+func signMessage(keyParams *keyParams, messageParams *messageParams) (*SignResult, error) {
+	key, err := keyParams.loadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	defer helperCrypto.ZeroKey(key)
+
+	scheme, hash, err := messageParams.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := ethcrypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27
+
+	return &SignResult{
+		Scheme:    scheme,
+		Address:   helperCrypto.PubKeyToAddress(&key.PublicKey).String(),
+		Hash:      hex.EncodeToHex(hash),
+		Signature: hex.EncodeToHex(signature),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	helperCrypto "github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/helper/types"
+)
+
+var (
+	verifyMessageParams messageParams
+	verifySignature     string
+	verifyAddress       string
+)
+
+var (
+	errNoSignature       = errors.New("no signature passed in")
+	errNoAddress         = errors.New("no address passed in")
+	errInvalidAddress    = errors.New("invalid address passed in")
+	errSignatureMismatch = errors.New("signature was not made by the given address")
+)
+
+// Returns a Cobra command for verifying the signature of a message or EIP-712 typed data.
+func getVerifyCommand() *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies that a message or typed data signature was made by the given address, exiting non-zero if not",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if verifySignature == "" {
+				return errNoSignature
+			}
+
+			if verifyAddress == "" {
+				return errNoAddress
+			}
+
+			if !common.IsHexAddress(verifyAddress) {
+				return fmt.Errorf("%w: %s", errInvalidAddress, verifyAddress)
+			}
+
+			return verifyMessageParams.validateFlags()
+		},
+		RunE: runVerifyCommand,
+	}
+
+	setMessageFlags(verifyCmd, &verifyMessageParams)
+
+	verifyCmd.Flags().StringVar(
+		&verifySignature,
+		signatureFlag,
+		"",
+		"the hex encoded [R || S || V] signature",
+	)
+
+	verifyCmd.Flags().StringVar(
+		&verifyAddress,
+		addressFlag,
+		"",
+		"the address expected to have made the signature",
+	)
+
+	return verifyCmd
+}
+
+// Recovers the signer of the message hash and writes whether it matches the expected address to the outputter.
+// An error is returned if the signature cannot be verified or was not made by the expected address,
+// so that the command exits non-zero.
+func runVerifyCommand(cmd *cobra.Command, _ []string) error {
+	// The flags are valid once the command runs, and the error is written by the root command
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	result, err := verifyMessage(&verifyMessageParams, verifySignature, verifyAddress)
+	if err != nil {
+		return err
+	}
+
+	outputter := command.InitializeOutputter(cmd)
+	outputter.SetCommandResult(result)
+	outputter.WriteOutput()
+
+	if !result.Valid {
+		return errSignatureMismatch
+	}
+
+	return nil
+}
+
+// Recovers the signer of the message hash from the [R || S || V] signature and checks it against the address.
+// The recovery ID V may be 0 or 1, or 27 or 28 as used by wallets.
+func verifyMessage(messageParams *messageParams, encodedSignature, address string) (*VerifyResult, error) {
+	scheme, hash, err := messageParams.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeHex(strings.TrimSpace(encodedSignature))
+	if err != nil || len(signature) != signatureLength {
+		return nil, errInvalidSignature
+	}
+
+	// Signatures made by wallets use 27 or 28 as the recovery ID
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	publicKey, err := ethcrypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	recovered := helperCrypto.PubKeyToAddress(publicKey)
+
+	return &VerifyResult{
+		Scheme:    scheme,
+		Hash:      hex.EncodeToHex(hash),
+		Recovered: recovered.String(),
+		Valid:     recovered == types.StringToAddress(address),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package emergency
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/command/flags"
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+var (
+	params emergencyParams
+)
+
+// Returns a Cobra command for reporting a market outcome through the emergency path of a running SX Reporter node.
+func GetCommand() *cobra.Command {
+	emergencyCmd := &cobra.Command{
+		Use: "emergency-report",
+		Short: "Reports a market outcome with emergencyReportOutcome. " +
+			"Requires OUTCOME_EMERGENCY_REPORTER_ROLE, a reason and explicit confirmation",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(emergencyCmd)
+
+	return emergencyCmd
+}
+
+// Configures flags for the given Cobra command.
+func setFlags(cmd *cobra.Command) {
+	flags.SetOperatorAddrFlag(cmd, &params.operatorAddr)
+	flags.SetOperatorTokenFileFlag(cmd, &params.operatorTokenFile)
+
+	cmd.Flags().StringVar(
+		&params.marketHash,
+		marketHashFlag,
+		"",
+		"the hash of the market to report",
+	)
+
+	cmd.Flags().Int32Var(
+		&params.outcome,
+		outcomeFlag,
+		0,
+		"the outcome to report, 0 (void), 1 (outcome one) or 2 (outcome two)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.reason,
+		reasonFlag,
+		"",
+		"the reason for using the emergency path, recorded in the audit trail",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.confirm,
+		confirmFlag,
+		false,
+		"confirms that the emergency report should be sent",
+	)
+
+	_ = cmd.MarkFlagRequired(marketHashFlag)
+	_ = cmd.MarkFlagRequired(outcomeFlag)
+}
+
+// It validates the emergency report parameters.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Sends the emergency report request to the operator API and writes the result to the outputter.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result := &EmergencyReportResult{}
+	if err := helper.OperatorPost(params.operatorAddr, params.operatorTokenFile, "/v1/emergency-report", params.getRequest(), result); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,73 @@
+package emergency
+
+import (
+	"errors"
+
+	"github.com/sx-network/sx-reporter/reporter"
+)
+
+// Defining flag names for the emergency report parameters.
+const (
+	marketHashFlag = "market-hash"
+	outcomeFlag    = "outcome"
+	reasonFlag     = "reason"
+	confirmFlag    = "confirm"
+)
+
+// Errors related to missing emergency report parameters.
+var (
+	errMissingMarketHash = errors.New("no market hash passed in")
+	errMissingReason     = errors.New("a reason for the emergency report is required")
+	errNotConfirmed      = errors.New("emergency reports must be explicitly confirmed with --" + confirmFlag)
+)
+
+// Holds the parameters of an emergency outcome report.
+type emergencyParams struct {
+	operatorAddr      string
+	operatorTokenFile string
+	marketHash        string
+	outcome           int32
+	reason            string
+	confirm           bool
+}
+
+// Checks that a 32 byte market hash and a reason are provided, that the outcome is allowed by the contract
+// and that the report is confirmed.
+func (ep *emergencyParams) validateFlags() error {
+	if ep.marketHash == "" {
+		return errMissingMarketHash
+	}
+
+	if err := reporter.ValidateMarketHash(ep.marketHash); err != nil {
+		return err
+	}
+
+	if _, err := reporter.ParseOutcome(int64(ep.outcome)); err != nil {
+		return err
+	}
+
+	if ep.reason == "" {
+		return errMissingReason
+	}
+
+	if !ep.confirm {
+		return errNotConfirmed
+	}
+
+	return nil
+}
+
+// Builds the operator API request body for the emergency report.
+func (ep *emergencyParams) getRequest() interface{} {
+	return struct {
+		MarketHash string `json:"marketHash"`
+		Outcome    int32  `json:"outcome"`
+		Reason     string `json:"reason"`
+		Confirm    bool   `json:"confirm"`
+	}{
+		MarketHash: ep.marketHash,
+		Outcome:    ep.outcome,
+		Reason:     ep.reason,
+		Confirm:    ep.confirm,
+	}
+}
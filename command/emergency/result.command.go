@@ -0,0 +1,52 @@
+package emergency
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type EmergencyReportResult struct {
+	Time       time.Time `json:"time"`
+	MarketHash string    `json:"marketHash"`
+	Outcome    int32     `json:"outcome"`
+	Reason     string    `json:"reason"`
+	Reporter   string    `json:"reporter"`
+	TxHash     string    `json:"txHash"`
+	Nonce      *uint64   `json:"nonce"`
+	Pending    bool      `json:"pending"`
+	Success    bool      `json:"success"`
+}
+
+func (r *EmergencyReportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Market hash|%s", r.MarketHash),
+		fmt.Sprintf("Outcome|%d", r.Outcome),
+		fmt.Sprintf("Reason|%s", r.Reason),
+		fmt.Sprintf("Reporter|%s", r.Reporter),
+		fmt.Sprintf("Tx hash|%s", r.TxHash),
+	}
+
+	if r.Nonce != nil {
+		vals = append(vals, fmt.Sprintf("Nonce|%d", *r.Nonce))
+	}
+
+	switch {
+	case r.Pending:
+		vals = append(vals, "Status|sent, see the market audit trail for the mined result")
+	case r.Success:
+		vals = append(vals, "Status|mined")
+	}
+
+	vals = append(vals, fmt.Sprintf("Time|%s", r.Time.Format(time.RFC3339)))
+
+	buffer.WriteString("\n[EMERGENCY REPORT]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -6,10 +6,15 @@ import (
 
 // Constants for flag names used in the CLI application.
 const (
-	ConfigPathFlag = "config"
-	JSONOutputFlag = "json"
+	ConfigPathFlag    = "config"
+	JSONOutputFlag    = "json"
+	OperatorAddrFlag  = "operator-addr"
+	OperatorTokenFlag = "operator-token-file"
 )
 
+// Default address of the operator API queried by CLI commands.
+const DefaultOperatorAddr = "127.0.0.1:9632"
+
 // Helper function to add a --json flag to a command.
 // Registers the --json output setting for all child commands of the provided Cobra command.
 // It adds a persistent boolean flag with the given name, default value and description to the command.
@@ -35,7 +40,7 @@ func SetConfigPathFlag(cmd *cobra.Command, configPath *string) {
 		configPath,
 		ConfigPathFlag,
 		"",
-		"the path to the CLI config. Supports .json",
+		"the path to the CLI config. Supports .yaml, .yml, .json and .toml",
 	)
 }
 
@@ -44,3 +49,25 @@ func SetConfigPathFlag(cmd *cobra.Command, configPath *string) {
 func IsConfigPathSpecified(cmd *cobra.Command) bool {
 	return cmd.Flags().Changed(ConfigPathFlag)
 }
+
+// Helper function to add a --operator-addr flag to a command.
+// This flag allows users to specify the operator API address of a running SX Reporter node.
+func SetOperatorAddrFlag(cmd *cobra.Command, operatorAddr *string) {
+	cmd.Flags().StringVar(
+		operatorAddr,
+		OperatorAddrFlag,
+		DefaultOperatorAddr,
+		"the operator API address of the SX Reporter node, as set by 'operator_addr' in its config",
+	)
+}
+
+// Helper function to add a --operator-token-file flag to a command.
+// This flag allows users to specify the file holding the operator API token of a running SX Reporter node.
+func SetOperatorTokenFileFlag(cmd *cobra.Command, tokenFile *string) {
+	cmd.Flags().StringVar(
+		tokenFile,
+		OperatorTokenFlag,
+		"",
+		"the file holding the operator API token of the SX Reporter node, as set by 'operator_token_file' in its config",
+	)
+}
@@ -3,6 +3,8 @@ package helper
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -11,8 +13,15 @@ import (
 	"github.com/sx-network/sx-reporter/infra/common"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/infra/secrets/awsssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/azurekeyvault"
+	"github.com/sx-network/sx-reporter/infra/secrets/env"
+	"github.com/sx-network/sx-reporter/infra/secrets/gcpssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/hashicorpvault"
 )
 
+// StdinPath is the file path for reading from stdin
+const StdinPath = "-"
+
 type ClientCloseResult struct {
 	Message string `json:"message"`
 }
@@ -73,6 +82,34 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = AWSSSM
+	case secrets.HashicorpVault:
+		vault, err := setupHashicorpVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = vault
+	case secrets.GCPSSM:
+		GCPSSM, err := setupGCPSSM(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = GCPSSM
+	case secrets.AzureKeyVault:
+		azureKeyVault, err := setupAzureKeyVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = azureKeyVault
+	case secrets.Env:
+		envManager, err := setupEnv(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = envManager
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
@@ -92,6 +129,62 @@ func setupAWSSSM(
 	)
 }
 
+// setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
+func setupHashicorpVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return hashicorpvault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupGCPSSM is a helper method for boilerplate gcp secret manager setup
+func setupGCPSSM(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return gcpssm.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupAzureKeyVault is a helper method for boilerplate azure key vault secrets manager setup
+func setupAzureKeyVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return azurekeyvault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupEnv is a helper method for boilerplate env secrets manager setup
+func setupEnv(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return env.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// FormatList formats a list, using a specific blank value replacement
+func FormatList(in []string) string {
+	columnConf := columnize.DefaultConfig()
+	columnConf.Empty = "<none>"
+
+	return columnize.Format(in, columnConf)
+}
+
 // FormatKV formats key value pairs:
 //
 // Key = Value
@@ -104,3 +197,12 @@ func FormatKV(in []string) string {
 
 	return columnize.Format(in, columnConf)
 }
+
+// ReadFile reads the file at the path, or stdin if the path is StdinPath
+func ReadFile(path string) ([]byte, error) {
+	if path == StdinPath {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
@@ -0,0 +1,148 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Timeouts applied to operator API requests made by CLI commands.
+// Posted requests may wait for a tx to be sent and downloads may be large, so they are given more time.
+const (
+	operatorRequestTimeout     = 30 * time.Second
+	operatorPostRequestTimeout = 5 * time.Minute
+	operatorDownloadTimeout    = 5 * time.Minute
+)
+
+// OperatorGet queries the operator API of a running node at the given address
+// and decodes the JSON response into result.
+// The token read from tokenFile, if any, authenticates the request
+func OperatorGet(operatorAddr, tokenFile, path string, result interface{}) error {
+	request, err := newOperatorRequest(http.MethodGet, operatorAddr, tokenFile, path, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: operatorRequestTimeout}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to reach operator API at %s, %w", operatorAddr, err)
+	}
+
+	return decodeOperatorResponse(response, result)
+}
+
+// OperatorPost sends the JSON encoded request to the operator API of a running node
+// at the given address and decodes the JSON response into result.
+// The token read from tokenFile, if any, authenticates the request
+func OperatorPost(operatorAddr, tokenFile, path string, request, result interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := newOperatorRequest(http.MethodPost, operatorAddr, tokenFile, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: operatorPostRequestTimeout}
+
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("unable to reach operator API at %s, %w", operatorAddr, err)
+	}
+
+	return decodeOperatorResponse(response, result)
+}
+
+// OperatorDownload queries the operator API of a running node at the given address
+// and copies the raw response body to w.
+// The token read from tokenFile, if any, authenticates the request
+func OperatorDownload(operatorAddr, tokenFile, path string, w io.Writer) error {
+	request, err := newOperatorRequest(http.MethodGet, operatorAddr, tokenFile, path, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: operatorDownloadTimeout}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to reach operator API at %s, %w", operatorAddr, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return decodeOperatorResponse(response, nil)
+	}
+
+	if _, err := io.Copy(w, response.Body); err != nil {
+		return fmt.Errorf("unable to read operator API response, %w", err)
+	}
+
+	return nil
+}
+
+// newOperatorRequest creates a request to the operator API of a running node at the given address,
+// carrying the token read from tokenFile as a bearer token if tokenFile is set
+func newOperatorRequest(method, operatorAddr, tokenFile, path string, body io.Reader) (*http.Request, error) {
+	request, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", operatorAddr, path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenFile == "" {
+		return request, nil
+	}
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read operator API token, %w", err)
+	}
+
+	if strings.TrimSpace(string(token)) == "" {
+		return nil, fmt.Errorf("operator API token file %s is empty", tokenFile)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	return request, nil
+}
+
+// decodeOperatorResponse decodes a successful operator API response into result,
+// or returns the error message of an unsuccessful one
+func decodeOperatorResponse(response *http.Response, result interface{}) error {
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read operator API response, %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		var errResponse struct {
+			Error string `json:"error"`
+		}
+
+		if err := json.Unmarshal(body, &errResponse); err == nil && errResponse.Error != "" {
+			return fmt.Errorf("operator API error: %s", errResponse.Error)
+		}
+
+		return fmt.Errorf("operator API returned status code %d", response.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("unable to parse operator API response, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type RewardsResult struct {
+	Accrued         *big.Int `json:"accrued"`
+	Withdrawn       *big.Int `json:"withdrawn"`
+	Available       *big.Int `json:"available"`
+	ReportedMarkets int      `json:"reportedMarkets"`
+}
+
+func (r *RewardsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	vals := []string{
+		fmt.Sprintf("Accrued (wei)|%s", r.Accrued),
+		fmt.Sprintf("Withdrawn (wei)|%s", r.Withdrawn),
+		fmt.Sprintf("Available (wei)|%s", r.Available),
+		fmt.Sprintf("Rewarded markets|%d", r.ReportedMarkets),
+	}
+
+	buffer.WriteString("\n[REWARDS]\n")
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
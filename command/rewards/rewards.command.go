@@ -0,0 +1,42 @@
+package rewards
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/command/flags"
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+var (
+	operatorAddr      string
+	operatorTokenFile string
+)
+
+// Returns a Cobra command for displaying the juiced reporting rewards of a running SX Reporter node.
+func GetCommand() *cobra.Command {
+	rewardsCmd := &cobra.Command{
+		Use:   "rewards",
+		Short: "Displays the juiced reporting rewards earned and withdrawn by the SX Reporter node",
+		Run:   runCommand,
+	}
+
+	flags.SetOperatorAddrFlag(rewardsCmd, &operatorAddr)
+	flags.SetOperatorTokenFileFlag(rewardsCmd, &operatorTokenFile)
+
+	return rewardsCmd
+}
+
+// Queries the operator API for the reward totals and writes them to the outputter.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result := &RewardsResult{}
+	if err := helper.OperatorGet(operatorAddr, operatorTokenFile, "/v1/rewards", result); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+// Defining flag names for data directory, config path, keystore passphrase files and output path.
+const (
+	dataDirFlag              = "data-dir"
+	configFlag               = "config"
+	passphraseFileFlag       = "passphrase-file"
+	outputFlag               = "output"
+	outputPassphraseFileFlag = "output-passphrase-file"
+	keyNameFlag              = "key-name"
+)
+
+// Errors related to missing parameters and missing keys.
+var (
+	errInvalidParams = errors.New("no config file or data directory passed in")
+	errNoOutput      = errors.New("no output path passed in")
+)
+
+// Holds export parameters including data directory, config path, local keystore passphrase file,
+// output path, exported keystore passphrase file and secrets manager.
+type exportParams struct {
+	dataDir              string
+	configPath           string
+	passphraseFile       string
+	outputPath           string
+	outputPassphraseFile string
+	keyName              string
+	secretsManager       secrets.SecretsManager
+	address              string
+}
+
+// Checks if the export parameters are valid.
+// It ensures that either a data directory or a config path, and an output path are provided.
+func (ep *exportParams) validateFlags() error {
+	if ep.dataDir == "" && ep.configPath == "" {
+		return errInvalidParams
+	}
+
+	if ep.outputPath == "" {
+		return errNoOutput
+	}
+
+	return secrets.ValidateReporterKeyName(ep.keyName)
+}
+
+// Initializes the secrets manager based on the config path
+// or initializes a local secrets manager if no config path is provided.
+func (ep *exportParams) initSecretsManager() error {
+	var err error
+
+	ep.secretsManager, err = helperSecret.SetupSecretsManager(ep.dataDir, ep.configPath, false, ep.passphraseFile)
+
+	return err
+}
+
+// Exports the validator key to an encrypted JSON keystore file at the output path.
+func (ep *exportParams) exportKey() error {
+	secretName := secrets.NamedReporterKey(ep.keyName)
+
+	if !ep.secretsManager.HasSecret(secretName) {
+		return fmt.Errorf(`secrets "%s" has not been initialized`, secretName)
+	}
+
+	encodedKey, err := ep.secretsManager.GetSecret(secretName)
+	if err != nil {
+		return err
+	}
+
+	defer crypto.ZeroBytes(encodedKey)
+
+	privateKey, err := crypto.BytesToECDSAPrivateKey(encodedKey)
+	if err != nil {
+		return err
+	}
+
+	defer crypto.ZeroKey(privateKey)
+
+	passphrase, err := keystore.ReadExportPassphrase(ep.outputPassphraseFile)
+	if err != nil {
+		return err
+	}
+
+	if err := keystore.WriteKeystoreFile(ep.outputPath, encodedKey, passphrase); err != nil {
+		return err
+	}
+
+	ep.address = crypto.PubKeyToAddress(&privateKey.PublicKey).String()
+
+	return nil
+}
+
+// Retrieves the results of the export, including the validator address and keystore path.
+func (ep *exportParams) getResult() command.CommandResult {
+	return &SecretsExportResult{
+		Address:  ep.address,
+		Keystore: ep.outputPath,
+	}
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+	"golang.org/x/term"
+)
+
+const (
+	testKey     = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testAddress = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+)
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  *exportParams
+		wantErr error
+	}{
+		{"no data dir or config", &exportParams{outputPath: "keystore.json"}, errInvalidParams},
+		{"no output", &exportParams{dataDir: "data"}, errNoOutput},
+		{"valid", &exportParams{dataDir: "data", outputPath: "keystore.json", keyName: "hot"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validateFlags(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateFlags() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportKey(t *testing.T) {
+	dir := t.TempDir()
+	passphraseFile := filepath.Join(dir, "passphrase")
+	outputPassphraseFile := filepath.Join(dir, "output-passphrase")
+
+	if err := os.WriteFile(passphraseFile, []byte("local secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(outputPassphraseFile, []byte("correct horse\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the local keystore is encrypted with a passphrase other than the exported keystore's
+	localManager, err := helperSecret.SetupSecretsManager(filepath.Join(dir, "data"), "", true, passphraseFile)
+	if err != nil {
+		t.Fatalf("SetupSecretsManager() error = %v", err)
+	}
+
+	params := &exportParams{
+		dataDir:              filepath.Join(dir, "data"),
+		passphraseFile:       passphraseFile,
+		outputPath:           filepath.Join(dir, "keystore.json"),
+		outputPassphraseFile: outputPassphraseFile,
+		keyName:              "hot",
+	}
+
+	if err := params.initSecretsManager(); err != nil {
+		t.Fatalf("initSecretsManager() error = %v", err)
+	}
+
+	// keys have to be initialized before being exported
+	if err := params.exportKey(); err == nil {
+		t.Fatal("exportKey() error = nil for a missing key")
+	}
+
+	if _, err := helperSecret.ImportValidatorKey(localManager, secrets.NamedReporterKey("hot"), []byte(testKey)); err != nil {
+		t.Fatalf("ImportValidatorKey() error = %v", err)
+	}
+
+	if err := params.exportKey(); err != nil {
+		t.Fatalf("exportKey() error = %v", err)
+	}
+
+	if params.address != testAddress {
+		t.Errorf("exported address = %s, want %s", params.address, testAddress)
+	}
+
+	if key, err := keystore.ReadKeystoreFile(params.outputPath, "correct horse"); err != nil || string(key) != testKey {
+		t.Errorf("exported keystore holds %s, %v, want %s", key, err, testKey)
+	}
+
+	if _, err := keystore.ReadKeystoreFile(params.outputPath, "local secret"); err == nil {
+		t.Error("exported keystore decrypted with the local keystore passphrase")
+	}
+
+	// an existing keystore is never overwritten
+	if err := params.exportKey(); err == nil {
+		t.Error("exportKey() error = nil for an existing output file")
+	}
+}
+
+func TestExportKeyNoOutputPassphrase(t *testing.T) {
+	dir := t.TempDir()
+
+	// the local keystore passphrase is never used for the exported keystore
+	t.Setenv(keystore.PassphraseEnvVar, "local secret")
+
+	params := &exportParams{
+		dataDir:    filepath.Join(dir, "data"),
+		outputPath: filepath.Join(dir, "keystore.json"),
+	}
+
+	if err := params.initSecretsManager(); err != nil {
+		t.Fatalf("initSecretsManager() error = %v", err)
+	}
+
+	if _, err := helperSecret.ImportValidatorKey(params.secretsManager, secrets.ReporterKey, []byte(testKey)); err != nil {
+		t.Fatalf("ImportValidatorKey() error = %v", err)
+	}
+
+	// the passphrase would be prompted for on a terminal
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		if err := params.exportKey(); !errors.Is(err, keystore.ErrNoExportPassphrase) {
+			t.Fatalf("exportKey() error = %v, want %v", err, keystore.ErrNoExportPassphrase)
+		}
+	}
+
+	t.Setenv(keystore.ExportPassphraseEnvVar, "correct horse")
+
+	if err := params.exportKey(); err != nil {
+		t.Fatalf("exportKey() error = %v", err)
+	}
+
+	if key, err := keystore.ReadKeystoreFile(params.outputPath, "correct horse"); err != nil || string(key) != testKey {
+		t.Errorf("exported keystore holds %s, %v, want %s", key, err, testKey)
+	}
+}
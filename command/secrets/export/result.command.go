@@ -0,0 +1,26 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type SecretsExportResult struct {
+	Address  string `json:"address"`
+	Keystore string `json:"keystore"`
+}
+
+func (r *SecretsExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SECRETS EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+		fmt.Sprintf("Keystore|%s", r.Keystore),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+var (
+	params exportParams
+)
+
+// Returns a Cobra command for exporting the reporter key to an encrypted keystore file.
+func GetCommand() *cobra.Command {
+	secretsExportCmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Exports the private key of the SX Reporter node from the specified Secrets Manager to an encrypted JSON keystore file", //nolint:lll
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsExportCmd)
+
+	return secretsExportCmd
+}
+
+// Configures flags for the given Cobra command. It sets up flags for
+// data directory, config path, keystore passphrase files and output path.
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the SX Reporter Node data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the passphrase file of the encrypted local keystore, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		"",
+		"the path of the exported keystore file, which must not exist yet",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPassphraseFile,
+		outputPassphraseFileFlag,
+		"",
+		"the path to the passphrase file of the exported keystore, if omitted, the passphrase is read from "+
+			keystore.ExportPassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyName,
+		keyNameFlag,
+		"",
+		"the name of the reporter key, if omitted, the default reporter key is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+// Checks if the flags are valid.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Exports the key from the secrets manager to the keystore file.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSecretsManager(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.exportKey(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,162 @@
+package importkey
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/command/helper"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/helper/types"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+// Defining flag names for data directory, config path, local key encryption,
+// keystore passphrase file and the key sources.
+const (
+	dataDirFlag        = "data-dir"
+	configFlag         = "config"
+	encryptFlag        = "encrypt"
+	passphraseFileFlag = "passphrase-file"
+
+	keyFileFlag              = "key-file"
+	mnemonicFileFlag         = "mnemonic-file"
+	mnemonicPasswordFileFlag = "mnemonic-password-file"
+	derivationPathFlag       = "derivation-path"
+	keystoreFlag             = "keystore"
+	keyNameFlag              = "key-name"
+)
+
+// hexPrefix is the optional prefix of hex encoded keys
+const hexPrefix = "0x"
+
+// Errors related to missing parameters and key sources.
+var (
+	errInvalidParams    = errors.New("no config file or data directory passed in")
+	errInvalidKeySource = errors.New(
+		"exactly one of --" + keyFileFlag + ", --" + mnemonicFileFlag + " or --" + keystoreFlag + " must be passed in",
+	)
+)
+
+// Holds import parameters including data directory, config path, local key encryption,
+// keystore passphrase file, the key source and secrets manager.
+type importParams struct {
+	dataDir              string
+	configPath           string
+	encrypt              bool
+	passphraseFile       string
+	keyFile              string
+	mnemonicFile         string
+	mnemonicPasswordFile string
+	derivationPath       string
+	keystorePath         string
+	keyName              string
+	secretsManager       secrets.SecretsManager
+	address              types.Address
+}
+
+// Checks if the import parameters are valid.
+// It ensures that either a data directory or a config path, and exactly one key source are provided.
+func (ip *importParams) validateFlags() error {
+	if ip.dataDir == "" && ip.configPath == "" {
+		return errInvalidParams
+	}
+
+	sources := 0
+
+	for _, source := range []string{ip.keyFile, ip.mnemonicFile, ip.keystorePath} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return errInvalidKeySource
+	}
+
+	return secrets.ValidateReporterKeyName(ip.keyName)
+}
+
+// Initializes the secrets manager based on the config path
+// or initializes a local secrets manager if no config path is provided.
+func (ip *importParams) initSecretsManager() error {
+	var err error
+
+	ip.secretsManager, err = helperSecret.SetupSecretsManager(ip.dataDir, ip.configPath, ip.encrypt, ip.passphraseFile)
+
+	return err
+}
+
+// Reads the key from the key source and sets it as the validator key.
+func (ip *importParams) importKey() error {
+	var (
+		encodedKey []byte
+		err        error
+	)
+
+	switch {
+	case ip.keystorePath != "":
+		ip.address, err = helperSecret.ImportECDSAValidatorKey(
+			ip.secretsManager,
+			secrets.NamedReporterKey(ip.keyName),
+			ip.keystorePath,
+			ip.passphraseFile,
+		)
+
+		return err
+	case ip.mnemonicFile != "":
+		encodedKey, err = ip.readMnemonicKey()
+	default:
+		encodedKey, err = ip.readHexKey()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ip.address, err = helperSecret.ImportValidatorKey(ip.secretsManager, secrets.NamedReporterKey(ip.keyName), encodedKey)
+
+	return err
+}
+
+// Reads the hex encoded key from the key file, or from stdin if the key file is "-".
+func (ip *importParams) readHexKey() ([]byte, error) {
+	buf, err := helper.ReadFile(ip.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey := strings.ToLower(strings.TrimSpace(string(buf)))
+
+	return []byte(strings.TrimPrefix(encodedKey, hexPrefix)), nil
+}
+
+// Derives the key at the derivation path from the mnemonic in the mnemonic file.
+func (ip *importParams) readMnemonicKey() ([]byte, error) {
+	mnemonic, err := helper.ReadFile(ip.mnemonicFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var password []byte
+
+	if ip.mnemonicPasswordFile != "" {
+		if password, err = helper.ReadFile(ip.mnemonicPasswordFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return keystore.DeriveKeyFromMnemonic(
+		string(mnemonic),
+		strings.TrimRight(string(password), "\r\n"),
+		ip.derivationPath,
+	)
+}
+
+// Retrieves the results of the import, including the validator address.
+func (ip *importParams) getResult() command.CommandResult {
+	return &SecretsImportResult{
+		Address: ip.address.String(),
+	}
+}
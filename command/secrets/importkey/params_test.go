@@ -0,0 +1,176 @@
+package importkey
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+const (
+	testKey     = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testAddress = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+
+	// Well-known development mnemonic and the address of its first account on the default derivation path.
+	testMnemonic        = "test test test test test test test test test test test junk"
+	testMnemonicAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+// Writes the content to a file in the given directory and returns its path.
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// Validates the params, sets up their secrets manager and imports their key.
+func runImport(t *testing.T, params *importParams) error {
+	t.Helper()
+
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	if err := params.initSecretsManager(); err != nil {
+		t.Fatalf("initSecretsManager() error = %v", err)
+	}
+
+	return params.importKey()
+}
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  *importParams
+		wantErr error
+	}{
+		{"no data dir or config", &importParams{keyFile: "key"}, errInvalidParams},
+		{"no key source", &importParams{dataDir: "data"}, errInvalidKeySource},
+		{"several key sources", &importParams{dataDir: "data", keyFile: "key", keystorePath: "keystore"}, errInvalidKeySource},
+		{"key file", &importParams{dataDir: "data", keyFile: "key"}, nil},
+		{"named key", &importParams{dataDir: "data", mnemonicFile: "mnemonic", keyName: "hot"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validateFlags(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateFlags() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (&importParams{dataDir: "data", keyFile: "key", keyName: "not a name"}).validateFlags(); err == nil {
+		t.Error("validateFlags() error = nil for an invalid key name")
+	}
+}
+
+func TestImportKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	params := &importParams{
+		dataDir: filepath.Join(dir, "data"),
+		keyFile: writeTestFile(t, dir, "key", " 0x"+testKey+"\n"),
+	}
+
+	if err := runImport(t, params); err != nil {
+		t.Fatalf("importKey() error = %v", err)
+	}
+
+	if params.address.String() != testAddress {
+		t.Errorf("imported address = %s, want %s", params.address, testAddress)
+	}
+
+	if secret, err := params.secretsManager.GetSecret(secrets.ReporterKey); err != nil || string(secret) != testKey {
+		t.Errorf("stored key = %s, %v, want %s", secret, err, testKey)
+	}
+
+	// an existing key is never overwritten
+	if err := params.importKey(); err == nil {
+		t.Error("importKey() error = nil for an already initialized key")
+	}
+
+	invalid := &importParams{dataDir: filepath.Join(dir, "invalid"), keyFile: writeTestFile(t, dir, "invalid", "0x1234")}
+	if err := runImport(t, invalid); err == nil {
+		t.Error("importKey() error = nil for an invalid key")
+	}
+}
+
+func TestImportMnemonic(t *testing.T) {
+	dir := t.TempDir()
+	params := &importParams{
+		dataDir:        filepath.Join(dir, "data"),
+		mnemonicFile:   writeTestFile(t, dir, "mnemonic", testMnemonic+"\n"),
+		derivationPath: keystore.DefaultDerivationPath,
+		keyName:        "hot",
+	}
+
+	if err := runImport(t, params); err != nil {
+		t.Fatalf("importKey() error = %v", err)
+	}
+
+	if params.address.String() != testMnemonicAddress {
+		t.Errorf("imported address = %s, want %s", params.address, testMnemonicAddress)
+	}
+
+	if address, err := helperSecret.LoadValidatorAddress(params.secretsManager, secrets.NamedReporterKey("hot")); err != nil ||
+		address.String() != testMnemonicAddress {
+		t.Errorf("stored named key address = %s, %v, want %s", address, err, testMnemonicAddress)
+	}
+
+	// a mnemonic password derives another key
+	withPassword := &importParams{
+		dataDir:              filepath.Join(dir, "data-password"),
+		mnemonicFile:         params.mnemonicFile,
+		mnemonicPasswordFile: writeTestFile(t, dir, "password", "extra\n"),
+		derivationPath:       keystore.DefaultDerivationPath,
+	}
+
+	if err := runImport(t, withPassword); err != nil {
+		t.Fatalf("importKey() error = %v", err)
+	}
+
+	if withPassword.address == params.address {
+		t.Error("mnemonic password did not change the derived key")
+	}
+}
+
+func TestImportKeystore(t *testing.T) {
+	dir := t.TempDir()
+	keystorePath := filepath.Join(dir, "keystore.json")
+
+	if err := keystore.WriteKeystoreFile(keystorePath, []byte(testKey), "correct horse"); err != nil {
+		t.Fatalf("WriteKeystoreFile() error = %v", err)
+	}
+
+	params := &importParams{
+		dataDir:        filepath.Join(dir, "data"),
+		keystorePath:   keystorePath,
+		passphraseFile: writeTestFile(t, dir, "passphrase", "correct horse\n"),
+	}
+
+	if err := runImport(t, params); err != nil {
+		t.Fatalf("importKey() error = %v", err)
+	}
+
+	if params.address.String() != testAddress {
+		t.Errorf("imported address = %s, want %s", params.address, testAddress)
+	}
+
+	wrongPassphrase := &importParams{
+		dataDir:        filepath.Join(dir, "wrong"),
+		keystorePath:   keystorePath,
+		passphraseFile: writeTestFile(t, dir, "wrong", "wrong horse"),
+	}
+
+	if err := runImport(t, wrongPassphrase); err == nil {
+		t.Error("importKey() error = nil with the wrong keystore passphrase")
+	}
+}
@@ -0,0 +1,24 @@
+package importkey
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type SecretsImportResult struct {
+	Address string `json:"address"`
+}
+
+func (r *SecretsImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SECRETS IMPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,130 @@
+package importkey
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+var (
+	params importParams
+)
+
+// Returns a Cobra command for importing an existing reporter key.
+func GetCommand() *cobra.Command {
+	secretsImportCmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Imports an existing private key for the SX Reporter node to the specified Secrets Manager",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsImportCmd)
+
+	return secretsImportCmd
+}
+
+// Configures flags for the given Cobra command. It sets up flags for
+// data directory, config path, local key encryption and the key sources.
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the SX Reporter Node data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.encrypt,
+		encryptFlag,
+		false,
+		"the flag indicating whether the key is stored in an encrypted JSON keystore, only for the local FS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the keystore passphrase file, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyFile,
+		keyFileFlag,
+		"",
+		"the path to a file holding the hex encoded private key, or - to read it from stdin",
+	)
+
+	cmd.Flags().StringVar(
+		&params.mnemonicFile,
+		mnemonicFileFlag,
+		"",
+		"the path to a file holding a BIP-39 mnemonic to derive the private key from, or - to read it from stdin",
+	)
+
+	cmd.Flags().StringVar(
+		&params.mnemonicPasswordFile,
+		mnemonicPasswordFileFlag,
+		"",
+		"the path to a file holding the optional BIP-39 mnemonic password",
+	)
+
+	cmd.Flags().StringVar(
+		&params.derivationPath,
+		derivationPathFlag,
+		keystore.DefaultDerivationPath,
+		"the BIP-32 derivation path of the private key derived from the mnemonic",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keystorePath,
+		keystoreFlag,
+		"",
+		"the path to an encrypted JSON keystore file holding the private key",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyName,
+		keyNameFlag,
+		"",
+		"the name of the reporter key, if omitted, the default reporter key is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(keyFileFlag, mnemonicFileFlag, keystoreFlag)
+}
+
+// Checks if the flags are valid.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Imports the key from the key source into the secrets manager.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSecretsManager(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.importKey(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -16,6 +16,11 @@ const (
 	configFlag  = "config"
 	ecdsaFlag   = "ecdsa"
 	numFlag     = "num"
+
+	encryptFlag        = "encrypt"
+	passphraseFileFlag = "passphrase-file"
+	keystoreFlag       = "keystore"
+	keyNameFlag        = "key-name"
 )
 
 // Errors related to invalid secrets configuration, missing parameters,
@@ -32,6 +37,10 @@ type initParams struct {
 	dataDir        string
 	configPath     string
 	generatesECDSA bool
+	encrypt        bool
+	passphraseFile string
+	keystorePath   string
+	keyName        string
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 }
@@ -43,7 +52,7 @@ func (ip *initParams) validateFlags() error {
 		return errInvalidParams
 	}
 
-	return nil
+	return secrets.ValidateReporterKeyName(ip.keyName)
 }
 
 // Initializes secrets by setting up the secrets manager and validator key.
@@ -78,7 +87,17 @@ func (ip *initParams) initSecretsManager() error {
 
 // Initializes a local secrets manager with the provided data directory.
 func (ip *initParams) initLocalSecretsManager() error {
-	local, err := helperSecret.SetupLocalSecretsManager(ip.dataDir)
+	var (
+		local secrets.SecretsManager
+		err   error
+	)
+
+	if ip.encrypt {
+		local, err = helperSecret.SetupEncryptedLocalSecretsManager(ip.dataDir, ip.passphraseFile)
+	} else {
+		local, err = helperSecret.SetupLocalSecretsManager(ip.dataDir)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -88,12 +107,24 @@ func (ip *initParams) initLocalSecretsManager() error {
 	return nil
 }
 
-// Initializes the validator key if ECDSA key generation is enabled.
+// Imports the validator key from a keystore file if specified,
+// otherwise initializes it if ECDSA key generation is enabled.
 func (ip *initParams) initValidatorKey() error {
 	var err error
 
+	if ip.keystorePath != "" {
+		_, err = helperSecret.ImportECDSAValidatorKey(
+			ip.secretsManager,
+			secrets.NamedReporterKey(ip.keyName),
+			ip.keystorePath,
+			ip.passphraseFile,
+		)
+
+		return err
+	}
+
 	if ip.generatesECDSA {
-		if _, err = helperSecret.InitECDSAValidatorKey(ip.secretsManager); err != nil {
+		if _, err = helperSecret.InitECDSAValidatorKey(ip.secretsManager, secrets.NamedReporterKey(ip.keyName)); err != nil {
 			return err
 		}
 	}
@@ -108,7 +139,7 @@ func (ip *initParams) getResult() (command.CommandResult, error) {
 		err error
 	)
 
-	if res.Address, err = helperSecret.LoadValidatorAddress(ip.secretsManager); err != nil {
+	if res.Address, err = helperSecret.LoadValidatorAddress(ip.secretsManager, secrets.NamedReporterKey(ip.keyName)); err != nil {
 		return nil, err
 	}
 
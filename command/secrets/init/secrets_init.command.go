@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/keystore"
 )
 
 const (
@@ -70,6 +71,41 @@ func setFlags(cmd *cobra.Command) {
 		true,
 		"the flag indicating whether new ECDSA key is created",
 	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.encrypt,
+		encryptFlag,
+		false,
+		"the flag indicating whether the key is stored in an encrypted JSON keystore, only for the local FS",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the keystore passphrase file, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.keystorePath,
+		keystoreFlag,
+		"",
+		"the path to an existing JSON keystore file to import instead of creating a new ECDSA key",
+	)
+
+	cmd.Flags().StringVar(
+		&basicParams.keyName,
+		keyNameFlag,
+		"",
+		"the name of the reporter key, if omitted, the default reporter key is used",
+	)
+
+	// encryption applies to the local FS only.
+	cmd.MarkFlagsMutuallyExclusive(encryptFlag, configFlag)
+
+	// an imported key can only be stored once.
+	cmd.MarkFlagsMutuallyExclusive(keystoreFlag, numFlag)
 }
 
 // It validates the number of secrets to create and checks if the flags are valid.
@@ -124,6 +160,9 @@ func newParamsList(params initParams, num int) []initParams {
 		paramsList[i-1] = initParams{
 			dataDir:        fmt.Sprintf("%s%d", params.dataDir, i),
 			generatesECDSA: params.generatesECDSA,
+			encrypt:        params.encrypt,
+			passphraseFile: params.passphraseFile,
+			keyName:        params.keyName,
 		}
 	}
 
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+// Defining flag names for data directory, config path and keystore passphrase file.
+const (
+	dataDirFlag        = "data-dir"
+	configFlag         = "config"
+	passphraseFileFlag = "passphrase-file"
+	keyNameFlag        = "key-name"
+)
+
+// Errors related to missing parameters and missing keys.
+var (
+	errInvalidParams = errors.New("no config file or data directory passed in")
+)
+
+// Holds parameters including data directory, config path,
+// keystore passphrase file and secrets manager.
+type outputParams struct {
+	dataDir        string
+	configPath     string
+	passphraseFile string
+	keyName        string
+	secretsManager secrets.SecretsManager
+}
+
+// Checks if the parameters are valid.
+// It ensures that either a data directory or a config path is provided.
+func (op *outputParams) validateFlags() error {
+	if op.dataDir == "" && op.configPath == "" {
+		return errInvalidParams
+	}
+
+	return secrets.ValidateReporterKeyName(op.keyName)
+}
+
+// Initializes the secrets manager based on the config path
+// or initializes a local secrets manager if no config path is provided.
+func (op *outputParams) initSecretsManager() error {
+	var err error
+
+	op.secretsManager, err = helperSecret.SetupSecretsManager(op.dataDir, op.configPath, false, op.passphraseFile)
+
+	return err
+}
+
+// Retrieves the reporter address and public key, without revealing the private key.
+func (op *outputParams) getResult() (command.CommandResult, error) {
+	secretName := secrets.NamedReporterKey(op.keyName)
+
+	if !op.secretsManager.HasSecret(secretName) {
+		return nil, fmt.Errorf(`secrets "%s" has not been initialized`, secretName)
+	}
+
+	publicKey, err := helperSecret.LoadValidatorPublicKey(op.secretsManager, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretsOutputResult{
+		Address:   crypto.PubKeyToAddress(publicKey).String(),
+		PublicKey: hex.EncodeToHex(crypto.MarshalPublicKey(publicKey)),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type SecretsOutputResult struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+func (r *SecretsOutputResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SECRETS OUTPUT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Public key (address)|%s", r.Address),
+		fmt.Sprintf("Public key|%s", r.PublicKey),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+var (
+	params outputParams
+)
+
+// Returns a Cobra command for printing the reporter address and public key.
+func GetCommand() *cobra.Command {
+	secretsOutputCmd := &cobra.Command{
+		Use:     "output",
+		Short:   "Prints the reporter address and public key held by the specified Secrets Manager, without revealing the private key",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsOutputCmd)
+
+	return secretsOutputCmd
+}
+
+// Configures flags for the given Cobra command.
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the SX Reporter Node data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the keystore passphrase file of an encrypted local key, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyName,
+		keyNameFlag,
+		"",
+		"the name of the reporter key, if omitted, the default reporter key is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+// Checks if the flags are valid.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Loads the reporter key from the secrets manager and outputs its address and public key.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSecretsManager(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	res, err := params.getResult()
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(res)
+}
@@ -0,0 +1,79 @@
+package rotate
+
+import (
+	"errors"
+
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/types"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+// Defining flag names for data directory, config path, local key encryption and keystore passphrase file.
+const (
+	dataDirFlag        = "data-dir"
+	configFlag         = "config"
+	encryptFlag        = "encrypt"
+	passphraseFileFlag = "passphrase-file"
+	keyNameFlag        = "key-name"
+)
+
+// Errors related to missing parameters.
+var (
+	errInvalidParams = errors.New("no config file or data directory passed in")
+)
+
+// Holds rotation parameters including data directory, config path, local key encryption,
+// keystore passphrase file and secrets manager.
+type rotateParams struct {
+	dataDir        string
+	configPath     string
+	encrypt        bool
+	passphraseFile string
+	keyName        string
+	secretsManager secrets.SecretsManager
+	oldAddress     types.Address
+	newAddress     types.Address
+	archiveName    string
+}
+
+// Checks if the rotation parameters are valid.
+// It ensures that either a data directory or a config path is provided.
+func (rp *rotateParams) validateFlags() error {
+	if rp.dataDir == "" && rp.configPath == "" {
+		return errInvalidParams
+	}
+
+	return secrets.ValidateReporterKeyName(rp.keyName)
+}
+
+// Initializes the secrets manager based on the config path
+// or initializes a local secrets manager if no config path is provided.
+func (rp *rotateParams) initSecretsManager() error {
+	var err error
+
+	rp.secretsManager, err = helperSecret.SetupSecretsManager(rp.dataDir, rp.configPath, rp.encrypt, rp.passphraseFile)
+
+	return err
+}
+
+// Archives the current validator key and replaces it with a newly generated one.
+func (rp *rotateParams) rotateKey() error {
+	var err error
+
+	rp.oldAddress, rp.newAddress, rp.archiveName, err = helperSecret.RotateECDSAValidatorKey(
+		rp.secretsManager,
+		secrets.NamedReporterKey(rp.keyName),
+	)
+
+	return err
+}
+
+// Retrieves the results of the rotation, including the old and new validator addresses and the archive name.
+func (rp *rotateParams) getResult() command.CommandResult {
+	return &SecretsRotateResult{
+		OldAddress: rp.oldAddress.String(),
+		NewAddress: rp.newAddress.String(),
+		Archive:    rp.archiveName,
+	}
+}
@@ -0,0 +1,68 @@
+package rotate
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/types"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	helperSecret "github.com/sx-network/sx-reporter/infra/secrets/helper"
+)
+
+func TestValidateFlags(t *testing.T) {
+	if err := (&rotateParams{}).validateFlags(); !errors.Is(err, errInvalidParams) {
+		t.Errorf("validateFlags() error = %v, want %v", err, errInvalidParams)
+	}
+
+	if err := (&rotateParams{dataDir: "data", keyName: "not a name"}).validateFlags(); err == nil {
+		t.Error("validateFlags() error = nil for an invalid key name")
+	}
+
+	if err := (&rotateParams{configPath: "secrets.json", keyName: "hot"}).validateFlags(); err != nil {
+		t.Errorf("validateFlags() error = %v", err)
+	}
+}
+
+func TestRotateKey(t *testing.T) {
+	params := &rotateParams{dataDir: filepath.Join(t.TempDir(), "data")}
+
+	if err := params.initSecretsManager(); err != nil {
+		t.Fatalf("initSecretsManager() error = %v", err)
+	}
+
+	// only initialized keys can be rotated
+	if err := params.rotateKey(); err == nil {
+		t.Fatal("rotateKey() error = nil for a missing key")
+	}
+
+	initAddress, err := helperSecret.InitECDSAValidatorKey(params.secretsManager, secrets.ReporterKey)
+	if err != nil {
+		t.Fatalf("InitECDSAValidatorKey() error = %v", err)
+	}
+
+	if err := params.rotateKey(); err != nil {
+		t.Fatalf("rotateKey() error = %v", err)
+	}
+
+	if params.oldAddress != initAddress || params.newAddress == initAddress || params.newAddress == types.ZeroAddress {
+		t.Fatalf("rotated %s to %s, want %s rotated to a new address", params.oldAddress, params.newAddress, initAddress)
+	}
+
+	if address, err := helperSecret.LoadValidatorAddress(params.secretsManager, secrets.ReporterKey); err != nil ||
+		address != params.newAddress {
+		t.Errorf("reporter key address = %s, %v, want %s", address, err, params.newAddress)
+	}
+
+	// the old key stays available in its archive
+	if address, err := helperSecret.LoadValidatorAddress(params.secretsManager, params.archiveName); err != nil ||
+		address != initAddress {
+		t.Errorf("archived key %q address = %s, %v, want %s", params.archiveName, address, err, initAddress)
+	}
+
+	result, ok := params.getResult().(*SecretsRotateResult)
+	if !ok || result.OldAddress != initAddress.String() || result.NewAddress != params.newAddress.String() ||
+		result.Archive != params.archiveName {
+		t.Errorf("getResult() = %+v, want the old and new addresses and the archive", params.getResult())
+	}
+}
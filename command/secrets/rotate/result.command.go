@@ -0,0 +1,28 @@
+package rotate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/sx-network/sx-reporter/command/helper"
+)
+
+type SecretsRotateResult struct {
+	OldAddress string `json:"old_address"`
+	NewAddress string `json:"new_address"`
+	Archive    string `json:"archive"`
+}
+
+func (r *SecretsRotateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SECRETS ROTATE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Old public key (address)|%s", r.OldAddress),
+		fmt.Sprintf("New public key (address)|%s", r.NewAddress),
+		fmt.Sprintf("Archived old key as|%s", r.Archive),
+	}))
+	buffer.WriteString("\n\nThe reporter roles and staking of the old address have to be moved to the new address.\n")
+
+	return buffer.String()
+}
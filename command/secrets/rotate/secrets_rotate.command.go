@@ -0,0 +1,94 @@
+package rotate
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+)
+
+var (
+	params rotateParams
+)
+
+// Returns a Cobra command for rotating the reporter key.
+func GetCommand() *cobra.Command {
+	secretsRotateCmd := &cobra.Command{
+		Use:     "rotate",
+		Short:   "Replaces the private key of the SX Reporter node with a new one, archiving the old key in the specified Secrets Manager", //nolint:lll
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(secretsRotateCmd)
+
+	return secretsRotateCmd
+}
+
+// Configures flags for the given Cobra command. It sets up flags for
+// data directory, config path, local key encryption and keystore passphrase file.
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the SX Reporter Node data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.encrypt,
+		encryptFlag,
+		false,
+		"the flag indicating whether the new and archived keys are stored in encrypted JSON keystores, only for the local FS",
+	)
+
+	cmd.Flags().StringVar(
+		&params.passphraseFile,
+		passphraseFileFlag,
+		"",
+		"the path to the keystore passphrase file, if omitted, the passphrase is read from "+
+			keystore.PassphraseEnvVar+" or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.keyName,
+		keyNameFlag,
+		"",
+		"the name of the reporter key, if omitted, the default reporter key is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+	cmd.MarkFlagsMutuallyExclusive(encryptFlag, configFlag)
+}
+
+// Checks if the flags are valid.
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+// Rotates the key in the secrets manager.
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSecretsManager(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.rotateKey(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -2,7 +2,11 @@ package secrets
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command/secrets/export"
+	"github.com/sx-network/sx-reporter/command/secrets/importkey"
 	initCmd "github.com/sx-network/sx-reporter/command/secrets/init"
+	"github.com/sx-network/sx-reporter/command/secrets/output"
+	"github.com/sx-network/sx-reporter/command/secrets/rotate"
 )
 
 func GetCommand() *cobra.Command {
@@ -19,5 +23,9 @@ func GetCommand() *cobra.Command {
 func registerSubcommands(baseCmd *cobra.Command) {
 	baseCmd.AddCommand(
 		initCmd.GetCommand(),
+		importkey.GetCommand(),
+		export.GetCommand(),
+		output.GetCommand(),
+		rotate.GetCommand(),
 	)
 }
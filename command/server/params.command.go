@@ -0,0 +1,106 @@
+package server
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/infra/server"
+)
+
+// Defining flag names overriding options of the config file.
+const (
+	logLevelFlag       = "log-level"
+	dataDirFlag        = "data-dir"
+	rpcURLFlag         = "rpc-url"
+	wsRPCURLFlag       = "ws-rpc-url"
+	operatorAddrFlag   = "operator-addr"
+	prometheusAddrFlag = "prometheus-addr"
+)
+
+// Holds the config options overridden by flags, which take precedence over the config file and environment.
+type overrideParams struct {
+	logLevel       string
+	dataDir        string
+	rpcURL         string
+	wsRPCURL       string
+	operatorAddr   string
+	prometheusAddr string
+}
+
+// Registers the override flags on the command.
+func (op *overrideParams) setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&op.logLevel,
+		logLevelFlag,
+		"",
+		"the log level, overrides 'log_level' of the config",
+	)
+
+	cmd.Flags().StringVar(
+		&op.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory, overrides 'data_dir' of the config",
+	)
+
+	cmd.Flags().StringVar(
+		&op.rpcURL,
+		rpcURLFlag,
+		"",
+		"the JSON-RPC HTTP endpoint, overrides 'reporter.rpc_url' of the config",
+	)
+
+	cmd.Flags().StringVar(
+		&op.wsRPCURL,
+		wsRPCURLFlag,
+		"",
+		"the JSON-RPC WebSocket endpoint, overrides 'reporter.ws_rpc_url' of the config",
+	)
+
+	cmd.Flags().StringVar(
+		&op.operatorAddr,
+		operatorAddrFlag,
+		"",
+		"the listen address of the operator API, overrides 'operator_addr' of the config",
+	)
+
+	cmd.Flags().StringVar(
+		&op.prometheusAddr,
+		prometheusAddrFlag,
+		"",
+		"the listen address of the prometheus metrics endpoint, overrides 'prometheus_addr' of the config",
+	)
+}
+
+// Applies the flags set on the command to the config.
+func (op *overrideParams) apply(cmd *cobra.Command, config *server.YAMLServerConfig) {
+	if cmd.Flags().Changed(logLevelFlag) {
+		config.LogLevel = op.logLevel
+	}
+
+	if cmd.Flags().Changed(dataDirFlag) {
+		config.DataDir = op.dataDir
+	}
+
+	if cmd.Flags().Changed(operatorAddrFlag) {
+		config.OperatorAddr = op.operatorAddr
+	}
+
+	if cmd.Flags().Changed(prometheusAddrFlag) {
+		config.PrometheusAddr = op.prometheusAddr
+	}
+
+	if !cmd.Flags().Changed(rpcURLFlag) && !cmd.Flags().Changed(wsRPCURLFlag) {
+		return
+	}
+
+	if config.YAMLReporterConfig == nil {
+		config.YAMLReporterConfig = &server.YAMLReporterConfig{}
+	}
+
+	if cmd.Flags().Changed(rpcURLFlag) {
+		config.YAMLReporterConfig.RPCURL = op.rpcURL
+	}
+
+	if cmd.Flags().Changed(wsRPCURLFlag) {
+		config.YAMLReporterConfig.WSRPCURL = op.wsRPCURL
+	}
+}
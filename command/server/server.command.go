@@ -1,13 +1,14 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/sx-network/sx-reporter/command"
 	"github.com/sx-network/sx-reporter/command/flags"
 	"github.com/sx-network/sx-reporter/command/helper"
+	"github.com/sx-network/sx-reporter/infra/common"
 	"github.com/sx-network/sx-reporter/infra/server"
 )
 
@@ -15,6 +16,7 @@ var (
 	serverParams = &server.YAMLServerConfig{
 		YAMLReporterConfig: &server.YAMLReporterConfig{},
 	}
+	overrides = &overrideParams{}
 )
 
 // This command starts the SX Reporter client.
@@ -27,26 +29,27 @@ func GetCommand() *cobra.Command {
 	}
 
 	flags.SetConfigPathFlag(serverCommand, &serverParams.ConfigPath)
+	overrides.setFlags(serverCommand)
 
 	return serverCommand
 }
 
 // The pre-run hook function for Cobra commands.
-// It sets the JSON log format based on the command's flag,
-// and initializes configuration parameters from a specified file,
-// if a config file is specified in the command.
-// It returns an error if there's an issue initializing the config from file.
+// It loads the specified config file with the SXR_ environment variable overrides and defaults applied,
+// then applies the flag overrides, such as the JSON log format.
+// It returns an error if there's an issue reading the config or if the resulting config is invalid.
 func runPreRun(cmd *cobra.Command, _ []string) error {
-	serverParams.SetJSONLogFormat(flags.IsJSONOutputFlagChanged(cmd))
+	if !flags.IsConfigPathSpecified(cmd) {
+		return fmt.Errorf("config file not specified. Please provide a path to the CLI config using the '--%s' flag", flags.ConfigPathFlag)
+	}
 
-	if flags.IsConfigPathSpecified(cmd) {
-		if err := serverParams.InitServerConfigFromFile(); err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("config.yml file not specified. Please provide a path to the CLI config using the '--%s' flag", flags.ConfigPathFlag)
+	config, err := loadConfig(cmd, serverParams.ConfigPath)
+	if err != nil {
+		return err
 	}
 
+	serverParams = config
+
 	if err := serverParams.InitRawParams(); err != nil {
 		return err
 	}
@@ -54,11 +57,31 @@ func runPreRun(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// Loads and validates the config file at the path, applying the environment and flag overrides.
+func loadConfig(cmd *cobra.Command, path string) (*server.YAMLServerConfig, error) {
+	config, err := server.LoadConfig(path, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides.apply(cmd, config)
+
+	if flags.IsJSONOutputFlagChanged(cmd) {
+		config.SetJSONLogFormat(true)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", path, err)
+	}
+
+	return config, nil
+}
+
 // The main function executed when a command is run in the CLI.
 func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 
-	if err := runServerLoop(serverParams.GenerateConfig(), outputter); err != nil {
+	if err := runServerLoop(cmd, serverParams.GenerateConfig(), outputter); err != nil {
 		outputter.SetError(err)
 		outputter.WriteOutput()
 
@@ -68,17 +91,37 @@ func runCommand(cmd *cobra.Command, _ []string) {
 
 // Simulates the main server loop with a provided configuration and outputter.
 // It takes a ServerConfig containing server configuration details and an OutputFormatter for formatting output.
+// The config file is reloaded on SIGHUP until the server is shut down.
 func runServerLoop(
+	cmd *cobra.Command,
 	serverConfig *server.ServerConfig,
 	outputter command.OutputFormatter,
 ) error {
-	jsonBytes, _ := json.Marshal(serverConfig)
-	fmt.Println(string(jsonBytes))
-
 	serverInstance, err := server.NewServer(serverConfig)
 	if err != nil {
 		return err
 	}
 
+	go handleReloadSignals(cmd, serverInstance)
+
 	return helper.HandleSignals(serverInstance.Close, outputter)
 }
+
+// Re-reads the config file on each SIGHUP and applies its safe changes to the running server.
+// Invalid configs are logged and leave the running config unchanged.
+func handleReloadSignals(cmd *cobra.Command, serverInstance *server.ServerConfig) {
+	for range common.GetReloadSignalCh() {
+		serverInstance.Logger.Info("reloading config", "path", serverParams.ConfigPath)
+
+		config, err := loadConfig(cmd, serverParams.ConfigPath)
+		if err != nil {
+			serverInstance.Logger.Error("failed to reload config, keeping the running config", "err", err)
+
+			continue
+		}
+
+		if err := serverInstance.Reload(config); err != nil {
+			serverInstance.Logger.Error("failed to reload config, keeping the running config", "err", err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/helper/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// ECDSAKeyLength is the length of a serialized secp256k1 private key
+const ECDSAKeyLength = 32
+
+// S256 is the secp256k1 elliptic curve
+var S256 = btcec.S256()
+
+var (
+	ErrInvalidKeyLength = fmt.Errorf("invalid key length, should be %dB", ECDSAKeyLength)
+	ErrInvalidKey       = errors.New("invalid key, should be in range [1, N-1] of the secp256k1 curve order")
+)
+
+// generateECDSAKeyAndMarshal generates a new ECDSA private key and serializes it to a byte array
+func generateECDSAKeyAndMarshal() ([]byte, error) {
+	key, err := GenerateECDSAKey()
+	if err != nil {
+		return nil, err
+	}
+
+	defer ZeroKey(key)
+
+	return MarshalECDSAPrivateKey(key)
+}
+
+// MarshalECDSAPrivateKey serializes the private key's D value to a 32B big-endian []byte
+func MarshalECDSAPrivateKey(priv *ecdsa.PrivateKey) ([]byte, error) {
+	return (*btcec.PrivateKey)(priv).Serialize(), nil
+}
+
+// GenerateECDSAKey generates a new key based on the secp256k1 elliptic curve.
+func GenerateECDSAKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(S256, rand.Reader)
+}
+
+// GenerateAndEncodeECDSAPrivateKey returns a newly generated private key and the hex encoding of that private key
+func GenerateAndEncodeECDSAPrivateKey() (*ecdsa.PrivateKey, []byte, error) {
+	keyBuff, err := keystore.CreatePrivateKey(generateECDSAKeyAndMarshal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := BytesToECDSAPrivateKey(keyBuff)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to execute byte array -> private key conversion, %w", err)
+	}
+
+	return privateKey, keyBuff, nil
+}
+
+// BytesToECDSAPrivateKey reads the hex encoded input byte array and constructs a private key if possible.
+// The decoded key buffer is zeroed once the key is parsed
+func BytesToECDSAPrivateKey(input []byte) (*ecdsa.PrivateKey, error) {
+	decoded := make([]byte, hex.DecodedLen(len(input)))
+	defer ZeroBytes(decoded)
+
+	if _, err := hex.Decode(decoded, input); err != nil {
+		return nil, err
+	}
+
+	return ParseECDSAPrivateKey(decoded)
+}
+
+// ParseECDSAPrivateKey constructs a private key from its 32B big-endian serialization.
+// The key is checked in constant time to be in the range [1, N-1] of the curve order
+func ParseECDSAPrivateKey(buf []byte) (*ecdsa.PrivateKey, error) {
+	if len(buf) != ECDSAKeyLength {
+		return nil, fmt.Errorf("%w, got %dB", ErrInvalidKeyLength, len(buf))
+	}
+
+	var scalar secp256k1.ModNScalar
+	defer scalar.Zero()
+
+	if overflow := scalar.SetByteSlice(buf); overflow || scalar.IsZero() {
+		return nil, ErrInvalidKey
+	}
+
+	prv, _ := btcec.PrivKeyFromBytes(S256, buf)
+
+	return prv.ToECDSA(), nil
+}
+
+// ZeroKey overwrites the private key's D value, so that it doesn't linger in memory once no longer needed
+func ZeroKey(priv *ecdsa.PrivateKey) {
+	if priv == nil || priv.D == nil {
+		return
+	}
+
+	words := priv.D.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+
+	priv.D.SetInt64(0)
+}
+
+// ZeroBytes overwrites the buffer holding key material
+func ZeroBytes(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+}
+
+// PubKeyToAddress returns the Ethereum address of a public key
+func PubKeyToAddress(pub *ecdsa.PublicKey) types.Address {
+	buf := Keccak256(MarshalPublicKey(pub)[1:])[12:]
+
+	return types.BytesToAddress(buf)
+}
+
+// Keccak256 calculates the Keccak256
+func Keccak256(v ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, i := range v {
+		h.Write(i)
+	}
+
+	return h.Sum(nil)
+}
+
+// MarshalPublicKey marshals a public key on the secp256k1 elliptic curve.
+func MarshalPublicKey(pub *ecdsa.PublicKey) []byte {
+	return elliptic.Marshal(S256, pub.X, pub.Y)
+}
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/types"
+)
+
+const (
+	// secp256k1 curve order N
+	curveOrderHex = "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"
+	// Generator point G, the public key of the private key 1
+	generatorHex = "04" +
+		"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+)
+
+func TestBytesToECDSAPrivateKeyAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		address   string
+		publicKey string
+	}{
+		{
+			"one",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			generatorHex,
+		},
+		{
+			"web3 accounts vector",
+			"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			"0x2c7536E3605D9C16a7a3D7b1898e529396a65c23",
+			"044e3b81af9c2234cad09d679ce6035ed1392347ce64ce405f5dcd36228a25de6e" +
+				"47fd35c4215d1edf53e6f83de344615ce719bdb0fd878f6ed76f06dd277956de",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := BytesToECDSAPrivateKey([]byte(tt.key))
+			if err != nil {
+				t.Fatalf("BytesToECDSAPrivateKey() error = %v", err)
+			}
+
+			if address := PubKeyToAddress(&key.PublicKey); address != types.StringToAddress(tt.address) {
+				t.Errorf("PubKeyToAddress() = %s, want %s", address, tt.address)
+			}
+
+			if publicKey := hex.EncodeToString(MarshalPublicKey(&key.PublicKey)); publicKey != tt.publicKey {
+				t.Errorf("MarshalPublicKey() = %s, want %s", publicKey, tt.publicKey)
+			}
+
+			serialized, err := MarshalECDSAPrivateKey(key)
+			if err != nil || hex.EncodeToString(serialized) != tt.key {
+				t.Errorf("MarshalECDSAPrivateKey() = %x, %v, want %s", serialized, err, tt.key)
+			}
+		})
+	}
+}
+
+func TestParseECDSAPrivateKeyRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{"zero", "0000000000000000000000000000000000000000000000000000000000000000", ErrInvalidKey},
+		{"curve order", curveOrderHex, ErrInvalidKey},
+		{"curve order plus one", "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364142", ErrInvalidKey},
+		{"all ones", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", ErrInvalidKey},
+		{"curve order minus one", "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140", nil},
+		{"short", "01", ErrInvalidKeyLength},
+		{"long", "00" + curveOrderHex, ErrInvalidKeyLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := hex.DecodeString(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ParseECDSAPrivateKey(buf); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseECDSAPrivateKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBytesToECDSAPrivateKeyInvalidHex(t *testing.T) {
+	if _, err := BytesToECDSAPrivateKey([]byte("0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f3623")); err == nil {
+		t.Error("BytesToECDSAPrivateKey() error = nil, want a hex decoding error")
+	}
+}
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		name  string
+		input [][]byte
+		hash  string
+	}{
+		{"empty", nil, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", [][]byte{[]byte("abc")}, "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"abc in parts", [][]byte{[]byte("a"), []byte("bc")}, "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hash := hex.EncodeToString(Keccak256(tt.input...)); hash != tt.hash {
+				t.Errorf("Keccak256() = %s, want %s", hash, tt.hash)
+			}
+		})
+	}
+}
+
+func TestZeroKey(t *testing.T) {
+	key, err := GenerateECDSAKey()
+	if err != nil {
+		t.Fatalf("GenerateECDSAKey() error = %v", err)
+	}
+
+	words := key.D.Bits()
+
+	ZeroKey(key)
+
+	if key.D.Sign() != 0 {
+		t.Errorf("D = %s after ZeroKey(), want 0", key.D)
+	}
+
+	for i, word := range words {
+		if word != 0 {
+			t.Fatalf("word %d of D = %x after ZeroKey(), want 0", i, word)
+		}
+	}
+
+	ZeroKey(nil)
+}
@@ -0,0 +1,106 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyPassphrase = errors.New("keystore passphrase must not be empty")
+)
+
+// IsEncryptedKey checks whether the data is an encrypted JSON keystore
+// rather than a plaintext hex encoded private key
+func IsEncryptedKey(data []byte) bool {
+	var keyJSON struct {
+		Crypto  json.RawMessage `json:"crypto"`
+		Version int             `json:"version"`
+	}
+
+	if err := json.Unmarshal(data, &keyJSON); err != nil {
+		return false
+	}
+
+	return keyJSON.Crypto != nil && keyJSON.Version == 3
+}
+
+// EncryptKey encrypts a hex encoded private key into the Web3 Secret Storage (v3) JSON keystore format,
+// using scrypt with the standard parameters
+func EncryptKey(encodedKey []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+
+	buf, err := hex.DecodeString(string(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode private key, %w", err)
+	}
+
+	privateKey, err := crypto.ToECDSA(buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key, %w", err)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	return keystore.EncryptKey(key, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// DecryptKey decrypts a Web3 Secret Storage JSON keystore, using either the scrypt
+// or the pbkdf2 key derivation function, and returns the hex encoded private key
+func DecryptKey(keyJSON []byte, passphrase string) ([]byte, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt keystore, %w", err)
+	}
+
+	return []byte(hex.EncodeToString(crypto.FromECDSA(key.PrivateKey))), nil
+}
+
+// ReadKeystoreFile imports the JSON keystore file at the specified path
+// and returns the hex encoded private key
+func ReadKeystoreFile(path string, passphrase string) ([]byte, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read keystore from disk (%s), %w", path, err)
+	}
+
+	return DecryptKey(keyJSON, passphrase)
+}
+
+// WriteKeystoreFile exports the hex encoded private key to a JSON keystore file at the specified path,
+// readable only by the current user. Existing files are not overwritten
+func WriteKeystoreFile(path string, encodedKey []byte, passphrase string) error {
+	keyJSON, err := EncryptKey(encodedKey, passphrase)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create keystore on disk (%s), %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(keyJSON); err != nil {
+		return fmt.Errorf("unable to write keystore to disk (%s), %w", path, err)
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,148 @@
+package keystore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestEncryptDecryptKey(t *testing.T) {
+	keyJSON, err := EncryptKey([]byte(testKey), "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptKey() error = %v", err)
+	}
+
+	if !IsEncryptedKey(keyJSON) {
+		t.Fatalf("IsEncryptedKey(%s) = false", keyJSON)
+	}
+
+	if key, err := DecryptKey(keyJSON, "correct horse"); err != nil || string(key) != testKey {
+		t.Errorf("DecryptKey() = %s, %v, want %s", key, err, testKey)
+	}
+
+	if _, err := DecryptKey(keyJSON, "wrong horse"); err == nil {
+		t.Error("DecryptKey() error = nil with the wrong passphrase")
+	}
+
+	if _, err := EncryptKey([]byte(testKey), ""); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Errorf("EncryptKey() error = %v, want %v", err, ErrEmptyPassphrase)
+	}
+
+	if _, err := EncryptKey([]byte("not hex"), "correct horse"); err == nil {
+		t.Error("EncryptKey() error = nil for an invalid private key")
+	}
+}
+
+func TestIsEncryptedKey(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"plaintext key", testKey, false},
+		{"v3 keystore", `{"version":3,"crypto":{"cipher":"aes-128-ctr"}}`, true},
+		{"other version", `{"version":1,"crypto":{"cipher":"aes-128-ctr"}}`, false},
+		{"missing crypto", `{"version":3}`, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsEncryptedKey([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: IsEncryptedKey() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteReadKeystoreFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	if err := WriteKeystoreFile(path, []byte(testKey), "correct horse"); err != nil {
+		t.Fatalf("WriteKeystoreFile() error = %v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("keystore file = %v, %v, want a file readable only by the current user", info, err)
+	}
+
+	// existing keystores are not overwritten
+	if err := WriteKeystoreFile(path, []byte(testKey), "correct horse"); err == nil {
+		t.Error("WriteKeystoreFile() error = nil for an existing file")
+	}
+
+	if key, err := ReadKeystoreFile(path, "correct horse"); err != nil || string(key) != testKey {
+		t.Errorf("ReadKeystoreFile() = %s, %v, want %s", key, err, testKey)
+	}
+}
+
+func TestReadPassphrase(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	t.Setenv(PassphraseEnvVar, "from env")
+
+	tests := []struct {
+		name           string
+		passphraseFile string
+		want           string
+		wantErr        error
+	}{
+		{"file with trailing newline", writeFile("newline", "from file\r\n"), "from file", nil},
+		{"file keeps inner whitespace", writeFile("spaces", " from file "), " from file ", nil},
+		{"empty file", writeFile("empty", "\n"), "", ErrEmptyPassphrase},
+		{"environment variable", "", "from env", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passphrase, err := ReadPassphrase(tt.passphraseFile, false)
+			if passphrase != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadPassphrase() = %q, %v, want %q, %v", passphrase, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ReadPassphrase(filepath.Join(dir, "missing"), false); err == nil {
+		t.Error("ReadPassphrase() error = nil for a missing file")
+	}
+
+	t.Setenv(PassphraseEnvVar, "")
+
+	if _, err := ReadPassphrase("", false); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Errorf("ReadPassphrase() error = %v, want %v", err, ErrEmptyPassphrase)
+	}
+}
+
+func TestReadExportPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte("from file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(PassphraseEnvVar, "local")
+	t.Setenv(ExportPassphraseEnvVar, "from env")
+
+	if passphrase, err := ReadExportPassphrase(path); passphrase != "from file" || err != nil {
+		t.Errorf("ReadExportPassphrase() = %q, %v, want from file", passphrase, err)
+	}
+
+	// the local keystore passphrase variable is not read
+	if passphrase, err := ReadExportPassphrase(""); passphrase != "from env" || err != nil {
+		t.Errorf("ReadExportPassphrase() = %q, %v, want from env", passphrase, err)
+	}
+
+	t.Setenv(ExportPassphraseEnvVar, "")
+
+	if _, err := ReadExportPassphrase(""); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Errorf("ReadExportPassphrase() error = %v, want %v", err, ErrEmptyPassphrase)
+	}
+}
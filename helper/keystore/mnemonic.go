@@ -0,0 +1,54 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the BIP-44 derivation path of the first Ethereum account
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid BIP-39 mnemonic")
+)
+
+// DeriveKeyFromMnemonic derives the private key at the BIP-32 derivation path from the BIP-39 mnemonic
+// and optional mnemonic password, and returns the hex encoded private key
+func DeriveKeyFromMnemonic(mnemonic string, password string, derivationPath string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path (%s), %w", derivationPath, err)
+	}
+
+	seed := bip39.NewSeed(mnemonic, password)
+
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive master key, %w", err)
+	}
+
+	for _, index := range path {
+		if key, err = key.Derive(index); err != nil {
+			return nil, fmt.Errorf("unable to derive key (%s), %w", derivationPath, err)
+		}
+	}
+
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key (%s), %w", derivationPath, err)
+	}
+
+	return []byte(hex.EncodeToString(privateKey.Serialize())), nil
+}
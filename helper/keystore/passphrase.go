@@ -0,0 +1,119 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnvVar is the environment variable the keystore passphrase is read from
+// if no passphrase file is specified
+const PassphraseEnvVar = "SXR_KEYSTORE_PASSPHRASE"
+
+// ExportPassphraseEnvVar is the environment variable the passphrase of an exported keystore is read from
+// if no output passphrase file is specified, so that it is kept apart from the local keystore passphrase
+const ExportPassphraseEnvVar = "SXR_EXPORT_PASSPHRASE"
+
+var (
+	ErrNoPassphrase       = errors.New("no keystore passphrase provided, use a passphrase file, " + PassphraseEnvVar + " or an interactive terminal")                        //nolint:lll
+	ErrNoExportPassphrase = errors.New("no exported keystore passphrase provided, use an output passphrase file, " + ExportPassphraseEnvVar + " or an interactive terminal") //nolint:lll
+	ErrPassphraseMismatch = errors.New("keystore passphrases do not match")
+)
+
+// Describes where a passphrase is read from when no passphrase file is specified
+type passphraseSource struct {
+	envVar          string // Environment variable holding the passphrase
+	prompt          string // Prompt for the passphrase
+	repeatPrompt    string // Prompt for the passphrase confirmation
+	errNoPassphrase error  // Error returned if the passphrase cannot be read
+}
+
+// ReadPassphrase reads the keystore passphrase from the passphrase file if specified,
+// then from the passphrase environment variable, and finally prompts for it if stdin is a terminal.
+// When confirm is set, a prompted passphrase has to be entered twice
+func ReadPassphrase(passphraseFile string, confirm bool) (string, error) {
+	return readPassphrase(passphraseFile, &passphraseSource{
+		envVar:          PassphraseEnvVar,
+		prompt:          "Keystore passphrase: ",
+		repeatPrompt:    "Repeat keystore passphrase: ",
+		errNoPassphrase: ErrNoPassphrase,
+	}, confirm)
+}
+
+// ReadExportPassphrase reads the passphrase of an exported keystore from the passphrase file if specified,
+// then from the export passphrase environment variable, and finally prompts for it twice if stdin is a terminal
+func ReadExportPassphrase(passphraseFile string) (string, error) {
+	return readPassphrase(passphraseFile, &passphraseSource{
+		envVar:          ExportPassphraseEnvVar,
+		prompt:          "Exported keystore passphrase: ",
+		repeatPrompt:    "Repeat exported keystore passphrase: ",
+		errNoPassphrase: ErrNoExportPassphrase,
+	}, true)
+}
+
+// readPassphrase reads a passphrase from the passphrase file if specified, or else from the given source
+func readPassphrase(passphraseFile string, source *passphraseSource, confirm bool) (string, error) {
+	if passphraseFile != "" {
+		buf, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read keystore passphrase file (%s), %w", passphraseFile, err)
+		}
+
+		passphrase := strings.TrimRight(string(buf), "\r\n")
+		if passphrase == "" {
+			return "", ErrEmptyPassphrase
+		}
+
+		return passphrase, nil
+	}
+
+	if passphrase, ok := os.LookupEnv(source.envVar); ok {
+		if passphrase == "" {
+			return "", ErrEmptyPassphrase
+		}
+
+		return passphrase, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", source.errNoPassphrase
+	}
+
+	passphrase, err := promptPassphrase(source.prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		return "", ErrEmptyPassphrase
+	}
+
+	if confirm {
+		repeated, err := promptPassphrase(source.repeatPrompt)
+		if err != nil {
+			return "", err
+		}
+
+		if repeated != passphrase {
+			return "", ErrPassphraseMismatch
+		}
+	}
+
+	return passphrase, nil
+}
+
+// promptPassphrase reads a passphrase from the terminal without echoing it
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+
+	buf, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", fmt.Errorf("unable to read keystore passphrase, %w", err)
+	}
+
+	return string(buf), nil
+}
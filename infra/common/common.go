@@ -48,12 +48,19 @@ func GetTerminationSignalCh() <-chan os.Signal {
 		signalCh,
 		os.Interrupt,
 		syscall.SIGTERM,
-		syscall.SIGHUP,
 	)
 
 	return signalCh
 }
 
+// GetReloadSignalCh returns a channel to emit SIGHUP signals requesting a config reload
+func GetReloadSignalCh() <-chan os.Signal {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP)
+
+	return signalCh
+}
+
 type ClientCloseResult struct {
 	Message string `json:"message"`
 }
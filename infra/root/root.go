@@ -5,7 +5,12 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/sx-network/sx-reporter/command/audit"
+	"github.com/sx-network/sx-reporter/command/config"
+	"github.com/sx-network/sx-reporter/command/crypto"
+	"github.com/sx-network/sx-reporter/command/emergency"
 	"github.com/sx-network/sx-reporter/command/flags"
+	"github.com/sx-network/sx-reporter/command/rewards"
 	"github.com/sx-network/sx-reporter/command/secrets"
 	serverCommand "github.com/sx-network/sx-reporter/command/server"
 )
@@ -34,6 +39,11 @@ func (rc *RootCommand) registerSubCommands() {
 	rc.baseCmd.AddCommand(
 		serverCommand.GetCommand(),
 		secrets.GetCommand(),
+		rewards.GetCommand(),
+		emergency.GetCommand(),
+		audit.GetCommand(),
+		crypto.GetCommand(),
+		config.GetCommand(),
 	)
 }
 
@@ -109,6 +109,25 @@ func (a *AwsSsmManager) SetSecret(name string, value []byte) error {
 	return nil
 }
 
+// UpdateSecret overwrites an existing secret on AWS SSM, which stores it as a new version of the parameter
+func (a *AwsSsmManager) UpdateSecret(name string, value []byte) error {
+	// Check if non-existent
+	if _, err := a.GetSecret(name); err != nil {
+		return err
+	}
+
+	if _, err := a.client.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(a.constructSecretPath(name)),
+		Value:     aws.String(string(value)),
+		Type:      aws.String(ssm.ParameterTypeSecureString),
+		Overwrite: aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("unable to update secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
 // HasSecret checks if the secret is present on AWS SSM ParameterStore
 func (a *AwsSsmManager) HasSecret(name string) bool {
 	_, err := a.GetSecret(name)
@@ -0,0 +1,288 @@
+package azurekeyvault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Constants representing the keys of the Azure Key Vault extra map.
+const (
+	// VaultURL is the URL of the key vault, such as https://<vault-name>.vault.azure.net
+	VaultURL = "vault-url"
+	// TenantID is the Azure AD tenant of the service principal, optional
+	TenantID = "tenant-id"
+	// ClientID is the client ID of the service principal or user-assigned managed identity, optional
+	ClientID = "client-id"
+	// ClientSecret is the client secret of the service principal, optional
+	ClientSecret = "client-secret"
+)
+
+const (
+	// Timeout applied to every Azure Key Vault request.
+	requestTimeout = 30 * time.Second
+	// Timeout for a secret deletion and purge to complete.
+	deletionTimeout = 2 * time.Minute
+	// Interval between checks for the completion of a secret deletion or purge.
+	deletionPollInterval = 2 * time.Second
+)
+
+// AzureKeyVaultManager is a SecretsManager that
+// stores secrets on Azure Key Vault
+type AzureKeyVaultManager struct {
+	// Local logger object
+	logger hclog.Logger
+
+	// The key vault URL
+	vaultURL string
+
+	// The service principal or managed identity credentials, optional
+	tenantID     string
+	clientID     string
+	clientSecret string
+
+	// The Azure Key Vault secrets client
+	client *azsecrets.Client
+
+	// The prefix of the secret names, the node name
+	secretPrefix string
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the node name is present
+	if config.Name == "" {
+		return nil, errors.New("no node name specified for Azure Key Vault secrets manager")
+	}
+
+	// Check if the extra map is present
+	if config.Extra == nil || config.Extra[VaultURL] == nil {
+		return nil, errors.New("required extra map containing 'vault-url' not found for azure-key-vault")
+	}
+
+	// Set up the base object
+	azureManager := &AzureKeyVaultManager{
+		logger:       params.Logger.Named(string(secrets.AzureKeyVault)),
+		vaultURL:     fmt.Sprintf("%v", config.Extra[VaultURL]),
+		tenantID:     extraString(config, TenantID),
+		clientID:     extraString(config, ClientID),
+		clientSecret: extraString(config, ClientSecret),
+		secretPrefix: config.Name,
+	}
+
+	// A client secret requires the service principal it belongs to
+	if azureManager.clientSecret != "" && (azureManager.tenantID == "" || azureManager.clientID == "") {
+		return nil, errors.New("'client-secret' requires 'tenant-id' and 'client-id' for azure-key-vault")
+	}
+
+	// Run the initial setup
+	if err := azureManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return azureManager, nil
+}
+
+// Setup sets up the Azure Key Vault secrets manager.
+// A service principal is used if a client secret is configured, a managed identity if only
+// a client ID is configured, and the default Azure credential chain otherwise
+func (a *AzureKeyVaultManager) Setup() error {
+	var (
+		credential azcore.TokenCredential
+		err        error
+	)
+
+	switch {
+	case a.clientSecret != "":
+		credential, err = azidentity.NewClientSecretCredential(a.tenantID, a.clientID, a.clientSecret, nil)
+	case a.clientID != "":
+		credential, err = azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{
+			ID: azidentity.ClientID(a.clientID),
+		})
+	default:
+		credential, err = azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			TenantID: a.tenantID,
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to initialize Azure credentials: %w", err)
+	}
+
+	client, err := azsecrets.NewClient(a.vaultURL, credential, nil)
+	if err != nil {
+		return fmt.Errorf("unable to initialize Azure Key Vault client: %w", err)
+	}
+
+	a.client = client
+
+	return nil
+}
+
+// constructSecretName is a helper method for constructing the name of the secret.
+// Key vault secret names may only contain alphanumeric characters and dashes
+func (a *AzureKeyVaultManager) constructSecretName(name string) string {
+	return fmt.Sprintf("%s-%s", a.secretPrefix, name)
+}
+
+// GetSecret fetches the latest version of a secret from Azure Key Vault
+func (a *AzureKeyVaultManager) GetSecret(name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response, err := a.client.GetSecret(ctx, a.constructSecretName(name), "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, secrets.ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read secret (%s), %w", name, err)
+	}
+
+	if response.Value == nil {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	return []byte(*response.Value), nil
+}
+
+// SetSecret saves a secret to Azure Key Vault
+func (a *AzureKeyVaultManager) SetSecret(name string, value []byte) error {
+	// Setting a secret adds a new version, so existing secrets are checked for to avoid overwriting them
+	if _, err := a.GetSecret(name); err == nil {
+		return fmt.Errorf("secret (%s) already initialized", name)
+	} else if !errors.Is(err, secrets.ErrSecretNotFound) {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	secretValue := string(value)
+
+	if _, err := a.client.SetSecret(ctx, a.constructSecretName(name), azsecrets.SetSecretParameters{
+		Value: &secretValue,
+	}, nil); err != nil {
+		return fmt.Errorf("unable to store secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// UpdateSecret adds a new version holding the value to an existing secret on Azure Key Vault
+func (a *AzureKeyVaultManager) UpdateSecret(name string, value []byte) error {
+	// Check if non-existent
+	if _, err := a.GetSecret(name); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	secretValue := string(value)
+
+	if _, err := a.client.SetSecret(ctx, a.constructSecretName(name), azsecrets.SetSecretParameters{
+		Value: &secretValue,
+	}, nil); err != nil {
+		return fmt.Errorf("unable to update secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the secret is present on Azure Key Vault
+func (a *AzureKeyVaultManager) HasSecret(name string) bool {
+	_, err := a.GetSecret(name)
+
+	return err == nil
+}
+
+// RemoveSecret removes a secret from Azure Key Vault.
+// The secret is purged once deleted if soft delete is enabled on the vault, as a soft-deleted secret
+// cannot be set again until it is purged. Purging requires the purge permission on the vault
+func (a *AzureKeyVaultManager) RemoveSecret(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deletionTimeout)
+	defer cancel()
+
+	secretName := a.constructSecretName(name)
+
+	response, err := a.client.DeleteSecret(ctx, secretName, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return secrets.ErrSecretNotFound
+		}
+
+		return fmt.Errorf("unable to delete secret (%s), %w", name, err)
+	}
+
+	// The secret is deleted for good if soft delete is disabled
+	if response.RecoveryID == nil {
+		return nil
+	}
+
+	// Deletion completes asynchronously, the deleted secret is found once it can be purged
+	if err := a.waitForDeletedSecret(ctx, secretName, true); err != nil {
+		return fmt.Errorf("unable to delete secret (%s), %w", name, err)
+	}
+
+	if _, err := a.client.PurgeDeletedSecret(ctx, secretName, nil); err != nil {
+		return fmt.Errorf("unable to purge deleted secret (%s), %w", name, err)
+	}
+
+	// Purging completes asynchronously too, the secret can be set again once it is no longer found
+	if err := a.waitForDeletedSecret(ctx, secretName, false); err != nil {
+		return fmt.Errorf("unable to purge deleted secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// waitForDeletedSecret polls Azure Key Vault until the deleted secret is found, or no longer found
+func (a *AzureKeyVaultManager) waitForDeletedSecret(ctx context.Context, secretName string, found bool) error {
+	ticker := time.NewTicker(deletionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := a.client.GetDeletedSecret(ctx, secretName, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+
+		if (err == nil) == found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// isNotFound checks whether the Azure request was rejected with a 404 response
+func isNotFound(err error) bool {
+	var responseErr *azcore.ResponseError
+
+	return errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound
+}
+
+// extraString returns the string value of the extra map key, or an empty string if it isn't set
+func extraString(config *secrets.SecretsManagerConfig, key string) string {
+	if config.Extra == nil || config.Extra[key] == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", config.Extra[key])
+}
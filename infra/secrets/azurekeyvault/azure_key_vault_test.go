@@ -0,0 +1,267 @@
+package azurekeyvault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Fake of the Azure Key Vault secrets REST API, following its challenge authentication and soft delete semantics.
+type fakeKeyVault struct {
+	secrets    map[string][]string // Versions of the secrets by name, the latest last.
+	deleted    map[string]bool     // Names of the soft-deleted secrets, pending purge.
+	softDelete bool                // Whether deleted secrets are kept until purged.
+	sync.Mutex
+}
+
+// Starts a key vault server backed by a fake.
+func newFakeKeyVault(t *testing.T, softDelete bool) (*httptest.Server, *fakeKeyVault) {
+	t.Helper()
+
+	vault := &fakeKeyVault{
+		secrets:    make(map[string][]string),
+		deleted:    make(map[string]bool),
+		softDelete: softDelete,
+	}
+
+	// the key vault client only sends bearer tokens over TLS
+	server := httptest.NewTLSServer(vault)
+	t.Cleanup(server.Close)
+
+	return server, vault
+}
+
+func (kv *fakeKeyVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.Lock()
+	defer kv.Unlock()
+
+	// requests are first sent without a token to elicit the authentication challenge
+	if r.Header.Get("Authorization") == "" {
+		w.Header().Set(
+			"WWW-Authenticate",
+			`Bearer authorization="https://login.microsoftonline.com/tenant", resource="https://vault.azure.net"`,
+		)
+		writeKeyVaultError(w, http.StatusUnauthorized, "Unauthorized")
+
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 {
+		writeKeyVaultError(w, http.StatusNotFound, "NotFound")
+
+		return
+	}
+
+	name := parts[1]
+
+	switch {
+	case parts[0] == "secrets" && r.Method == http.MethodGet:
+		versions := kv.secrets[name]
+		if len(versions) == 0 {
+			writeKeyVaultError(w, http.StatusNotFound, "SecretNotFound")
+
+			return
+		}
+
+		writeKeyVaultResponse(w, http.StatusOK, map[string]interface{}{"value": versions[len(versions)-1]})
+	case parts[0] == "secrets" && r.Method == http.MethodPut:
+		if kv.deleted[name] {
+			writeKeyVaultError(w, http.StatusConflict, "Conflict")
+
+			return
+		}
+
+		var request struct {
+			Value string `json:"value"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeKeyVaultError(w, http.StatusBadRequest, "BadParameter")
+
+			return
+		}
+
+		kv.secrets[name] = append(kv.secrets[name], request.Value)
+
+		writeKeyVaultResponse(w, http.StatusOK, map[string]interface{}{"value": request.Value})
+	case parts[0] == "secrets" && r.Method == http.MethodDelete:
+		if _, ok := kv.secrets[name]; !ok {
+			writeKeyVaultError(w, http.StatusNotFound, "SecretNotFound")
+
+			return
+		}
+
+		delete(kv.secrets, name)
+
+		response := map[string]interface{}{}
+
+		if kv.softDelete {
+			kv.deleted[name] = true
+			response["recoveryId"] = "https://vault.azure.net/deletedsecrets/" + name
+		}
+
+		writeKeyVaultResponse(w, http.StatusOK, response)
+	case parts[0] == "deletedsecrets" && r.Method == http.MethodGet:
+		if !kv.deleted[name] {
+			writeKeyVaultError(w, http.StatusNotFound, "SecretNotFound")
+
+			return
+		}
+
+		writeKeyVaultResponse(w, http.StatusOK, map[string]interface{}{})
+	case parts[0] == "deletedsecrets" && r.Method == http.MethodDelete:
+		if !kv.deleted[name] {
+			writeKeyVaultError(w, http.StatusNotFound, "SecretNotFound")
+
+			return
+		}
+
+		delete(kv.deleted, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeKeyVaultError(w, http.StatusNotFound, "NotFound")
+	}
+}
+
+func writeKeyVaultResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeKeyVaultError(w http.ResponseWriter, status int, code string) {
+	writeKeyVaultResponse(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": code},
+	})
+}
+
+// Credential handing out a static token.
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// Creates an Azure Key Vault secrets manager for the node whose client talks to the given server.
+func newTestKeyVaultManager(t *testing.T, server *httptest.Server) *AzureKeyVaultManager {
+	t.Helper()
+
+	client, err := azsecrets.NewClient(server.URL, fakeCredential{}, &azsecrets.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Transport: server.Client(),
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		},
+		DisableChallengeResourceVerification: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return &AzureKeyVaultManager{
+		logger:       hclog.NewNullLogger(),
+		vaultURL:     server.URL,
+		client:       client,
+		secretPrefix: "node",
+	}
+}
+
+func TestAzureKeyVaultManagerSetRemoveSet(t *testing.T) {
+	tests := []struct {
+		name       string
+		softDelete bool
+	}{
+		{"soft delete", true},
+		{"no soft delete", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, vault := newFakeKeyVault(t, tt.softDelete)
+			manager := newTestKeyVaultManager(t, server)
+
+			if err := manager.SetSecret(secrets.ReporterKey, []byte("first")); err != nil {
+				t.Fatalf("SetSecret() error = %v", err)
+			}
+
+			if err := manager.SetSecret(secrets.ReporterKey, []byte("second")); err == nil {
+				t.Fatal("SetSecret() on an existing secret error = nil, want an already initialized error")
+			}
+
+			if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "first" {
+				t.Fatalf("GetSecret() = %q, %v, want first", value, err)
+			}
+
+			// a soft-deleted secret is purged, so it can be set again
+			if err := manager.RemoveSecret(secrets.ReporterKey); err != nil {
+				t.Fatalf("RemoveSecret() error = %v", err)
+			}
+
+			if vault.deleted["node-"+secrets.ReporterKey] {
+				t.Fatal("RemoveSecret() did not purge the deleted secret")
+			}
+
+			if manager.HasSecret(secrets.ReporterKey) {
+				t.Fatal("HasSecret() after RemoveSecret() = true")
+			}
+
+			if err := manager.SetSecret(secrets.ReporterKey, []byte("third")); err != nil {
+				t.Fatalf("SetSecret() after RemoveSecret() error = %v", err)
+			}
+
+			if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "third" {
+				t.Fatalf("GetSecret() = %q, %v, want third", value, err)
+			}
+		})
+	}
+}
+
+func TestAzureKeyVaultManagerMissingSecret(t *testing.T) {
+	server, _ := newFakeKeyVault(t, true)
+	manager := newTestKeyVaultManager(t, server)
+
+	if _, err := manager.GetSecret(secrets.ReporterKey); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("GetSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+
+	if err := manager.UpdateSecret(secrets.ReporterKey, []byte("new")); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("UpdateSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("RemoveSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+}
+
+func TestAzureKeyVaultManagerUpdateSecret(t *testing.T) {
+	server, vault := newFakeKeyVault(t, true)
+	manager := newTestKeyVaultManager(t, server)
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("old")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	if err := manager.UpdateSecret(secrets.ReporterKey, []byte("new")); err != nil {
+		t.Fatalf("UpdateSecret() error = %v", err)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "new" {
+		t.Fatalf("GetSecret() = %q, %v, want new", value, err)
+	}
+
+	if versions := len(vault.secrets["node-"+secrets.ReporterKey]); versions != 2 {
+		t.Errorf("secret versions = %d, want 2", versions)
+	}
+}
@@ -3,12 +3,20 @@ package config
 import (
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/infra/secrets/awsssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/azurekeyvault"
+	"github.com/sx-network/sx-reporter/infra/secrets/env"
+	"github.com/sx-network/sx-reporter/infra/secrets/gcpssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/hashicorpvault"
 	"github.com/sx-network/sx-reporter/infra/secrets/local"
 )
 
 // It is a map that defines the SecretManager factories for different secret management solutions.
 // It maps each SecretsManagerType to its corresponding factory function.
 var SecretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManagerFactory{
-	secrets.Local:  local.SecretsManagerFactory,
-	secrets.AWSSSM: awsssm.SecretsManagerFactory,
+	secrets.Local:          local.SecretsManagerFactory,
+	secrets.AWSSSM:         awsssm.SecretsManagerFactory,
+	secrets.HashicorpVault: hashicorpvault.SecretsManagerFactory,
+	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
+	secrets.AzureKeyVault:  azurekeyvault.SecretsManagerFactory,
+	secrets.Env:            env.SecretsManagerFactory,
 }
@@ -0,0 +1,171 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Constants representing the keys of the env secrets manager extra map.
+// Additional reporter keys are read from the environment variable suffixed with the upper-cased key name,
+// such as SXR_REPORTER_KEY_HOT for the key named hot, or from the file named after the key next to the key file.
+const (
+	// EnvVar is the environment variable holding the reporter key
+	EnvVar = "env-var"
+	// KeyFile is the path to a mounted file holding the reporter key, such as a Kubernetes secret volume
+	KeyFile = "key-file"
+)
+
+// DefaultEnvVar is the environment variable the reporter key is read from if neither
+// an environment variable nor a key file is configured
+const DefaultEnvVar = "SXR_REPORTER_KEY"
+
+// ErrReadOnly is returned when modifying secrets of the read-only env secrets manager
+var ErrReadOnly = errors.New("env secrets manager is read-only")
+
+// EnvSecretsManager is a read-only SecretsManager that
+// reads the reporter key from an environment variable or a mounted file
+type EnvSecretsManager struct {
+	// Local logger object
+	logger hclog.Logger
+
+	// The environment variable holding the reporter key
+	envVar string
+
+	// The path to the file holding the reporter key, takes precedence over the environment variable
+	keyFile string
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Set up the base object
+	envManager := &EnvSecretsManager{
+		logger: params.Logger.Named(string(secrets.Env)),
+		envVar: DefaultEnvVar,
+	}
+
+	if config.Extra != nil {
+		if config.Extra[EnvVar] != nil {
+			envManager.envVar = fmt.Sprintf("%v", config.Extra[EnvVar])
+		}
+
+		if config.Extra[KeyFile] != nil {
+			envManager.keyFile = fmt.Sprintf("%v", config.Extra[KeyFile])
+		}
+	}
+
+	// Run the initial setup
+	if err := envManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return envManager, nil
+}
+
+// Setup checks that the reporter key source is available
+func (e *EnvSecretsManager) Setup() error {
+	if e.keyFile != "" {
+		if _, err := os.Stat(e.keyFile); err != nil {
+			return fmt.Errorf("unable to read reporter key file (%s), %w", e.keyFile, err)
+		}
+
+		return nil
+	}
+
+	if e.envVar == "" {
+		return errors.New("no 'env-var' or 'key-file' specified for env secrets manager")
+	}
+
+	if _, ok := os.LookupEnv(e.envVar); !ok {
+		e.logger.Warn("reporter key environment variable is not set", "env", e.envVar)
+	}
+
+	return nil
+}
+
+// GetSecret reads the secret from the key file or the environment variable.
+// The key file is read on every call, so that updates to mounted secrets are picked up
+func (e *EnvSecretsManager) GetSecret(name string) ([]byte, error) {
+	keyFile, envVar, ok := e.secretSource(name)
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	var value string
+
+	if keyFile != "" {
+		buf, err := os.ReadFile(keyFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, secrets.ErrSecretNotFound
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to read secret from disk (%s), %w", keyFile, err)
+		}
+
+		value = string(buf)
+	} else {
+		value = os.Getenv(envVar)
+	}
+
+	// Mounted files and environment variables commonly carry trailing newlines
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	return []byte(value), nil
+}
+
+// secretSource returns the key file and the environment variable the secret is read from,
+// or false if the secret is not a reporter key. The key file takes precedence if set
+func (e *EnvSecretsManager) secretSource(name string) (string, string, bool) {
+	if name == secrets.ReporterKey {
+		return e.keyFile, e.envVar, true
+	}
+
+	keyName, ok := secrets.ReporterKeyNameOf(name)
+	if !ok {
+		return "", "", false
+	}
+
+	var keyFile, envVar string
+
+	if e.keyFile != "" {
+		keyFile = filepath.Join(filepath.Dir(e.keyFile), keyName)
+	}
+
+	if e.envVar != "" {
+		envVar = e.envVar + "_" + strings.ToUpper(strings.ReplaceAll(keyName, "-", "_"))
+	}
+
+	return keyFile, envVar, true
+}
+
+// SetSecret is not supported by the read-only env secrets manager
+func (e *EnvSecretsManager) SetSecret(_ string, _ []byte) error {
+	return ErrReadOnly
+}
+
+// UpdateSecret is not supported by the read-only env secrets manager
+func (e *EnvSecretsManager) UpdateSecret(_ string, _ []byte) error {
+	return ErrReadOnly
+}
+
+// HasSecret checks if the secret is set
+func (e *EnvSecretsManager) HasSecret(name string) bool {
+	_, err := e.GetSecret(name)
+
+	return err == nil
+}
+
+// RemoveSecret is not supported by the read-only env secrets manager
+func (e *EnvSecretsManager) RemoveSecret(_ string) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,205 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+const testEnvVar = "SXR_TEST_REPORTER_KEY"
+
+// Creates an env secrets manager reading from the test environment variable, and from the key file if set.
+func newTestEnvManager(t *testing.T, keyFile string) secrets.SecretsManager {
+	t.Helper()
+
+	extra := map[string]interface{}{EnvVar: testEnvVar}
+	if keyFile != "" {
+		extra[KeyFile] = keyFile
+	}
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Extra: extra},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	if err != nil {
+		t.Fatalf("SecretsManagerFactory() error = %v", err)
+	}
+
+	return manager
+}
+
+func writeTestFile(t *testing.T, path string, value string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(value), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestEnvSecretsManagerGetSecret(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		files     map[string]string
+		secret    string
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "env var trimmed",
+			env:       map[string]string{testEnvVar: "  0xabc\n"},
+			secret:    secrets.ReporterKey,
+			want:      "0xabc",
+			wantFound: true,
+		},
+		{
+			name:   "empty env var",
+			env:    map[string]string{testEnvVar: " \n"},
+			secret: secrets.ReporterKey,
+		},
+		{
+			name:   "unset env var",
+			secret: secrets.ReporterKey,
+		},
+		{
+			name:      "key file trimmed",
+			files:     map[string]string{"key": "0xdef\n"},
+			secret:    secrets.ReporterKey,
+			want:      "0xdef",
+			wantFound: true,
+		},
+		{
+			name:      "key file takes precedence over env var",
+			env:       map[string]string{testEnvVar: "0xabc"},
+			files:     map[string]string{"key": "0xdef"},
+			secret:    secrets.ReporterKey,
+			want:      "0xdef",
+			wantFound: true,
+		},
+		{
+			name:   "empty key file",
+			env:    map[string]string{testEnvVar: "0xabc"},
+			files:  map[string]string{"key": "\n"},
+			secret: secrets.ReporterKey,
+		},
+		{
+			name:      "named key env var",
+			env:       map[string]string{testEnvVar: "0xabc", testEnvVar + "_HOT_1": "0x123\n"},
+			secret:    secrets.NamedReporterKey("hot-1"),
+			want:      "0x123",
+			wantFound: true,
+		},
+		{
+			name:   "missing named key env var",
+			env:    map[string]string{testEnvVar: "0xabc"},
+			secret: secrets.NamedReporterKey("hot-1"),
+		},
+		{
+			name:      "named key file next to the key file",
+			env:       map[string]string{testEnvVar + "_HOT_1": "0x123"},
+			files:     map[string]string{"key": "0xdef", "hot-1": "0x456\n"},
+			secret:    secrets.NamedReporterKey("hot-1"),
+			want:      "0x456",
+			wantFound: true,
+		},
+		{
+			name:   "missing named key file",
+			env:    map[string]string{testEnvVar + "_HOT_1": "0x123"},
+			files:  map[string]string{"key": "0xdef"},
+			secret: secrets.NamedReporterKey("hot-1"),
+		},
+		{
+			name:   "not a reporter key",
+			env:    map[string]string{testEnvVar: "0xabc", testEnvVar + "_OTHER": "0x123"},
+			secret: "other",
+		},
+		{
+			name:   "archived reporter key",
+			env:    map[string]string{testEnvVar: "0xabc"},
+			secret: secrets.ReporterKey + "-archive-1700000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			keyFile := ""
+
+			if tt.files != nil {
+				dir := t.TempDir()
+				keyFile = filepath.Join(dir, "key")
+
+				for name, value := range tt.files {
+					writeTestFile(t, filepath.Join(dir, name), value)
+				}
+			}
+
+			manager := newTestEnvManager(t, keyFile)
+
+			value, err := manager.GetSecret(tt.secret)
+			if !tt.wantFound {
+				if !errors.Is(err, secrets.ErrSecretNotFound) {
+					t.Errorf("GetSecret() = %q, %v, want %v", value, err, secrets.ErrSecretNotFound)
+				}
+
+				if manager.HasSecret(tt.secret) {
+					t.Error("HasSecret() = true, want false")
+				}
+
+				return
+			}
+
+			if err != nil || string(value) != tt.want {
+				t.Errorf("GetSecret() = %q, %v, want %s", value, err, tt.want)
+			}
+
+			if !manager.HasSecret(tt.secret) {
+				t.Error("HasSecret() = false, want true")
+			}
+		})
+	}
+}
+
+func TestEnvSecretsManagerReadOnly(t *testing.T) {
+	t.Setenv(testEnvVar, "0xabc")
+
+	manager := newTestEnvManager(t, "")
+
+	updater, ok := manager.(secrets.SecretUpdater)
+	if !ok {
+		t.Fatal("env secrets manager does not implement secrets.SecretUpdater")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("0xdef")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SetSecret() error = %v, want %v", err, ErrReadOnly)
+	}
+
+	if err := updater.UpdateSecret(secrets.ReporterKey, []byte("0xdef")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("UpdateSecret() error = %v, want %v", err, ErrReadOnly)
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RemoveSecret() error = %v, want %v", err, ErrReadOnly)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "0xabc" {
+		t.Errorf("GetSecret() = %q, %v, want 0xabc", value, err)
+	}
+}
+
+func TestEnvSecretsManagerMissingKeyFile(t *testing.T) {
+	_, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Extra: map[string]interface{}{KeyFile: filepath.Join(t.TempDir(), "key")}},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	if err == nil {
+		t.Error("SecretsManagerFactory() with a missing key file error = nil")
+	}
+}
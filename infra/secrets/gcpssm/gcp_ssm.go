@@ -0,0 +1,225 @@
+package gcpssm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Constants representing the keys of the GCP Secret Manager extra map.
+const (
+	// ProjectID is the GCP project the secrets are stored in
+	ProjectID = "project-id"
+	// CredentialsFile is the path to the service account credentials file
+	CredentialsFile = "gcp-ssm-cred"
+)
+
+// Timeout applied to every GCP Secret Manager request.
+const requestTimeout = 30 * time.Second
+
+// GCPSecretsManager is a SecretsManager that
+// stores secrets on GCP Secret Manager
+type GCPSecretsManager struct {
+	// Local logger object
+	logger hclog.Logger
+
+	// The GCP project ID
+	projectID string
+
+	// The path to the service account credentials file
+	credentialsFile string
+
+	// The GCP Secret Manager client
+	client *secretmanager.Client
+
+	// The prefix of the secret IDs, the node name
+	secretPrefix string
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the node name is present
+	if config.Name == "" {
+		return nil, errors.New("no node name specified for GCP secrets manager")
+	}
+
+	// Check if the extra map is present
+	if config.Extra == nil || config.Extra[ProjectID] == nil || config.Extra[CredentialsFile] == nil {
+		return nil, errors.New("required extra map containing 'project-id' and 'gcp-ssm-cred' not found for gcp-ssm")
+	}
+
+	// Set up the base object
+	gcpManager := &GCPSecretsManager{
+		logger:          params.Logger.Named(string(secrets.GCPSSM)),
+		projectID:       fmt.Sprintf("%v", config.Extra[ProjectID]),
+		credentialsFile: fmt.Sprintf("%v", config.Extra[CredentialsFile]),
+		secretPrefix:    config.Name,
+	}
+
+	// Check if the credentials file is present
+	if _, err := os.Stat(gcpManager.credentialsFile); err != nil {
+		return nil, fmt.Errorf("unable to read GCP credentials file (%s), %w", gcpManager.credentialsFile, err)
+	}
+
+	// Run the initial setup
+	if err := gcpManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return gcpManager, nil
+}
+
+// Setup sets up the GCP Secret Manager
+func (g *GCPSecretsManager) Setup() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	client, err := secretmanager.NewClient(ctx, option.WithCredentialsFile(g.credentialsFile))
+	if err != nil {
+		return fmt.Errorf("unable to initialize GCP secrets manager client: %w", err)
+	}
+
+	g.client = client
+
+	return nil
+}
+
+// constructSecretPath is a helper method for constructing a path to the secret
+func (g *GCPSecretsManager) constructSecretPath(name string) string {
+	return fmt.Sprintf("projects/%s/secrets/%s_%s", g.projectID, g.secretPrefix, name)
+}
+
+// GetSecret fetches the latest version of a secret from GCP Secret Manager
+func (g *GCPSecretsManager) GetSecret(name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	response, err := g.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+		Name: fmt.Sprintf("%s/versions/latest", g.constructSecretPath(name)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, secrets.ErrSecretNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read secret (%s), %w", name, err)
+	}
+
+	return response.Payload.Data, nil
+}
+
+// SetSecret saves a secret to GCP Secret Manager
+func (g *GCPSecretsManager) SetSecret(name string, value []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	// Creating the secret fails if it already exists, so existing secrets are never overwritten
+	secret, err := g.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   fmt.Sprintf("projects/%s", g.projectID),
+		SecretId: fmt.Sprintf("%s_%s", g.secretPrefix, name),
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create secret (%s), %w", name, err)
+	}
+
+	if _, err := g.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: secret.Name,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: value,
+		},
+	}); err != nil {
+		// Remove the secret created without a version, so setting it can be retried.
+		// A new context is used, as the version may have failed to be added because the request timed out
+		deleteCtx, deleteCancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer deleteCancel()
+
+		if deleteErr := g.client.DeleteSecret(deleteCtx, &secretmanagerpb.DeleteSecretRequest{
+			Name: secret.Name,
+		}); deleteErr != nil {
+			g.logger.Error("unable to remove secret created without a version", "name", name, "err", deleteErr)
+		}
+
+		return fmt.Errorf("unable to store secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// UpdateSecret adds a new version holding the value to an existing secret on GCP Secret Manager
+func (g *GCPSecretsManager) UpdateSecret(name string, value []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if _, err := g.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: g.constructSecretPath(name),
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: value,
+		},
+	}); err != nil {
+		if isNotFound(err) {
+			return secrets.ErrSecretNotFound
+		}
+
+		return fmt.Errorf("unable to update secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the secret is present on GCP Secret Manager
+func (g *GCPSecretsManager) HasSecret(name string) bool {
+	_, err := g.GetSecret(name)
+
+	return err == nil
+}
+
+// RemoveSecret removes a secret, including all of its versions, from GCP Secret Manager
+func (g *GCPSecretsManager) RemoveSecret(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	if err := g.client.DeleteSecret(ctx, &secretmanagerpb.DeleteSecretRequest{
+		Name: g.constructSecretPath(name),
+	}); err != nil {
+		if isNotFound(err) {
+			return secrets.ErrSecretNotFound
+		}
+
+		return fmt.Errorf("unable to delete secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// isNotFound checks whether the GCP request was rejected as not found, over either the gRPC or the REST transport
+func isNotFound(err error) bool {
+	if status.Code(err) == codes.NotFound {
+		return true
+	}
+
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
@@ -0,0 +1,253 @@
+package gcpssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"google.golang.org/api/option"
+)
+
+const testSecretsPath = "projects/project/secrets"
+
+// Fake of the GCP Secret Manager REST API, holding the versions of each secret.
+type fakeSecretManager struct {
+	secrets        map[string][][]byte // Versions of the secrets by secret ID, the latest last.
+	failAddVersion bool                // Whether adding a secret version fails.
+	sync.Mutex
+}
+
+// Starts a GCP Secret Manager server backed by a fake.
+func newFakeSecretManager(t *testing.T) (*httptest.Server, *fakeSecretManager) {
+	t.Helper()
+
+	fake := &fakeSecretManager{secrets: make(map[string][][]byte)}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return server, fake
+}
+
+func (f *fakeSecretManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	switch {
+	case r.Method == http.MethodPost && path == testSecretsPath:
+		id := r.URL.Query().Get("secretId")
+		if _, ok := f.secrets[id]; ok {
+			writeGCPError(w, http.StatusConflict, "ALREADY_EXISTS")
+
+			return
+		}
+
+		f.secrets[id] = nil
+
+		writeGCPResponse(w, map[string]interface{}{"name": testSecretsPath + "/" + id})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, ":addVersion"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, testSecretsPath+"/"), ":addVersion")
+
+		var request struct {
+			Payload struct {
+				Data []byte `json:"data"`
+			} `json:"payload"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeGCPError(w, http.StatusBadRequest, "INVALID_ARGUMENT")
+
+			return
+		}
+
+		if _, ok := f.secrets[id]; !ok {
+			writeGCPError(w, http.StatusNotFound, "NOT_FOUND")
+
+			return
+		}
+
+		if f.failAddVersion {
+			writeGCPError(w, http.StatusForbidden, "PERMISSION_DENIED")
+
+			return
+		}
+
+		f.secrets[id] = append(f.secrets[id], request.Payload.Data)
+
+		writeGCPResponse(w, map[string]interface{}{"name": testSecretsPath + "/" + id + "/versions/1"})
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/versions/latest:access"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, testSecretsPath+"/"), "/versions/latest:access")
+
+		versions := f.secrets[id]
+		if len(versions) == 0 {
+			writeGCPError(w, http.StatusNotFound, "NOT_FOUND")
+
+			return
+		}
+
+		writeGCPResponse(w, map[string]interface{}{
+			"name":    path,
+			"payload": map[string]interface{}{"data": versions[len(versions)-1]},
+		})
+	case r.Method == http.MethodDelete:
+		id := strings.TrimPrefix(path, testSecretsPath+"/")
+		if _, ok := f.secrets[id]; !ok {
+			writeGCPError(w, http.StatusNotFound, "NOT_FOUND")
+
+			return
+		}
+
+		delete(f.secrets, id)
+
+		writeGCPResponse(w, map[string]interface{}{})
+	default:
+		writeGCPError(w, http.StatusNotFound, "NOT_FOUND")
+	}
+}
+
+func writeGCPResponse(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeGCPError(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": status, "status": status},
+	})
+}
+
+// Creates a GCP secrets manager for the node whose client talks to the given server over REST.
+func newTestGCPManager(t *testing.T, serverURL string) *GCPSecretsManager {
+	t.Helper()
+
+	client, err := secretmanager.NewRESTClient(
+		context.Background(),
+		option.WithEndpoint(serverURL),
+		option.WithoutAuthentication(),
+	)
+	if err != nil {
+		t.Fatalf("NewRESTClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	return &GCPSecretsManager{
+		logger:       hclog.NewNullLogger(),
+		projectID:    "project",
+		client:       client,
+		secretPrefix: "node",
+	}
+}
+
+func TestGCPSecretsManagerSetRemoveSet(t *testing.T) {
+	server, fake := newFakeSecretManager(t)
+	manager := newTestGCPManager(t, server.URL)
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("first")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("second")); err == nil {
+		t.Fatal("SetSecret() on an existing secret error = nil, want an already exists error")
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "first" {
+		t.Fatalf("GetSecret() = %q, %v, want first", value, err)
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); err != nil {
+		t.Fatalf("RemoveSecret() error = %v", err)
+	}
+
+	if _, ok := fake.secrets["node_"+secrets.ReporterKey]; ok {
+		t.Fatal("RemoveSecret() kept the secret")
+	}
+
+	if manager.HasSecret(secrets.ReporterKey) {
+		t.Fatal("HasSecret() after RemoveSecret() = true")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("third")); err != nil {
+		t.Fatalf("SetSecret() after RemoveSecret() error = %v", err)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "third" {
+		t.Fatalf("GetSecret() = %q, %v, want third", value, err)
+	}
+}
+
+func TestGCPSecretsManagerMissingSecret(t *testing.T) {
+	server, _ := newFakeSecretManager(t)
+	manager := newTestGCPManager(t, server.URL)
+
+	if _, err := manager.GetSecret(secrets.ReporterKey); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("GetSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+
+	if err := manager.UpdateSecret(secrets.ReporterKey, []byte("new")); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("UpdateSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("RemoveSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+}
+
+func TestGCPSecretsManagerUpdateSecret(t *testing.T) {
+	server, fake := newFakeSecretManager(t)
+	manager := newTestGCPManager(t, server.URL)
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("old")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	if err := manager.UpdateSecret(secrets.ReporterKey, []byte("new")); err != nil {
+		t.Fatalf("UpdateSecret() error = %v", err)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "new" {
+		t.Fatalf("GetSecret() = %q, %v, want new", value, err)
+	}
+
+	if versions := len(fake.secrets["node_"+secrets.ReporterKey]); versions != 2 {
+		t.Errorf("secret versions = %d, want 2", versions)
+	}
+}
+
+func TestGCPSecretsManagerSetSecretFailedVersion(t *testing.T) {
+	server, fake := newFakeSecretManager(t)
+	manager := newTestGCPManager(t, server.URL)
+
+	fake.failAddVersion = true
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("first")); err == nil {
+		t.Fatal("SetSecret() error = nil, want a version error")
+	}
+
+	// the secret created without a version is removed, so it can be set again
+	if _, ok := fake.secrets["node_"+secrets.ReporterKey]; ok {
+		t.Fatal("SetSecret() left an empty secret behind")
+	}
+
+	fake.failAddVersion = false
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("second")); err != nil {
+		t.Fatalf("SetSecret() after a failed version error = %v", err)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "second" {
+		t.Fatalf("GetSecret() = %q, %v, want second", value, err)
+	}
+}
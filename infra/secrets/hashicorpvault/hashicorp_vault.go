@@ -0,0 +1,436 @@
+package hashicorpvault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Constants representing the keys of the Vault secrets manager extra map.
+const (
+	// AuthMethod is the auth method used to log in, either token [Default] or approle
+	AuthMethod = "auth-method"
+	// AppRoleMount is the mount path of the AppRole auth method, defaults to approle
+	AppRoleMount = "approle-mount"
+	// RoleID is the AppRole role ID
+	RoleID = "role-id"
+	// SecretID is the AppRole secret ID
+	SecretID = "secret-id"
+	// SecretIDFile is the path to a file holding the AppRole secret ID
+	SecretIDFile = "secret-id-file"
+	// KVMount is the mount path of the KV v2 secrets engine, defaults to secret
+	KVMount = "kv-mount"
+	// SigningMount is the mount path of the Ethereum signing plugin, transaction signing is disabled if empty
+	SigningMount = "signing-mount"
+	// SigningAccount is the name of the signing plugin account, defaults to the node name
+	SigningAccount = "signing-account"
+)
+
+// Constants representing the supported auth methods.
+const (
+	tokenAuth   = "token"
+	appRoleAuth = "approle"
+)
+
+// Constants representing the default mount paths.
+const (
+	defaultAppRoleMount = "approle"
+	defaultKVMount      = "secret"
+)
+
+var (
+	errSigningDisabled = errors.New("no 'signing-mount' specified for Vault secrets manager")
+)
+
+// VaultSecretsManager is a SecretsManager that
+// stores secrets on a HashiCorp Vault instance, using the KV v2 secrets engine.
+//
+// Transaction signing is supported through an Ethereum signing plugin mounted at 'signing-mount',
+// such as vault-plugin-secrets-ethsign, so that the reporter key never leaves Vault.
+// The built-in transit secrets engine cannot be used for this, as it doesn't support the secp256k1 curve.
+type VaultSecretsManager struct {
+	// Local logger object
+	logger hclog.Logger
+
+	// Token used for Vault instance authentication
+	token string
+
+	// The Vault instance URL
+	serverURL string
+
+	// The namespace under which the secrets are stored
+	namespace string
+
+	// The auth method used to log in
+	authMethod string
+
+	// The AppRole auth method mount path, role ID and secret ID
+	appRoleMount string
+	roleID       string
+	secretID     string
+
+	// The base paths of the secrets data and metadata in the KV v2 secrets engine
+	basePath         string
+	metadataBasePath string
+
+	// The signing plugin mount path and account name
+	signingMount   string
+	signingAccount string
+
+	// The Vault client
+	client *vault.Client
+
+	// Mux for logging in again once the AppRole token expires
+	loginLock sync.Mutex
+}
+
+// SecretsManagerFactory implements the factory method
+func SecretsManagerFactory(
+	config *secrets.SecretsManagerConfig,
+	params *secrets.SecretsManagerParams,
+) (secrets.SecretsManager, error) {
+	// Check if the server URL is present
+	if config.ServerURL == "" {
+		return nil, errors.New("no server URL specified for Vault secrets manager")
+	}
+
+	// Check if the node name is present
+	if config.Name == "" {
+		return nil, errors.New("no node name specified for Vault secrets manager")
+	}
+
+	// Set up the base object
+	vaultManager := &VaultSecretsManager{
+		logger:         params.Logger.Named(string(secrets.HashicorpVault)),
+		token:          config.Token,
+		serverURL:      config.ServerURL,
+		namespace:      config.Namespace,
+		authMethod:     extraString(config, AuthMethod, tokenAuth),
+		appRoleMount:   extraString(config, AppRoleMount, defaultAppRoleMount),
+		roleID:         extraString(config, RoleID, ""),
+		secretID:       extraString(config, SecretID, ""),
+		signingMount:   strings.Trim(extraString(config, SigningMount, ""), "/"),
+		signingAccount: extraString(config, SigningAccount, config.Name),
+	}
+
+	// Set the base paths of the secrets data and metadata in the KV v2 secrets engine
+	kvMount := strings.Trim(extraString(config, KVMount, defaultKVMount), "/")
+	vaultManager.basePath = fmt.Sprintf("%s/data/%s", kvMount, config.Name)
+	vaultManager.metadataBasePath = fmt.Sprintf("%s/metadata/%s", kvMount, config.Name)
+
+	switch vaultManager.authMethod {
+	case tokenAuth:
+		if vaultManager.token == "" {
+			return nil, errors.New("no token specified for Vault secrets manager")
+		}
+	case appRoleAuth:
+		if secretIDFile := extraString(config, SecretIDFile, ""); secretIDFile != "" {
+			secretID, err := os.ReadFile(secretIDFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read Vault AppRole secret ID file (%s), %w", secretIDFile, err)
+			}
+
+			vaultManager.secretID = strings.TrimSpace(string(secretID))
+		}
+
+		if vaultManager.roleID == "" || vaultManager.secretID == "" {
+			return nil, errors.New(
+				"required extra map containing 'role-id' and 'secret-id' or 'secret-id-file' not found for approle auth",
+			)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Vault auth method '%s'", vaultManager.authMethod)
+	}
+
+	// Run the initial setup
+	if err := vaultManager.Setup(); err != nil {
+		return nil, err
+	}
+
+	return vaultManager, nil
+}
+
+// Setup sets up the HashiCorp Vault secrets manager
+func (v *VaultSecretsManager) Setup() error {
+	config := vault.DefaultConfig()
+	config.Address = v.serverURL
+
+	client, err := vault.NewClient(config)
+	if err != nil {
+		return fmt.Errorf("unable to initialize Vault client: %w", err)
+	}
+
+	if v.namespace != "" {
+		client.SetNamespace(v.namespace)
+	}
+
+	v.client = client
+
+	if v.authMethod == appRoleAuth {
+		return v.login()
+	}
+
+	client.SetToken(v.token)
+
+	return nil
+}
+
+// login logs in using the AppRole auth method and sets the resulting client token
+func (v *VaultSecretsManager) login() error {
+	secret, err := v.client.Logical().Write(
+		fmt.Sprintf("auth/%s/login", v.appRoleMount),
+		map[string]interface{}{
+			"role_id":   v.roleID,
+			"secret_id": v.secretID,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to log in to Vault with AppRole, %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return errors.New("unable to log in to Vault with AppRole, no client token returned")
+	}
+
+	v.client.SetToken(secret.Auth.ClientToken)
+
+	return nil
+}
+
+// withLogin runs the request, logging in again and retrying once
+// if it is denied because the AppRole token has expired
+func (v *VaultSecretsManager) withLogin(request func() (*vault.Secret, error)) (*vault.Secret, error) {
+	secret, err := request()
+	if err == nil || v.authMethod != appRoleAuth || !isPermissionDenied(err) {
+		return secret, err
+	}
+
+	v.loginLock.Lock()
+	loginErr := v.login()
+	v.loginLock.Unlock()
+
+	if loginErr != nil {
+		return nil, loginErr
+	}
+
+	return request()
+}
+
+// constructSecretPath is a helper method for constructing a path to the secret
+func (v *VaultSecretsManager) constructSecretPath(name string) string {
+	return fmt.Sprintf("%s/%s", v.basePath, name)
+}
+
+// constructMetadataPath is a helper method for constructing a path to the metadata of the secret
+func (v *VaultSecretsManager) constructMetadataPath(name string) string {
+	return fmt.Sprintf("%s/%s", v.metadataBasePath, name)
+}
+
+// GetSecret fetches a secret from the Vault instance
+func (v *VaultSecretsManager) GetSecret(name string) ([]byte, error) {
+	secret, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Read(v.constructSecretPath(name))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secret from Vault, %w", err)
+	}
+
+	if secret == nil {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	// KV v2 nests the stored key-value pairs under data,
+	// which is nil if the latest version has been deleted
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return nil, secrets.ErrSecretNotFound
+	}
+
+	value, ok := data[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to assert type for secret (%s) from Vault", name)
+	}
+
+	return []byte(value), nil
+}
+
+// SetSecret saves a secret to the Vault instance
+func (v *VaultSecretsManager) SetSecret(name string, value []byte) error {
+	// Check-and-set with version 0 only allows the write if the secret doesn't exist yet
+	if _, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Write(
+			v.constructSecretPath(name),
+			map[string]interface{}{
+				"options": map[string]interface{}{
+					"cas": 0,
+				},
+				"data": map[string]interface{}{
+					name: string(value),
+				},
+			},
+		)
+	}); err != nil {
+		return fmt.Errorf("unable to store secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// UpdateSecret stores the value as a new version of an existing secret on the Vault instance
+func (v *VaultSecretsManager) UpdateSecret(name string, value []byte) error {
+	secret, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Read(v.constructSecretPath(name))
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read secret from Vault, %w", err)
+	}
+
+	if secret == nil || secret.Data["data"] == nil {
+		return secrets.ErrSecretNotFound
+	}
+
+	metadata, ok := secret.Data["metadata"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unable to assert type for secret (%s) metadata from Vault", name)
+	}
+
+	version, ok := metadata["version"].(json.Number)
+	if !ok {
+		return fmt.Errorf("unable to assert type for secret (%s) version from Vault", name)
+	}
+
+	// Check-and-set with the current version rejects the write if the secret changed since it was read
+	if _, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Write(
+			v.constructSecretPath(name),
+			map[string]interface{}{
+				"options": map[string]interface{}{
+					"cas": version,
+				},
+				"data": map[string]interface{}{
+					name: string(value),
+				},
+			},
+		)
+	}); err != nil {
+		return fmt.Errorf("unable to update secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// HasSecret checks if the secret is present on the Vault instance
+func (v *VaultSecretsManager) HasSecret(name string) bool {
+	_, err := v.GetSecret(name)
+
+	return err == nil
+}
+
+// RemoveSecret removes a secret from the Vault instance
+func (v *VaultSecretsManager) RemoveSecret(name string) error {
+	// Check if non-existent
+	if _, err := v.GetSecret(name); err != nil {
+		return err
+	}
+
+	// Delete the metadata and every version of the secret. Deleting the data path only soft-deletes
+	// the latest version, which keeps the secret's version history and so rejects setting it again
+	if _, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Delete(v.constructMetadataPath(name))
+	}); err != nil {
+		return fmt.Errorf("unable to delete secret (%s), %w", name, err)
+	}
+
+	return nil
+}
+
+// SignerAddress returns the address of the signing plugin account
+func (v *VaultSecretsManager) SignerAddress() (string, error) {
+	if v.signingMount == "" {
+		return "", errSigningDisabled
+	}
+
+	secret, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Read(fmt.Sprintf("%s/accounts/%s", v.signingMount, v.signingAccount))
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to read signing account from Vault, %w", err)
+	}
+
+	if secret == nil {
+		return "", fmt.Errorf("signing account (%s) not found in Vault", v.signingAccount)
+	}
+
+	address, ok := secret.Data["address"].(string)
+	if !ok {
+		return "", fmt.Errorf("unable to assert type for signing account (%s) address from Vault", v.signingAccount)
+	}
+
+	return address, nil
+}
+
+// SignTx signs a legacy transaction with the signing plugin account and returns its RLP encoding
+func (v *VaultSecretsManager) SignTx(tx *secrets.SignTxRequest) ([]byte, error) {
+	if v.signingMount == "" {
+		return nil, errSigningDisabled
+	}
+
+	value := tx.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	secret, err := v.withLogin(func() (*vault.Secret, error) {
+		return v.client.Logical().Write(
+			fmt.Sprintf("%s/accounts/%s/sign", v.signingMount, v.signingAccount),
+			map[string]interface{}{
+				"to":       tx.To,
+				"data":     hex.EncodeToHex(tx.Data),
+				"value":    value.String(),
+				"gas":      tx.Gas,
+				"gasPrice": new(big.Int).SetUint64(tx.GasPrice).String(),
+				"nonce":    tx.Nonce,
+				"chainId":  tx.ChainID.String(),
+			},
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction with Vault, %w", err)
+	}
+
+	if secret == nil {
+		return nil, errors.New("unable to sign transaction with Vault, no response returned")
+	}
+
+	signedTx, ok := secret.Data["signed_transaction"].(string)
+	if !ok {
+		return nil, errors.New("unable to assert type for signed transaction from Vault")
+	}
+
+	return hex.DecodeHex(signedTx)
+}
+
+// isPermissionDenied checks whether the Vault request was rejected with a 403 response
+func isPermissionDenied(err error) bool {
+	var responseErr *vault.ResponseError
+
+	return errors.As(err, &responseErr) && responseErr.StatusCode == 403
+}
+
+// extraString returns the string value of the extra map key, or the default value if it isn't set
+func extraString(config *secrets.SecretsManagerConfig, key string, defaultValue string) string {
+	if config.Extra == nil || config.Extra[key] == nil {
+		return defaultValue
+	}
+
+	return fmt.Sprintf("%v", config.Extra[key])
+}
@@ -0,0 +1,222 @@
+package hashicorpvault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Version history of a secret held by the fake KV v2 secrets engine.
+type fakeKVSecret struct {
+	versions []map[string]interface{} // Data of every version, the latest last.
+	deleted  bool                     // Whether the latest version is soft-deleted.
+}
+
+// Fake of the KV v2 secrets engine mounted at secret/, following its check-and-set and soft delete semantics.
+type fakeKV struct {
+	secrets map[string]*fakeKVSecret // Secrets by path below the mount.
+	sync.Mutex
+}
+
+// Starts a Vault server backed by a fake KV v2 secrets engine.
+func newFakeVault(t *testing.T) (*httptest.Server, *fakeKV) {
+	t.Helper()
+
+	kv := &fakeKV{secrets: make(map[string]*fakeKVSecret)}
+
+	server := httptest.NewServer(kv)
+	t.Cleanup(server.Close)
+
+	return server, kv
+}
+
+func (kv *fakeKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.Lock()
+	defer kv.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/secret/")
+
+	switch {
+	case strings.HasPrefix(path, "data/"):
+		kv.serveData(w, r, strings.TrimPrefix(path, "data/"))
+	case strings.HasPrefix(path, "metadata/") && r.Method == http.MethodDelete:
+		delete(kv.secrets, strings.TrimPrefix(path, "metadata/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+	}
+}
+
+// Serves reads, check-and-set writes and soft deletes of the latest version of the secret.
+func (kv *fakeKV) serveData(w http.ResponseWriter, r *http.Request, path string) {
+	secret := kv.secrets[path]
+
+	switch r.Method {
+	case http.MethodGet:
+		if secret == nil || secret.deleted {
+			writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+
+			return
+		}
+
+		writeVaultResponse(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     secret.versions[len(secret.versions)-1],
+				"metadata": map[string]interface{}{"version": len(secret.versions)},
+			},
+		})
+	case http.MethodPut, http.MethodPost:
+		var request struct {
+			Options map[string]interface{} `json:"options"`
+			Data    map[string]interface{} `json:"data"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeVaultResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{err.Error()}})
+
+			return
+		}
+
+		current := 0
+		if secret != nil {
+			current = len(secret.versions)
+		}
+
+		if cas, ok := request.Options["cas"].(float64); ok && int(cas) != current {
+			writeVaultResponse(w, http.StatusBadRequest, map[string]interface{}{
+				"errors": []string{"check-and-set parameter did not match the current version"},
+			})
+
+			return
+		}
+
+		if secret == nil {
+			secret = &fakeKVSecret{}
+			kv.secrets[path] = secret
+		}
+
+		secret.versions = append(secret.versions, request.Data)
+		secret.deleted = false
+
+		writeVaultResponse(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"version": len(secret.versions)},
+		})
+	case http.MethodDelete:
+		if secret != nil {
+			secret.deleted = true
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeVaultResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Creates a Vault secrets manager for the node against the given server.
+func newTestVaultManager(t *testing.T, serverURL string) secrets.SecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(
+		&secrets.SecretsManagerConfig{Token: "root", ServerURL: serverURL, Name: "node"},
+		&secrets.SecretsManagerParams{Logger: hclog.NewNullLogger()},
+	)
+	if err != nil {
+		t.Fatalf("SecretsManagerFactory() error = %v", err)
+	}
+
+	return manager
+}
+
+func TestVaultSecretsManagerSetRemoveSet(t *testing.T) {
+	server, kv := newFakeVault(t)
+	manager := newTestVaultManager(t, server.URL)
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("first")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("second")); err == nil {
+		t.Fatal("SetSecret() on an existing secret error = nil, want a check-and-set error")
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); err != nil {
+		t.Fatalf("RemoveSecret() error = %v", err)
+	}
+
+	if _, ok := kv.secrets["node/"+secrets.ReporterKey]; ok {
+		t.Fatal("RemoveSecret() kept the secret's version history")
+	}
+
+	if manager.HasSecret(secrets.ReporterKey) {
+		t.Fatal("HasSecret() after RemoveSecret() = true")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("third")); err != nil {
+		t.Fatalf("SetSecret() after RemoveSecret() error = %v", err)
+	}
+
+	value, err := manager.GetSecret(secrets.ReporterKey)
+	if err != nil || string(value) != "third" {
+		t.Fatalf("GetSecret() = %q, %v, want third", value, err)
+	}
+}
+
+func TestVaultSecretsManagerRemoveMissingSecret(t *testing.T) {
+	server, _ := newFakeVault(t)
+	manager := newTestVaultManager(t, server.URL)
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Fatalf("RemoveSecret() error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+}
+
+func TestVaultSecretsManagerRotateSequence(t *testing.T) {
+	server, kv := newFakeVault(t)
+	manager := newTestVaultManager(t, server.URL)
+
+	updater, ok := manager.(secrets.SecretUpdater)
+	if !ok {
+		t.Fatal("Vault secrets manager does not implement secrets.SecretUpdater")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("old")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	// Archive the current key, then replace it in place
+	archiveName := secrets.ReporterKey + "-archive-1700000000"
+	if err := manager.SetSecret(archiveName, []byte("old")); err != nil {
+		t.Fatalf("SetSecret(archive) error = %v", err)
+	}
+
+	if err := updater.UpdateSecret(secrets.ReporterKey, []byte("new")); err != nil {
+		t.Fatalf("UpdateSecret() error = %v", err)
+	}
+
+	if value, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(value) != "new" {
+		t.Fatalf("GetSecret() = %q, %v, want new", value, err)
+	}
+
+	if value, err := manager.GetSecret(archiveName); err != nil || string(value) != "old" {
+		t.Fatalf("GetSecret(archive) = %q, %v, want old", value, err)
+	}
+
+	if versions := len(kv.secrets["node/"+secrets.ReporterKey].versions); versions != 2 {
+		t.Errorf("secret versions = %d, want 2", versions)
+	}
+
+	if err := updater.UpdateSecret("missing", []byte("new")); !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("UpdateSecret() on a missing secret error = %v, want %v", err, secrets.ErrSecretNotFound)
+	}
+}
@@ -1,14 +1,21 @@
 package helper
 
 import (
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
-	"github.com/sx-network/sx-reporter/command/crypto"
+	"github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/helper/keystore"
 	"github.com/sx-network/sx-reporter/helper/types"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/infra/secrets/awsssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/azurekeyvault"
+	"github.com/sx-network/sx-reporter/infra/secrets/env"
+	"github.com/sx-network/sx-reporter/infra/secrets/gcpssm"
+	"github.com/sx-network/sx-reporter/infra/secrets/hashicorpvault"
 	"github.com/sx-network/sx-reporter/infra/secrets/local"
 )
 
@@ -25,10 +32,180 @@ func SetupLocalSecretsManager(dataDir string) (secrets.SecretsManager, error) {
 	)
 }
 
-// InitECDSAValidatorKey creates new ECDSA key and set as a validator key
-func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address, error) {
-	if secretsManager.HasSecret(secrets.ReporterKey) {
-		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ReporterKey)
+// SetupSecretsManager sets up the secrets manager described by the config file if specified,
+// otherwise the local secrets manager for the data directory.
+// Keys written by the local secrets manager are encrypted if encrypt is set
+func SetupSecretsManager(
+	dataDir string,
+	configPath string,
+	encrypt bool,
+	passphraseFile string,
+) (secrets.SecretsManager, error) {
+	if configPath == "" {
+		if encrypt {
+			return SetupEncryptedLocalSecretsManager(dataDir, passphraseFile)
+		}
+
+		return local.SecretsManagerFactory(
+			nil, // Local secrets manager doesn't require a config
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path:           dataDir,
+					secrets.PassphraseFile: passphraseFile,
+				},
+			},
+		)
+	}
+
+	secretsConfig, err := secrets.ReadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets configuration, %w", err)
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errors.New("unsupported secrets manager")
+	}
+
+	return InitCloudSecretsManager(secretsConfig)
+}
+
+// SetupEncryptedLocalSecretsManager is a helper method for boilerplate local secrets manager setup,
+// storing new keys as encrypted JSON keystores
+func SetupEncryptedLocalSecretsManager(dataDir string, passphraseFile string) (secrets.SecretsManager, error) {
+	return local.SecretsManagerFactory(
+		nil, // Local secrets manager doesn't require a config
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+			Extra: map[string]interface{}{
+				secrets.Path:           dataDir,
+				secrets.Encrypt:        true,
+				secrets.PassphraseFile: passphraseFile,
+			},
+		},
+	)
+}
+
+// ImportECDSAValidatorKey imports the ECDSA key from a JSON keystore file and sets it as the validator key held under the secret name
+func ImportECDSAValidatorKey(
+	secretsManager secrets.SecretsManager,
+	secretName string,
+	keystorePath string,
+	passphraseFile string,
+) (types.Address, error) {
+	if secretsManager.HasSecret(secretName) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secretName)
+	}
+
+	passphrase, err := keystore.ReadPassphrase(passphraseFile, false)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	validatorKeyEncoded, err := keystore.ReadKeystoreFile(keystorePath, passphrase)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	defer crypto.ZeroBytes(validatorKeyEncoded)
+
+	return ImportValidatorKey(secretsManager, secretName, validatorKeyEncoded)
+}
+
+// ImportValidatorKey validates the hex encoded ECDSA key and sets it as the validator key held under the secret name
+func ImportValidatorKey(
+	secretsManager secrets.SecretsManager,
+	secretName string,
+	validatorKeyEncoded []byte,
+) (types.Address, error) {
+	if secretsManager.HasSecret(secretName) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secretName)
+	}
+
+	validatorKey, err := crypto.BytesToECDSAPrivateKey(validatorKeyEncoded)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	defer crypto.ZeroKey(validatorKey)
+
+	// Write the validator private key to the secrets manager storage
+	if setErr := secretsManager.SetSecret(
+		secretName,
+		validatorKeyEncoded,
+	); setErr != nil {
+		return types.ZeroAddress, setErr
+	}
+
+	return crypto.PubKeyToAddress(&validatorKey.PublicKey), nil
+}
+
+// RotateECDSAValidatorKey archives the validator key held under the secret name under a new archive name,
+// then creates a new ECDSA key and stores it in place of the validator key.
+// The validator key is replaced without being removed, so it is left in place if the new key cannot be stored
+func RotateECDSAValidatorKey(
+	secretsManager secrets.SecretsManager,
+	secretName string,
+) (oldAddress types.Address, newAddress types.Address, archiveName string, err error) {
+	updater, ok := secretsManager.(secrets.SecretUpdater)
+	if !ok {
+		return types.ZeroAddress, types.ZeroAddress, "", errors.New("secrets manager does not support replacing secrets")
+	}
+
+	oldKeyEncoded, err := secretsManager.GetSecret(secretName)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroAddress, "", fmt.Errorf(`unable to load secret "%s", %w`, secretName, err)
+	}
+
+	defer crypto.ZeroBytes(oldKeyEncoded)
+
+	oldKey, err := crypto.BytesToECDSAPrivateKey(oldKeyEncoded)
+	if err != nil {
+		return types.ZeroAddress, types.ZeroAddress, "", err
+	}
+
+	defer crypto.ZeroKey(oldKey)
+
+	oldAddress = crypto.PubKeyToAddress(&oldKey.PublicKey)
+
+	newKey, newKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	if err != nil {
+		return oldAddress, types.ZeroAddress, "", err
+	}
+
+	defer crypto.ZeroKey(newKey)
+	defer crypto.ZeroBytes(newKeyEncoded)
+
+	newAddress = crypto.PubKeyToAddress(&newKey.PublicKey)
+
+	// Archive the current validator key before replacing it
+	archiveName = secrets.ArchivedReporterKey(secretName, time.Now())
+	if err := secretsManager.SetSecret(archiveName, oldKeyEncoded); err != nil {
+		return oldAddress, newAddress, "", fmt.Errorf(`unable to archive secret "%s", %w`, secretName, err)
+	}
+
+	if err := updater.UpdateSecret(secretName, newKeyEncoded); err != nil {
+		// The current validator key is still in place, so its archive is dropped
+		if removeErr := secretsManager.RemoveSecret(archiveName); removeErr != nil {
+			return oldAddress, newAddress, archiveName, fmt.Errorf(
+				`unable to store new secret "%s", %w, and unable to remove archive "%s", %v`,
+				secretName,
+				err,
+				archiveName,
+				removeErr,
+			)
+		}
+
+		return oldAddress, newAddress, "", fmt.Errorf(`unable to store new secret "%s", %w`, secretName, err)
+	}
+
+	return oldAddress, newAddress, archiveName, nil
+}
+
+// InitECDSAValidatorKey creates new ECDSA key and set as the validator key held under the secret name
+func InitECDSAValidatorKey(secretsManager secrets.SecretsManager, secretName string) (types.Address, error) {
+	if secretsManager.HasSecret(secretName) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secretName)
 	}
 
 	validatorKey, validatorKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
@@ -36,11 +213,14 @@ func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address
 		return types.ZeroAddress, err
 	}
 
+	defer crypto.ZeroKey(validatorKey)
+	defer crypto.ZeroBytes(validatorKeyEncoded)
+
 	address := crypto.PubKeyToAddress(&validatorKey.PublicKey)
 
 	// Write the validator private key to the secrets manager storage
 	if setErr := secretsManager.SetSecret(
-		secrets.ReporterKey,
+		secretName,
 		validatorKeyEncoded,
 	); setErr != nil {
 		return types.ZeroAddress, setErr
@@ -49,23 +229,37 @@ func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address
 	return address, nil
 }
 
-// LoadValidatorAddress loads ECDSA key by SecretsManager and returns reporter address
-func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address, error) {
-	if !secretsManager.HasSecret(secrets.ReporterKey) {
+// LoadValidatorAddress loads the ECDSA key held under the secret name and returns reporter address
+func LoadValidatorAddress(secretsManager secrets.SecretsManager, secretName string) (types.Address, error) {
+	if !secretsManager.HasSecret(secretName) {
 		return types.ZeroAddress, nil
 	}
 
-	encodedKey, err := secretsManager.GetSecret(secrets.ReporterKey)
+	publicKey, err := LoadValidatorPublicKey(secretsManager, secretName)
 	if err != nil {
 		return types.ZeroAddress, err
 	}
 
+	return crypto.PubKeyToAddress(publicKey), nil
+}
+
+// LoadValidatorPublicKey loads the ECDSA key held under the secret name and returns its public key
+func LoadValidatorPublicKey(secretsManager secrets.SecretsManager, secretName string) (*ecdsa.PublicKey, error) {
+	encodedKey, err := secretsManager.GetSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer crypto.ZeroBytes(encodedKey)
+
 	privateKey, err := crypto.BytesToECDSAPrivateKey(encodedKey)
 	if err != nil {
-		return types.ZeroAddress, err
+		return nil, err
 	}
 
-	return crypto.PubKeyToAddress(&privateKey.PublicKey), nil
+	defer crypto.ZeroKey(privateKey)
+
+	return &privateKey.PublicKey, nil
 }
 
 // LoadNodeID loads Libp2p key by SecretsManager and returns Node ID
@@ -102,6 +296,34 @@ func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secre
 		}
 
 		secretsManager = AWSSSM
+	case secrets.HashicorpVault:
+		vault, err := setupHashicorpVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = vault
+	case secrets.GCPSSM:
+		GCPSSM, err := setupGCPSSM(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = GCPSSM
+	case secrets.AzureKeyVault:
+		azureKeyVault, err := setupAzureKeyVault(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = azureKeyVault
+	case secrets.Env:
+		envManager, err := setupEnv(secretsConfig)
+		if err != nil {
+			return secretsManager, err
+		}
+
+		secretsManager = envManager
 	default:
 		return secretsManager, errors.New("unsupported secrets manager")
 	}
@@ -120,3 +342,51 @@ func setupAWSSSM(
 		},
 	)
 }
+
+// setupHashicorpVault is a helper method for boilerplate hashicorp vault secrets manager setup
+func setupHashicorpVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return hashicorpvault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupGCPSSM is a helper method for boilerplate gcp secret manager setup
+func setupGCPSSM(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return gcpssm.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupAzureKeyVault is a helper method for boilerplate azure key vault secrets manager setup
+func setupAzureKeyVault(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return azurekeyvault.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
+
+// setupEnv is a helper method for boilerplate env secrets manager setup
+func setupEnv(
+	secretsConfig *secrets.SecretsManagerConfig,
+) (secrets.SecretsManager, error) {
+	return env.SecretsManagerFactory(
+		secretsConfig,
+		&secrets.SecretsManagerParams{
+			Logger: hclog.NewNullLogger(),
+		},
+	)
+}
@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Local secrets manager failing to replace secrets.
+type failingUpdater struct {
+	secrets.SecretsManager
+}
+
+func (f *failingUpdater) UpdateSecret(string, []byte) error {
+	return errors.New("update rejected")
+}
+
+// Secrets manager unable to replace secrets.
+type readOnlyManager struct {
+	secrets.SecretsManager
+}
+
+func TestRotateECDSAValidatorKey(t *testing.T) {
+	for _, secretName := range []string{secrets.ReporterKey, secrets.NamedReporterKey("hot")} {
+		t.Run(secretName, func(t *testing.T) {
+			manager, err := SetupLocalSecretsManager(t.TempDir())
+			if err != nil {
+				t.Fatalf("SetupLocalSecretsManager() error = %v", err)
+			}
+
+			initAddress, err := InitECDSAValidatorKey(manager, secretName)
+			if err != nil {
+				t.Fatalf("InitECDSAValidatorKey() error = %v", err)
+			}
+
+			oldAddress, newAddress, archiveName, err := RotateECDSAValidatorKey(manager, secretName)
+			if err != nil {
+				t.Fatalf("RotateECDSAValidatorKey() error = %v", err)
+			}
+
+			if oldAddress != initAddress || newAddress == oldAddress {
+				t.Fatalf("RotateECDSAValidatorKey() = %s, %s, want %s and a new address", oldAddress, newAddress, initAddress)
+			}
+
+			if address, err := LoadValidatorAddress(manager, secretName); err != nil || address != newAddress {
+				t.Errorf("LoadValidatorAddress() = %s, %v, want %s", address, err, newAddress)
+			}
+
+			if !secrets.IsArchivedReporterKey(archiveName) {
+				t.Fatalf("archive name %q is not an archived reporter key", archiveName)
+			}
+
+			if address, err := LoadValidatorAddress(manager, archiveName); err != nil || address != oldAddress {
+				t.Errorf("LoadValidatorAddress(archive) = %s, %v, want %s", address, err, oldAddress)
+			}
+		})
+	}
+}
+
+func TestRotateECDSAValidatorKeyUpdateFailure(t *testing.T) {
+	manager, err := SetupLocalSecretsManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("SetupLocalSecretsManager() error = %v", err)
+	}
+
+	initAddress, err := InitECDSAValidatorKey(manager, secrets.ReporterKey)
+	if err != nil {
+		t.Fatalf("InitECDSAValidatorKey() error = %v", err)
+	}
+
+	_, _, archiveName, err := RotateECDSAValidatorKey(&failingUpdater{manager}, secrets.ReporterKey)
+	if err == nil {
+		t.Fatal("RotateECDSAValidatorKey() error = nil, want the update error")
+	}
+
+	if archiveName != "" {
+		t.Errorf("archive name = %q, want the archive removed", archiveName)
+	}
+
+	if address, err := LoadValidatorAddress(manager, secrets.ReporterKey); err != nil || address != initAddress {
+		t.Errorf("LoadValidatorAddress() = %s, %v, want the current key %s left in place", address, err, initAddress)
+	}
+}
+
+func TestRotateECDSAValidatorKeyUnsupported(t *testing.T) {
+	manager, err := SetupLocalSecretsManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("SetupLocalSecretsManager() error = %v", err)
+	}
+
+	initAddress, err := InitECDSAValidatorKey(manager, secrets.ReporterKey)
+	if err != nil {
+		t.Fatalf("InitECDSAValidatorKey() error = %v", err)
+	}
+
+	if _, _, _, err := RotateECDSAValidatorKey(&readOnlyManager{manager}, secrets.ReporterKey); err == nil {
+		t.Fatal("RotateECDSAValidatorKey() error = nil, want an unsupported error")
+	}
+
+	if address, err := LoadValidatorAddress(manager, secrets.ReporterKey); err != nil || address != initAddress {
+		t.Errorf("LoadValidatorAddress() = %s, %v, want %s", address, err, initAddress)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/helper/keystore"
 	"github.com/sx-network/sx-reporter/infra/common"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 )
@@ -26,6 +27,19 @@ type LocalSecretsManager struct {
 
 	// Mux for the secretPathMap
 	secretPathMapLock sync.RWMutex
+
+	// Whether new secrets are written as encrypted JSON keystores
+	encrypt bool
+
+	// Path to the keystore passphrase file, the passphrase
+	// is read from the environment or prompted for if empty
+	passphraseFile string
+
+	// Keystore passphrase, read once when first needed
+	passphrase string
+
+	// Mux for the passphrase
+	passphraseLock sync.Mutex
 }
 
 // SecretsManagerFactory implements the factory method
@@ -50,6 +64,20 @@ func SecretsManagerFactory(
 		return nil, errors.New("invalid type assertion")
 	}
 
+	if encrypt, ok := params.Extra[secrets.Encrypt]; ok {
+		localManager.encrypt, ok = encrypt.(bool)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+	}
+
+	if passphraseFile, ok := params.Extra[secrets.PassphraseFile]; ok {
+		localManager.passphraseFile, ok = passphraseFile.(string)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+	}
+
 	// Run the initial setup
 	_ = localManager.Setup()
 
@@ -78,15 +106,40 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.ReporterKeyLocal,
 	)
 
+	// Keep storing keys encrypted once the reporter key is stored as an encrypted keystore
+	if secret, err := os.ReadFile(l.secretPathMap[secrets.ReporterKey]); err == nil && keystore.IsEncryptedKey(secret) {
+		l.encrypt = true
+	}
+
 	return nil
 }
 
-// GetSecret gets the local SecretsManager's secret from disk
-func (l *LocalSecretsManager) GetSecret(name string) ([]byte, error) {
+// getSecretPath returns the path of the secret on disk.
+// Named reporter keys are stored in their own key files, and archived reporter keys
+// are stored next to the reporter key, under their secret name
+func (l *LocalSecretsManager) getSecretPath(name string) (string, bool) {
 	l.secretPathMapLock.RLock()
 	secretPath, ok := l.secretPathMap[name]
 	l.secretPathMapLock.RUnlock()
 
+	if ok {
+		return secretPath, true
+	}
+
+	if keyName, named := secrets.ReporterKeyNameOf(name); named {
+		return filepath.Join(l.path, secrets.NamedReporterKeyLocal(keyName)), true
+	}
+
+	if secrets.IsArchivedReporterKey(name) {
+		return filepath.Join(l.path, name), true
+	}
+
+	return "", false
+}
+
+// GetSecret gets the local SecretsManager's secret from disk
+func (l *LocalSecretsManager) GetSecret(name string) ([]byte, error) {
+	secretPath, ok := l.getSecretPath(name)
 	if !ok {
 		return nil, secrets.ErrSecretNotFound
 	}
@@ -101,6 +154,16 @@ func (l *LocalSecretsManager) GetSecret(name string) ([]byte, error) {
 		)
 	}
 
+	// Decrypt the secret if it is stored as an encrypted keystore
+	if keystore.IsEncryptedKey(secret) {
+		passphrase, err := l.getPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
+
+		return keystore.DecryptKey(secret, passphrase)
+	}
+
 	return secret, nil
 }
 
@@ -111,10 +174,7 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 		return nil
 	}
 
-	l.secretPathMapLock.Lock()
-	secretPath, ok := l.secretPathMap[name]
-	l.secretPathMapLock.Unlock()
-
+	secretPath, ok := l.getSecretPath(name)
 	if !ok {
 		return secrets.ErrSecretNotFound
 	}
@@ -126,8 +186,56 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 			secretPath,
 		)
 	}
-	// Write the secret to disk
-	if err := os.WriteFile(secretPath, value, os.ModePerm); err != nil {
+
+	return l.writeSecret(secretPath, value)
+}
+
+// UpdateSecret replaces the local SecretsManager's existing secret on disk.
+// The new secret is written next to it first and then renamed over it, so the secret is never left half written
+func (l *LocalSecretsManager) UpdateSecret(name string, value []byte) error {
+	secretPath, ok := l.getSecretPath(name)
+	if !ok {
+		return secrets.ErrSecretNotFound
+	}
+
+	if _, err := os.Stat(secretPath); err != nil {
+		return secrets.ErrSecretNotFound
+	}
+
+	tempPath := secretPath + ".new"
+
+	// Clear a leftover from an interrupted update
+	if err := os.Remove(tempPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove secret from disk (%s), %w", tempPath, err)
+	}
+
+	if err := l.writeSecret(tempPath, value); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempPath, secretPath); err != nil {
+		_ = os.Remove(tempPath)
+
+		return fmt.Errorf("unable to replace secret on disk (%s), %w", secretPath, err)
+	}
+
+	return nil
+}
+
+// writeSecret writes the secret to a new file at the path on disk
+func (l *LocalSecretsManager) writeSecret(secretPath string, value []byte) error {
+	// Write the secret to disk as an encrypted keystore if enabled
+	if l.encrypt {
+		passphrase, err := l.getPassphrase(true)
+		if err != nil {
+			return err
+		}
+
+		return keystore.WriteKeystoreFile(secretPath, value, passphrase)
+	}
+
+	// Write the secret to disk, readable only by the current user
+	if err := os.WriteFile(secretPath, value, 0600); err != nil {
 		return fmt.Errorf(
 			"unable to write secret to disk (%s), %w",
 			secretPath,
@@ -138,28 +246,49 @@ func (l *LocalSecretsManager) SetSecret(name string, value []byte) error {
 	return nil
 }
 
-// HasSecret checks if the secret is present on disk
+// HasSecret checks if the secret is present on disk.
+// Only the secret file is checked, so encrypted secrets are not decrypted and no passphrase is needed
 func (l *LocalSecretsManager) HasSecret(name string) bool {
-	_, err := l.GetSecret(name)
+	secretPath, ok := l.getSecretPath(name)
+	if !ok {
+		return false
+	}
+
+	info, err := os.Stat(secretPath)
 
-	return err == nil
+	return err == nil && info.Mode().IsRegular()
 }
 
 // RemoveSecret removes the local SecretsManager's secret from disk
 func (l *LocalSecretsManager) RemoveSecret(name string) error {
-	l.secretPathMapLock.Lock()
-	secretPath, ok := l.secretPathMap[name]
-	defer l.secretPathMapLock.Unlock()
-
+	// The secret path is kept, so that the secret can be set again once removed
+	secretPath, ok := l.getSecretPath(name)
 	if !ok {
 		return secrets.ErrSecretNotFound
 	}
 
-	delete(l.secretPathMap, name)
-
 	if removeErr := os.Remove(secretPath); removeErr != nil {
 		return fmt.Errorf("unable to remove secret, %w", removeErr)
 	}
 
 	return nil
 }
+
+// getPassphrase returns the keystore passphrase, reading it on first use
+func (l *LocalSecretsManager) getPassphrase(confirm bool) (string, error) {
+	l.passphraseLock.Lock()
+	defer l.passphraseLock.Unlock()
+
+	if l.passphrase != "" {
+		return l.passphrase, nil
+	}
+
+	passphrase, err := keystore.ReadPassphrase(l.passphraseFile, confirm)
+	if err != nil {
+		return "", err
+	}
+
+	l.passphrase = passphrase
+
+	return passphrase, nil
+}
@@ -0,0 +1,143 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/helper/keystore"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// Creates a local secrets manager in the given directory, reading the keystore passphrase from passphraseFile.
+func newTestManager(t *testing.T, path string, encrypt bool, passphraseFile string) secrets.SecretsManager {
+	t.Helper()
+
+	manager, err := SecretsManagerFactory(nil, &secrets.SecretsManagerParams{
+		Logger: hclog.NewNullLogger(),
+		Extra: map[string]interface{}{
+			secrets.Path:           path,
+			secrets.Encrypt:        encrypt,
+			secrets.PassphraseFile: passphraseFile,
+		},
+	})
+	if err != nil {
+		t.Fatalf("SecretsManagerFactory() error = %v", err)
+	}
+
+	return manager
+}
+
+func TestLocalSecretsManagerPlaintext(t *testing.T) {
+	path := t.TempDir()
+	manager := newTestManager(t, path, false, "")
+
+	if manager.HasSecret(secrets.ReporterKey) {
+		t.Fatal("HasSecret() = true before the secret was set")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte(testKey)); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	// secrets are never overwritten by SetSecret
+	if err := manager.SetSecret(secrets.ReporterKey, []byte(testKey)); err == nil {
+		t.Error("SetSecret() error = nil for an existing secret")
+	}
+
+	info, err := os.Stat(filepath.Join(path, secrets.ReporterKeyLocal))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("secret file = %v, %v, want a file readable only by the current user", info, err)
+	}
+
+	if secret, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(secret) != testKey {
+		t.Fatalf("GetSecret() = %s, %v, want %s", secret, err, testKey)
+	}
+
+	if err := manager.RemoveSecret(secrets.ReporterKey); err != nil {
+		t.Fatalf("RemoveSecret() error = %v", err)
+	}
+
+	if manager.HasSecret(secrets.ReporterKey) {
+		t.Error("HasSecret() = true once the secret was removed")
+	}
+
+	if manager.HasSecret("unknown") {
+		t.Error("HasSecret() = true for an unknown secret")
+	}
+}
+
+func TestLocalSecretsManagerEncrypted(t *testing.T) {
+	path := t.TempDir()
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+
+	if err := os.WriteFile(passphraseFile, []byte("correct horse\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := newTestManager(t, path, true, passphraseFile)
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte(testKey)); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	stored, err := os.ReadFile(filepath.Join(path, secrets.ReporterKeyLocal))
+	if err != nil || !keystore.IsEncryptedKey(stored) {
+		t.Fatalf("stored secret is not an encrypted keystore, %v", err)
+	}
+
+	if secret, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(secret) != testKey {
+		t.Fatalf("GetSecret() = %s, %v, want %s", secret, err, testKey)
+	}
+
+	// the secret is found without a passphrase, which is only needed to read it
+	reopened := newTestManager(t, path, false, filepath.Join(path, "missing-passphrase"))
+
+	if !reopened.HasSecret(secrets.ReporterKey) {
+		t.Error("HasSecret() = false for an encrypted secret without its passphrase")
+	}
+
+	if _, err := reopened.GetSecret(secrets.ReporterKey); err == nil {
+		t.Error("GetSecret() error = nil without the keystore passphrase")
+	}
+
+	// new secrets are encrypted like the existing reporter key
+	name := secrets.NamedReporterKey("hot")
+
+	if err := newTestManager(t, path, false, passphraseFile).SetSecret(name, []byte(testKey)); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	stored, err = os.ReadFile(filepath.Join(path, secrets.NamedReporterKeyLocal("hot")))
+	if err != nil || !keystore.IsEncryptedKey(stored) {
+		t.Errorf("named secret is not an encrypted keystore, %v", err)
+	}
+}
+
+func TestLocalSecretsManagerUpdateSecret(t *testing.T) {
+	manager := newTestManager(t, t.TempDir(), false, "")
+
+	updater, ok := manager.(secrets.SecretUpdater)
+	if !ok {
+		t.Fatal("local secrets manager does not implement SecretUpdater")
+	}
+
+	if err := updater.UpdateSecret(secrets.ReporterKey, []byte(testKey)); err == nil {
+		t.Error("UpdateSecret() error = nil for a missing secret")
+	}
+
+	if err := manager.SetSecret(secrets.ReporterKey, []byte("old")); err != nil {
+		t.Fatalf("SetSecret() error = %v", err)
+	}
+
+	if err := updater.UpdateSecret(secrets.ReporterKey, []byte(testKey)); err != nil {
+		t.Fatalf("UpdateSecret() error = %v", err)
+	}
+
+	if secret, err := manager.GetSecret(secrets.ReporterKey); err != nil || string(secret) != testKey {
+		t.Errorf("GetSecret() = %s, %v, want %s", secret, err, testKey)
+	}
+}
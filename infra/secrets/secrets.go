@@ -3,7 +3,13 @@ package secrets
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -35,6 +41,14 @@ const (
 	Local SecretsManagerType = "local"
 	// AWSSSM pertains to AWS SSM using configured EC2 instance role
 	AWSSSM SecretsManagerType = "aws-ssm"
+	// HashicorpVault pertains to the HashiCorp Vault KV v2 secrets engine
+	HashicorpVault SecretsManagerType = "hashicorp-vault"
+	// GCPSSM pertains to GCP Secret Manager using a service account credentials file
+	GCPSSM SecretsManagerType = "gcp-ssm"
+	// AzureKeyVault pertains to Azure Key Vault using Azure AD credentials
+	AzureKeyVault SecretsManagerType = "azure-key-vault"
+	// Env pertains to a read-only key taken from an environment variable or a mounted file
+	Env SecretsManagerType = "env"
 )
 
 // Constants representing keys used in the SecretsManagerParams Extra map for configuration.
@@ -47,6 +61,10 @@ const (
 	Server = "server"
 	// Name is the name of the current node
 	Name = "name"
+	// Encrypt indicates whether the local secrets manager stores keys in encrypted JSON keystores
+	Encrypt = "encrypt"
+	// PassphraseFile is the path to the file holding the local keystore passphrase
+	PassphraseFile = "passphrase-file"
 )
 
 // It is the factory method for creating secrets managers.
@@ -80,18 +98,127 @@ type SecretsManager interface {
 	RemoveSecret(name string) error
 }
 
+// Defines the interface of secrets managers able to sign transactions
+// with a key that never leaves the secrets manager.
+type TxSigner interface {
+	// SignerAddress returns the address of the signing key.
+	SignerAddress() (string, error)
+	// SignTx signs the legacy transaction using EIP-155 and returns its RLP encoding.
+	SignTx(tx *SignTxRequest) ([]byte, error)
+}
+
+// Defines the interface of secrets managers able to replace the value of an existing secret in place,
+// such as by storing it as a new version of the secret.
+type SecretUpdater interface {
+	// UpdateSecret replaces the value of the existing secret with the given name.
+	// It returns ErrSecretNotFound if the secret does not exist.
+	UpdateSecret(name string, value []byte) error
+}
+
+// Represents a transaction to be signed by a TxSigner.
+type SignTxRequest struct {
+	To       string   // Recipient address
+	Data     []byte   // Transaction input
+	Value    *big.Int // Value in wei
+	Gas      uint64   // Gas limit
+	GasPrice uint64   // Gas price in wei
+	Nonce    uint64   // Sender nonce
+	ChainID  *big.Int // Chain ID used for replay protection
+}
+
 // Constants representing names for available secrets.
 const (
 	// ReporterKey is the private key secret of the reporter node
 	ReporterKey = "validator-key"
 )
 
+// DefaultReporterKeyName is the name of the reporter key held under ReporterKey
+const DefaultReporterKeyName = "default"
+
+// reporterKeyArchiveInfix separates the secret name of a rotated reporter key from its rotation time
+const reporterKeyArchiveInfix = "-archive-"
+
+// reporterKeyNameRegex matches the names of additional reporter keys
+var reporterKeyNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// ErrInvalidReporterKeyName is returned for reporter key names that cannot be stored on every backend
+var ErrInvalidReporterKeyName = errors.New(
+	"invalid reporter key name, expected up to 32 lowercase alphanumeric characters and dashes, not containing 'archive'",
+)
+
+// ValidateReporterKeyName checks whether the name can be used for a reporter key.
+// Names only contain alphanumeric characters and dashes, as required by the most restrictive backends
+func ValidateReporterKeyName(keyName string) error {
+	if keyName == "" || keyName == DefaultReporterKeyName {
+		return nil
+	}
+
+	if !reporterKeyNameRegex.MatchString(keyName) || strings.Contains(keyName, "archive") {
+		return fmt.Errorf("%w: %s", ErrInvalidReporterKeyName, keyName)
+	}
+
+	return nil
+}
+
+// NamedReporterKey returns the name of the secret holding the named reporter key.
+// The default key, also selected by an empty name, is held under ReporterKey
+func NamedReporterKey(keyName string) string {
+	if keyName == "" || keyName == DefaultReporterKeyName {
+		return ReporterKey
+	}
+
+	return ReporterKey + "-" + keyName
+}
+
+// ReporterKeyNameOf returns the name of the additional reporter key held under the secret name,
+// or false if the secret does not hold an additional reporter key
+func ReporterKeyNameOf(name string) (string, bool) {
+	keyName, ok := strings.CutPrefix(name, ReporterKey+"-")
+	if !ok || keyName == "" || keyName == DefaultReporterKeyName || ValidateReporterKeyName(keyName) != nil {
+		return "", false
+	}
+
+	return keyName, true
+}
+
+// ArchivedReporterKey returns the name under which the reporter key held under the secret name
+// and rotated at the given time is archived
+func ArchivedReporterKey(name string, rotatedAt time.Time) string {
+	return fmt.Sprintf("%s%s%d", name, reporterKeyArchiveInfix, rotatedAt.Unix())
+}
+
+// IsArchivedReporterKey checks whether the secret name is the name of an archived reporter key
+func IsArchivedReporterKey(name string) bool {
+	index := strings.LastIndex(name, reporterKeyArchiveInfix)
+	if index < 0 {
+		return false
+	}
+
+	base, suffix := name[:index], name[index+len(reporterKeyArchiveInfix):]
+	if suffix == "" {
+		return false
+	}
+
+	if _, err := strconv.ParseUint(suffix, 10, 64); err != nil {
+		return false
+	}
+
+	_, named := ReporterKeyNameOf(base)
+
+	return base == ReporterKey || named
+}
+
 // Constants representing file names for the local StorageManager.
 const (
 	// It is the file name for the reporter node's private key in the local StorageManager.
 	ReporterKeyLocal = "reporter.key"
 )
 
+// NamedReporterKeyLocal returns the file name for an additional named reporter key in the local StorageManager
+func NamedReporterKeyLocal(keyName string) string {
+	return fmt.Sprintf("reporter-%s.key", keyName)
+}
+
 // It is an error indicating that a secret was not found.
 var ErrSecretNotFound = errors.New("secret not found")
 
@@ -99,7 +226,7 @@ var ErrSecretNotFound = errors.New("secret not found")
 func (c *SecretsManagerConfig) WriteConfig(path string) error {
 	jsonBytes, _ := json.MarshalIndent(c, "", " ")
 
-	return os.WriteFile(path, jsonBytes, os.ModePerm)
+	return os.WriteFile(path, jsonBytes, 0600)
 }
 
 // ReadConfig reads the SecretsManagerConfig from the specified path
@@ -122,5 +249,9 @@ func ReadConfig(path string) (*SecretsManagerConfig, error) {
 // SupportedServiceManager checks if the passed in service manager type is supported
 func SupportedServiceManager(service SecretsManagerType) bool {
 	return service == AWSSSM ||
+		service == HashicorpVault ||
+		service == GCPSSM ||
+		service == AzureKeyVault ||
+		service == Env ||
 		service == Local
 }
@@ -1,80 +1,359 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/hashicorp/go-hclog"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/reporter"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// Version of the config schema. Files without a version are read as the current version.
+	ConfigVersion = 1
+	// Default log level of the server logger.
+	DefaultLogLevel = "INFO"
+	// Default JSON-RPC HTTP endpoint.
+	DefaultJSONRPCURL = "https://rpc.sx-rollup-testnet.t.raas.gelato.cloud"
+	// Default JSON-RPC WebSocket endpoint.
+	DefaultJSONRPCWsURL = "wss://ws.sx-rollup-testnet.t.raas.gelato.cloud"
+)
+
 // YAMLServerConfig represents the configuration of a server, typically loaded from a YAML file.
 type YAMLServerConfig struct {
 	// Specifies the path to the configuration file.
-	ConfigPath string
+	ConfigPath string `json:"-" yaml:"-" toml:"-"`
+	// Version of the config schema.
+	Version int `json:"version" yaml:"version" toml:"version"`
+	// Log level of the server logger, one of TRACE, DEBUG, INFO, WARN or ERROR.
+	LogLevel string `json:"log_level" yaml:"log_level" toml:"log_level"`
+	// Log level overrides by subsystem, e.g. reporter.tx. Overriding a subsystem applies to its own subsystems too.
+	LogLevels map[string]string `json:"log_levels" yaml:"log_levels" toml:"log_levels"`
+	// Path of the log file. Logs are written to standard output if empty.
+	LogFile string `json:"log_file" yaml:"log_file" toml:"log_file"`
+	// Rotation of the log file.
+	LogRotation *YAMLLogRotationConfig `json:"log_rotation" yaml:"log_rotation" toml:"log_rotation"`
 	// Indicates whether to use JSON log format.
-	JSONLogFormat bool `json:"json_log_format" yaml:"json_log_format"`
+	JSONLogFormat bool `json:"json_log_format" yaml:"json_log_format" toml:"json_log_format"`
 	// Specifies the directory for storing data.
-	DataDir string `json:"data_dir" yaml:"data_dir"`
+	DataDir string `json:"data_dir" yaml:"data_dir" toml:"data_dir"`
 	// Specifies the path to the secrets configuration file.
-	SecretsConfigPath string `json:"secrets_config" yaml:"secrets_config"`
+	SecretsConfigPath string `json:"secrets_config" yaml:"secrets_config" toml:"secrets_config"`
+	// Specifies the path to the passphrase file of an encrypted local keystore.
+	KeystorePassphraseFile string `json:"keystore_passphrase_file" yaml:"keystore_passphrase_file" toml:"keystore_passphrase_file"`
+	// Specifies the listen address of the operator API. The API is disabled if empty.
+	OperatorAddr string `json:"operator_addr" yaml:"operator_addr" toml:"operator_addr"`
+	// Specifies the path to the file holding the bearer token required by the operator API.
+	// The API is unauthenticated if empty, which is only allowed on a loopback listen address.
+	OperatorTokenFile string `json:"operator_token_file" yaml:"operator_token_file" toml:"operator_token_file"`
+	// Specifies the listen address of the prometheus metrics endpoint. Metrics are not served if empty.
+	PrometheusAddr string `json:"prometheus_addr" yaml:"prometheus_addr" toml:"prometheus_addr"`
 	// Contains the configuration for the reporter.
-	YAMLReporterConfig *YAMLReporterConfig `json:"reporter" yaml:"reporter"`
-	// Contains the configuration for secrets management.
-	SecretsConfig *secrets.SecretsManagerConfig
+	YAMLReporterConfig *YAMLReporterConfig `json:"reporter" yaml:"reporter" toml:"reporter"`
+	// Contains the configuration for exporting traces.
+	Tracing *YAMLTracingConfig `json:"tracing" yaml:"tracing" toml:"tracing"`
+	// Contains the configuration for secrets management, read from SecretsConfigPath.
+	SecretsConfig *secrets.SecretsManagerConfig `json:"-" yaml:"-" toml:"-"`
+}
+
+// YAMLLogRotationConfig represents the rotation policy of the log file.
+type YAMLLogRotationConfig struct {
+	// Size in megabytes the log file reaches before it is rotated. Defaults to 100.
+	MaxSizeMB int `json:"max_size_mb" yaml:"max_size_mb" toml:"max_size_mb"`
+	// Interval in hours between time-based rotations, in addition to the size-based ones. Disabled if omitted.
+	IntervalHours uint64 `json:"interval_hours" yaml:"interval_hours" toml:"interval_hours"`
+	// Number of rotated log files kept. All are kept if omitted.
+	MaxBackups int `json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+	// Age in days after which rotated log files are deleted. Kept regardless of age if omitted.
+	MaxAgeDays int `json:"max_age_days" yaml:"max_age_days" toml:"max_age_days"`
+	// Whether rotated log files are compressed with gzip.
+	Compress bool `json:"compress" yaml:"compress" toml:"compress"`
+}
+
+// YAMLTracingConfig represents the export of OpenTelemetry traces to an OTLP collector.
+type YAMLTracingConfig struct {
+	// Endpoint of the OTLP collector as host:port. Tracing is disabled if omitted.
+	OTLPEndpoint string `json:"otlp_endpoint" yaml:"otlp_endpoint" toml:"otlp_endpoint"`
+	// Protocol of the OTLP collector, grpc or http. Defaults to grpc.
+	Protocol string `json:"protocol" yaml:"protocol" toml:"protocol"`
+	// Whether the collector is reached without TLS.
+	Insecure bool `json:"insecure" yaml:"insecure" toml:"insecure"`
+	// Ratio of the traces sampled, between 0 and 1. Defaults to 1.
+	SampleRatio float64 `json:"sample_ratio" yaml:"sample_ratio" toml:"sample_ratio"`
+	// Service name reported with the spans. Defaults to sx-reporter.
+	ServiceName string `json:"service_name" yaml:"service_name" toml:"service_name"`
 }
 
 // YAMLReporterConfig represents the configuration of a reporter, typically part of the server configuration.
 type YAMLReporterConfig struct {
-	AMQPURI                string `json:"amqp_uri" yaml:"amqp_uri"`
-	AMQPExchangeName       string `json:"amqp_exchange_name" yaml:"amqp_exchange_name"`
-	AMQPQueueName          string `json:"amqp_queue_name" yaml:"amqp_queue_name"`
-	VerifyOutcomeAPIURL    string `json:"verify_outcome_api_url" yaml:"verify_outcome_api_url"`
-	OutcomeReporterAddress string `json:"outcome_reporter_address" yaml:"outcome_reporter_address"`
-	SXNodeAddress          string `json:"sx_node_address" yaml:"sx_node_address"`
+	AMQPURI                string `json:"amqp_uri" yaml:"amqp_uri" toml:"amqp_uri"`
+	AMQPExchangeName       string `json:"amqp_exchange_name" yaml:"amqp_exchange_name" toml:"amqp_exchange_name"`
+	AMQPQueueName          string `json:"amqp_queue_name" yaml:"amqp_queue_name" toml:"amqp_queue_name"`
+	VerifyOutcomeAPIURL    string `json:"verify_outcome_api_url" yaml:"verify_outcome_api_url" toml:"verify_outcome_api_url"`
+	OutcomeReporterAddress string `json:"outcome_reporter_address" yaml:"outcome_reporter_address" toml:"outcome_reporter_address"`
+	SXNodeAddress          string `json:"sx_node_address" yaml:"sx_node_address" toml:"sx_node_address"`
+	// Interval in seconds between scheduled refreshes of the on-chain voting period.
+	VotingPeriodRefreshSeconds uint64 `json:"voting_period_refresh_seconds" yaml:"voting_period_refresh_seconds" toml:"voting_period_refresh_seconds"`
+	// Roles required for proposing, voting and reporting. Roles that are omitted or set to none are not checked.
+	ProposeRole string `json:"propose_role" yaml:"propose_role" toml:"propose_role"`
+	VoteRole    string `json:"vote_role" yaml:"vote_role" toml:"vote_role"`
+	ReportRole  string `json:"report_role" yaml:"report_role" toml:"report_role"`
+	// Staking contract checked for the eligibility of the reporter keys to vote and report, along with the signature
+	// of its eligibility view function taking an address and returning a bool. Staking is unchecked if omitted.
+	StakingAddress             string `json:"staking_address" yaml:"staking_address" toml:"staking_address"`
+	StakingEligibilityFunction string `json:"staking_eligibility_function" yaml:"staking_eligibility_function" toml:"staking_eligibility_function"`
+	// Interval in seconds between scheduled role and staking eligibility checks.
+	EligibilityRefreshSeconds uint64 `json:"eligibility_refresh_seconds" yaml:"eligibility_refresh_seconds" toml:"eligibility_refresh_seconds"`
+	// Whether juiced reporting rewards are withdrawn automatically once the threshold is reached.
+	AutoWithdrawRewards bool `json:"auto_withdraw_rewards" yaml:"auto_withdraw_rewards" toml:"auto_withdraw_rewards"`
+	// Minimum available rewards, in wei, before an automatic withdrawal is sent.
+	RewardWithdrawThreshold string `json:"reward_withdraw_threshold" yaml:"reward_withdraw_threshold" toml:"reward_withdraw_threshold"`
+	// Address withdrawn rewards are forwarded to. Defaults to the reporter address.
+	RewardPayoutAddress string `json:"reward_payout_address" yaml:"reward_payout_address" toml:"reward_payout_address"`
+	// Address of the WSX token the rewards are paid in, required when forwarding rewards.
+	WSXAddress string `json:"wsx_address" yaml:"wsx_address" toml:"wsx_address"`
+	// Signer used for reporting transactions. The reporter key from the secrets manager is used if omitted.
+	Signer *YAMLSignerConfig `json:"signer" yaml:"signer" toml:"signer"`
+	// Named reporter keys operated by the node. The default reporter key and the signer above are used if omitted.
+	Keys []*YAMLReporterKeyConfig `json:"keys" yaml:"keys" toml:"keys"`
+	// Routing rule by function, either a key name, round-robin, or all for voteOutcome. The first key is used if omitted.
+	Routing map[string]string `json:"routing" yaml:"routing" toml:"routing"`
+	// Interval in seconds between scheduled balance checks of the reporter keys.
+	BalanceCheckSeconds uint64 `json:"balance_check_seconds" yaml:"balance_check_seconds" toml:"balance_check_seconds"`
+	// Balances, in wei, below which a warning is raised and below which low funds protection is activated.
+	BalanceWarningThreshold  string `json:"balance_warning_threshold" yaml:"balance_warning_threshold" toml:"balance_warning_threshold"`
+	BalanceCriticalThreshold string `json:"balance_critical_threshold" yaml:"balance_critical_threshold" toml:"balance_critical_threshold"`
+	// Functions paused by low funds protection. Defaults to proposeOutcome, withdrawJuicedRewards and transfer.
+	LowFundsPausedFunctions []string `json:"low_funds_paused_functions" yaml:"low_funds_paused_functions" toml:"low_funds_paused_functions"`
+	// JSON-RPC HTTP endpoint used for calls and txs.
+	RPCURL string `json:"rpc_url" yaml:"rpc_url" toml:"rpc_url"`
+	// JSON-RPC WebSocket endpoint used for event subscriptions.
+	WSRPCURL string `json:"ws_rpc_url" yaml:"ws_rpc_url" toml:"ws_rpc_url"`
+	// Timeout in seconds for JSON-RPC connections and nonce queries. Defaults to 30.
+	RPCTimeoutSeconds uint64 `json:"rpc_timeout_seconds" yaml:"rpc_timeout_seconds" toml:"rpc_timeout_seconds"`
+	// Timeout in seconds for verify outcome API requests. Defaults to 30.
+	VerifyOutcomeTimeoutSeconds uint64 `json:"verify_outcome_timeout_seconds" yaml:"verify_outcome_timeout_seconds" toml:"verify_outcome_timeout_seconds"`
+	// Time in seconds to wait for a sent tx to be mined. Defaults to 300.
+	TxConfirmationTimeoutSeconds uint64 `json:"tx_confirmation_timeout_seconds" yaml:"tx_confirmation_timeout_seconds" toml:"tx_confirmation_timeout_seconds"`
+	// Number of AMQP messages prefetched by the consumer. Defaults to 4.
+	AMQPPrefetchCount int `json:"amqp_prefetch_count" yaml:"amqp_prefetch_count" toml:"amqp_prefetch_count"`
+	// Delay in seconds before restarting a failed AMQP consumer. Defaults to 2.
+	AMQPReconnectDelaySeconds uint64 `json:"amqp_reconnect_delay_seconds" yaml:"amqp_reconnect_delay_seconds" toml:"amqp_reconnect_delay_seconds"`
+	// Gas policy for reporting transactions.
+	Gas *YAMLGasConfig `json:"gas" yaml:"gas" toml:"gas"`
+	// Alerting rules and notifiers. Alerting is disabled if omitted.
+	Alerting *YAMLAlertingConfig `json:"alerting" yaml:"alerting" toml:"alerting"`
+	// Policy for votes on markets the verify outcome API could not verify.
+	VotePolicy *YAMLVotePolicyConfig `json:"vote_policy" yaml:"vote_policy" toml:"vote_policy"`
+}
+
+// YAMLVotePolicyConfig represents the policy for votes on markets that could not be verified.
+type YAMLVotePolicyConfig struct {
+	// Policy applied when verification fails, delay to retry with backoff until the retry period ends
+	// then abstain, or abstain to abstain right away. Defaults to delay.
+	Policy string `json:"policy" yaml:"policy" toml:"policy"`
+	// Delay in seconds before the first verification retry, doubling up to the max backoff. Defaults to 10 and 300.
+	InitialBackoffSeconds uint64 `json:"initial_backoff_seconds" yaml:"initial_backoff_seconds" toml:"initial_backoff_seconds"`
+	MaxBackoffSeconds     uint64 `json:"max_backoff_seconds" yaml:"max_backoff_seconds" toml:"max_backoff_seconds"`
+	// Fraction of the voting period, from the report time, after which verification is no longer retried. Defaults to 0.5.
+	RetryPeriodFraction float64 `json:"retry_period_fraction" yaml:"retry_period_fraction" toml:"retry_period_fraction"`
+}
+
+// YAMLAlertingConfig represents the alerting rules and the notifiers alerts are sent to.
+type YAMLAlertingConfig struct {
+	// Rules raising alerts, any of tx_failed, balance_low, subscription_stalled, verify_errors
+	// and outcome_disagreement. All rules are enabled if omitted.
+	Rules []string `json:"rules" yaml:"rules" toml:"rules"`
+	// Window in seconds within which an alert is not sent again at the same severity. Defaults to 900.
+	DedupWindowSeconds uint64 `json:"dedup_window_seconds" yaml:"dedup_window_seconds" toml:"dedup_window_seconds"`
+	// Maximum number of alerts sent per minute across rules. Defaults to 20.
+	RateLimitPerMinute int `json:"rate_limit_per_minute" yaml:"rate_limit_per_minute" toml:"rate_limit_per_minute"`
+	// Number of verify outcome API errors within the window raising an alert. Defaults to 5 within 300 seconds.
+	VerifyErrorThreshold     int    `json:"verify_error_threshold" yaml:"verify_error_threshold" toml:"verify_error_threshold"`
+	VerifyErrorWindowSeconds uint64 `json:"verify_error_window_seconds" yaml:"verify_error_window_seconds" toml:"verify_error_window_seconds"`
+	// Time in seconds without new heads after which the event subscriptions are considered stalled. Defaults to 600.
+	SubscriptionStallSeconds uint64 `json:"subscription_stall_seconds" yaml:"subscription_stall_seconds" toml:"subscription_stall_seconds"`
+	// Backends the alerts are sent to.
+	Notifiers []*YAMLNotifierConfig `json:"notifiers" yaml:"notifiers" toml:"notifiers"`
+}
+
+// YAMLNotifierConfig represents a backend alerts are sent to.
+type YAMLNotifierConfig struct {
+	// Type of the notifier, one of webhook, slack or pagerduty.
+	Type string `json:"type" yaml:"type" toml:"type"`
+	// URL of the webhook, or of the PagerDuty Events API which defaults to the public endpoint.
+	URL string `json:"url" yaml:"url" toml:"url"`
+	// Integration routing key of the PagerDuty service.
+	RoutingKey string `json:"routing_key" yaml:"routing_key" toml:"routing_key"`
+	// Timeout in seconds for notifier requests. Defaults to 10.
+	TimeoutSeconds uint64 `json:"timeout_seconds" yaml:"timeout_seconds" toml:"timeout_seconds"`
+	// Rules whose alerts are sent to the notifier. Alerts of all enabled rules are sent if omitted.
+	Rules []string `json:"rules" yaml:"rules" toml:"rules"`
+}
+
+// YAMLGasConfig represents the gas policy for reporting transactions.
+type YAMLGasConfig struct {
+	// Attempts to send and mine a tx before giving up. Defaults to 4.
+	MaxTxTries int `json:"max_tx_tries" yaml:"max_tx_tries" toml:"max_tx_tries"`
+	// Fixed gas price in wei. The node's gas price is used if omitted.
+	GasPrice string `json:"gas_price" yaml:"gas_price" toml:"gas_price"`
+	// Upper bound of the gas price in wei. Unbounded if omitted.
+	MaxGasPrice string `json:"max_gas_price" yaml:"max_gas_price" toml:"max_gas_price"`
+	// Multiplier applied to the estimated gas limit, at least 1. Defaults to 1.
+	GasLimitMultiplier float64 `json:"gas_limit_multiplier" yaml:"gas_limit_multiplier" toml:"gas_limit_multiplier"`
+}
+
+// YAMLReporterKeyConfig represents the configuration of a named reporter key.
+type YAMLReporterKeyConfig struct {
+	// Name of the key, used in routing rules, metrics and logs. Local keys are read from the secret of the same name.
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// Signer used for the key's transactions. The reporter key of the same name from the secrets manager is used if omitted.
+	Signer *YAMLSignerConfig `json:"signer" yaml:"signer" toml:"signer"`
+}
+
+// YAMLSignerConfig represents the configuration of the reporting transaction signer.
+type YAMLSignerConfig struct {
+	// Type of the signer, one of local, web3signer, clef or secrets-manager.
+	Type string `json:"type" yaml:"type" toml:"type"`
+	// JSON-RPC URL of the remote signer.
+	URL string `json:"url" yaml:"url" toml:"url"`
+	// Address of the reporter account held by the remote signer.
+	Address string `json:"address" yaml:"address" toml:"address"`
+	// Timeout in seconds for remote signing requests.
+	TimeoutSeconds uint64 `json:"timeout_seconds" yaml:"timeout_seconds" toml:"timeout_seconds"`
 }
 
 // Represents the configuration of the server.
 type ServerConfig struct {
-	JSONLogFormat        bool                          // Indicates whether to use JSON log format
-	LogLevel             hclog.Level                   // Log level for the server logger
-	Logger               hclog.Logger                  // Logger instance for the server
-	ReporterConfig       *ReporterConfig               // Configuration for the reporter
-	ReporterService      *reporter.ReporterService     // Reporter service instance
-	SecretsManagerConfig *secrets.SecretsManagerConfig // Configuration for the secrets manager
-	SecretsManager       secrets.SecretsManager        // Secrets manager instance
-	DataDir              string                        // Directory for storing data
+	JSONLogFormat          bool                          // Indicates whether to use JSON log format
+	LogLevel               hclog.Level                   // Log level for the server logger
+	SubsystemLogLevels     map[string]hclog.Level        // Log level overrides by subsystem
+	LogFile                string                        // Path of the log file, standard output is used if empty
+	LogRotation            *LogRotationConfig            // Rotation policy of the log file
+	Tracing                *TracingConfig                // Export of traces, disabled if nil
+	Logger                 hclog.Logger                  // Logger instance for the server
+	ReporterConfig         *ReporterConfig               // Configuration for the reporter
+	ReporterService        *reporter.ReporterService     // Reporter service instance
+	SecretsManagerConfig   *secrets.SecretsManagerConfig // Configuration for the secrets manager
+	SecretsManager         secrets.SecretsManager        // Secrets manager instance
+	DataDir                string                        // Directory for storing data
+	KeystorePassphraseFile string                        // Path to the passphrase file of an encrypted local keystore
+	OperatorAddr           string                        // Listen address of the operator API
+	OperatorTokenFile      string                        // Path to the file holding the operator API token
+	PrometheusAddr         string                        // Listen address of the prometheus metrics endpoint
+	operatorServer         *http.Server                  // Operator API server instance
+	prometheusServer       *http.Server                  // Prometheus metrics server instance
+	yamlConfig             *YAMLServerConfig             // Raw config the server was generated from, compared on reload
+	logLevels              *logLevels                    // Levels of the logger and its subsystems, replaced on reload
+	logFile                *lumberjack.Logger            // Rotating log file writer, nil if logging to standard output
+	tracerProvider         *sdktrace.TracerProvider      // Provider exporting traces, nil if tracing is disabled
+}
+
+// Represents the export of traces to an OTLP collector.
+type TracingConfig struct {
+	OTLPEndpoint string  // Endpoint of the OTLP collector
+	Protocol     string  // Protocol of the OTLP collector
+	Insecure     bool    // Whether the collector is reached without TLS
+	SampleRatio  float64 // Ratio of the traces sampled
+	ServiceName  string  // Service name reported with the spans
+}
+
+// Represents the rotation policy of the log file.
+type LogRotationConfig struct {
+	MaxSizeMB  int           // Size in megabytes the log file reaches before it is rotated
+	Interval   time.Duration // Interval between time-based rotations, disabled if zero
+	MaxBackups int           // Number of rotated log files kept, all if zero
+	MaxAgeDays int           // Age in days after which rotated log files are deleted, never if zero
+	Compress   bool          // Whether rotated log files are compressed
 }
 
 // Represents the configuration for the reporter service.
 type ReporterConfig struct {
-	DataFeedAMQPURI          string // URI for the AMQP connection
-	DataFeedAMQPExchangeName string // Name of the AMQP exchange
-	DataFeedAMQPQueueName    string // Name of the AMQP queue
-	VerifyOutcomeURI         string // URI for verifying outcome
-	OutcomeReporterAddress   string // Address of the outcome reporter
-	SXNodeAddress            string // Address of the SX node
+	DataFeedAMQPURI          string                     // URI for the AMQP connection
+	DataFeedAMQPExchangeName string                     // Name of the AMQP exchange
+	DataFeedAMQPQueueName    string                     // Name of the AMQP queue
+	VerifyOutcomeURI         string                     // URI for verifying outcome
+	OutcomeReporterAddress   string                     // Address of the outcome reporter
+	SXNodeAddress            string                     // Address of the SX node
+	VotingPeriodRefresh      time.Duration              // Interval between refreshes of the on-chain voting period
+	ProposeRole              string                     // Role required for proposing outcomes
+	VoteRole                 string                     // Role required for voting on outcomes
+	ReportRole               string                     // Role required for reporting outcomes
+	StakingAddress           string                     // Address of the staking contract
+	StakingFunction          string                     // Signature of the staking eligibility function
+	EligibilityRefresh       time.Duration              // Interval between role and staking eligibility checks
+	AutoWithdrawRewards      bool                       // Whether rewards are withdrawn automatically
+	RewardWithdrawThreshold  string                     // Minimum available rewards in wei before withdrawing
+	RewardPayoutAddress      string                     // Address withdrawn rewards are forwarded to
+	WSXAddress               string                     // Address of the WSX reward token
+	SignerType               string                     // Type of the reporting tx signer
+	SignerURL                string                     // JSON-RPC URL of a remote signer
+	SignerAddress            string                     // Reporter address held by a remote signer
+	SignerTimeout            time.Duration              // Timeout for remote signing requests
+	Keys                     []*ReporterKeyConfig       // Named reporter keys
+	Routing                  map[string]string          // Routing rule by function type
+	BalanceCheck             time.Duration              // Interval between balance checks of the reporter keys
+	BalanceWarningThreshold  string                     // Balance in wei below which a warning is raised
+	BalanceCriticalThreshold string                     // Balance in wei below which low funds protection is activated
+	LowFundsPausedFunctions  []string                   // Functions paused by low funds protection
+	JSONRPCURL               string                     // JSON-RPC HTTP endpoint
+	JSONRPCWsURL             string                     // JSON-RPC WebSocket endpoint
+	RPCTimeout               time.Duration              // Timeout for JSON-RPC connections and nonce queries
+	VerifyOutcomeTimeout     time.Duration              // Timeout for verify outcome API requests
+	TxConfirmationTimeout    time.Duration              // Time to wait for a sent tx to be mined
+	AMQPPrefetchCount        int                        // Number of AMQP messages prefetched by the consumer
+	AMQPReconnectDelay       time.Duration              // Delay before restarting a failed AMQP consumer
+	GasMaxTxTries            int                        // Attempts to send and mine a tx
+	GasPrice                 string                     // Fixed gas price in wei
+	MaxGasPrice              string                     // Upper bound of the gas price in wei
+	GasLimitMultiplier       float64                    // Multiplier applied to the estimated gas limit
+	Alerting                 *reporter.AlertingConfig   // Alerting rules and notifiers
+	VotePolicy               *reporter.VotePolicyConfig // Policy for votes on unverified markets
 }
 
-// Initializes the server configuration from a file path specified in YAMLServerConfig.ConfigPath.
-// It reads and parses the config file into yamlServerConfig.
-// Returns an error if there's an issue reading or parsing the file.
-func (yamlServerConfig *YAMLServerConfig) InitServerConfigFromFile() error {
-	config, err := ReadConfigFile(yamlServerConfig.ConfigPath)
+// Represents the configuration of a named reporter key.
+type ReporterKeyConfig struct {
+	Name          string        // Name of the key
+	SignerType    string        // Type of the key's tx signer
+	SignerURL     string        // JSON-RPC URL of a remote signer
+	SignerAddress string        // Reporter address held by a remote signer
+	SignerTimeout time.Duration // Timeout for remote signing requests
+}
+
+// Reads the config file at the path, then applies the environment overrides read with lookup and fills in the defaults.
+// The resulting config is not validated, see Validate.
+func LoadConfig(path string, lookup func(string) (string, bool)) (*YAMLServerConfig, error) {
+	config, err := ReadConfigFile(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	*yamlServerConfig = *config
+	config.ConfigPath = path
 
-	return nil
+	if err := config.ApplyEnvOverrides(lookup); err != nil {
+		return nil, err
+	}
+
+	config.SetDefaults()
+
+	return config, nil
 }
 
 // Reads and parses a configuration file specified by 'path'.
-// It supports .yaml and .yml file formats.
+// It supports .yaml, .yml, .json and .toml file formats. Unknown keys are rejected.
 // Returns a YAMLServerConfig object parsed from the file.
 // Returns an error if there's an issue reading or parsing the file.
 func ReadConfigFile(path string) (*YAMLServerConfig, error) {
@@ -86,41 +365,276 @@ func ReadConfigFile(path string) (*YAMLServerConfig, error) {
 
 	var unmarshalFunc func([]byte, interface{}) error
 
-	switch {
-	case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
-		unmarshalFunc = yaml.Unmarshal
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		unmarshalFunc = unmarshalYAML
+	case ".json":
+		unmarshalFunc = unmarshalJSON
+	case ".toml":
+		unmarshalFunc = unmarshalTOML
 	default:
-		return nil, fmt.Errorf("suffix of %s is neither hcl, json, yaml nor yml", path)
+		return nil, fmt.Errorf("suffix of %s is neither yaml, yml, json nor toml", path)
 	}
 
 	yamlServerConfig := &YAMLServerConfig{}
 
-	if err := unmarshalFunc(data, &yamlServerConfig); err != nil {
-		return nil, err
+	if err := unmarshalFunc(data, yamlServerConfig); err != nil {
+		return nil, fmt.Errorf("unable to parse config file %s, %w", path, err)
 	}
 
 	return yamlServerConfig, nil
 }
 
+// Decodes YAML data, rejecting unknown keys.
+func unmarshalYAML(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// Decodes JSON data, rejecting unknown keys.
+func unmarshalJSON(data []byte, out interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(out)
+}
+
+// Decodes TOML data, rejecting unknown keys.
+func unmarshalTOML(data []byte, out interface{}) error {
+	metadata, err := toml.Decode(string(data), out)
+	if err != nil {
+		return err
+	}
+
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+
+		return fmt.Errorf("unknown keys %s", strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
+// Fills in the defaults of options left unset by the config file, environment and flags.
+// Options the reporter service defaults itself, such as intervals and timeouts, are left unset.
+func (yamlServerConfig *YAMLServerConfig) SetDefaults() {
+	if yamlServerConfig.Version == 0 {
+		yamlServerConfig.Version = ConfigVersion
+	}
+
+	if yamlServerConfig.LogLevel == "" {
+		yamlServerConfig.LogLevel = DefaultLogLevel
+	}
+
+	if yamlServerConfig.YAMLReporterConfig == nil {
+		yamlServerConfig.YAMLReporterConfig = &YAMLReporterConfig{}
+	}
+
+	if yamlServerConfig.YAMLReporterConfig.RPCURL == "" {
+		yamlServerConfig.YAMLReporterConfig.RPCURL = DefaultJSONRPCURL
+	}
+
+	if yamlServerConfig.YAMLReporterConfig.WSRPCURL == "" {
+		yamlServerConfig.YAMLReporterConfig.WSRPCURL = DefaultJSONRPCWsURL
+	}
+}
+
 // Generates a Config object from the yamlServerConfig's configuration data.
 // It populates the Config object with parsed values from the raw configuration.
 func (yamlServerConfig *YAMLServerConfig) GenerateConfig() *ServerConfig {
+	reporterConfig := yamlServerConfig.YAMLReporterConfig
+	if reporterConfig == nil {
+		reporterConfig = &YAMLReporterConfig{}
+	}
+
+	gasConfig := reporterConfig.Gas
+	if gasConfig == nil {
+		gasConfig = &YAMLGasConfig{}
+	}
+
+	signerConfig := reporterConfig.Signer
+	if signerConfig == nil {
+		signerConfig = &YAMLSignerConfig{}
+	}
+
+	keys := make([]*ReporterKeyConfig, 0, len(reporterConfig.Keys))
+
+	for _, keyConfig := range reporterConfig.Keys {
+		keySignerConfig := keyConfig.Signer
+		if keySignerConfig == nil {
+			keySignerConfig = &YAMLSignerConfig{}
+		}
+
+		keys = append(keys, &ReporterKeyConfig{
+			Name:          keyConfig.Name,
+			SignerType:    keySignerConfig.Type,
+			SignerURL:     keySignerConfig.URL,
+			SignerAddress: keySignerConfig.Address,
+			SignerTimeout: time.Duration(keySignerConfig.TimeoutSeconds) * time.Second,
+		})
+	}
+
 	return &ServerConfig{
-		LogLevel:             hclog.LevelFromString("DEBUG"),
-		JSONLogFormat:        yamlServerConfig.JSONLogFormat,
-		DataDir:              yamlServerConfig.DataDir,
-		SecretsManagerConfig: yamlServerConfig.SecretsConfig,
+		yamlConfig:             yamlServerConfig,
+		LogLevel:               hclog.LevelFromString(yamlServerConfig.LogLevel),
+		SubsystemLogLevels:     subsystemLogLevels(yamlServerConfig.LogLevels),
+		LogFile:                yamlServerConfig.LogFile,
+		LogRotation:            yamlServerConfig.LogRotation.generateConfig(),
+		Tracing:                yamlServerConfig.Tracing.generateConfig(),
+		JSONLogFormat:          yamlServerConfig.JSONLogFormat,
+		DataDir:                yamlServerConfig.DataDir,
+		KeystorePassphraseFile: yamlServerConfig.KeystorePassphraseFile,
+		OperatorAddr:           yamlServerConfig.OperatorAddr,
+		OperatorTokenFile:      yamlServerConfig.OperatorTokenFile,
+		PrometheusAddr:         yamlServerConfig.PrometheusAddr,
+		SecretsManagerConfig:   yamlServerConfig.SecretsConfig,
 		ReporterConfig: &ReporterConfig{
-			DataFeedAMQPURI:          yamlServerConfig.YAMLReporterConfig.AMQPURI,
-			DataFeedAMQPExchangeName: yamlServerConfig.YAMLReporterConfig.AMQPExchangeName,
-			DataFeedAMQPQueueName:    yamlServerConfig.YAMLReporterConfig.AMQPQueueName,
-			VerifyOutcomeURI:         yamlServerConfig.YAMLReporterConfig.VerifyOutcomeAPIURL,
-			OutcomeReporterAddress:   yamlServerConfig.YAMLReporterConfig.OutcomeReporterAddress,
-			SXNodeAddress:            yamlServerConfig.YAMLReporterConfig.SXNodeAddress,
+			DataFeedAMQPURI:          reporterConfig.AMQPURI,
+			DataFeedAMQPExchangeName: reporterConfig.AMQPExchangeName,
+			DataFeedAMQPQueueName:    reporterConfig.AMQPQueueName,
+			VerifyOutcomeURI:         reporterConfig.VerifyOutcomeAPIURL,
+			OutcomeReporterAddress:   reporterConfig.OutcomeReporterAddress,
+			SXNodeAddress:            reporterConfig.SXNodeAddress,
+			VotingPeriodRefresh: time.Duration(
+				reporterConfig.VotingPeriodRefreshSeconds,
+			) * time.Second,
+			ProposeRole:     reporterConfig.ProposeRole,
+			VoteRole:        reporterConfig.VoteRole,
+			ReportRole:      reporterConfig.ReportRole,
+			StakingAddress:  reporterConfig.StakingAddress,
+			StakingFunction: reporterConfig.StakingEligibilityFunction,
+			EligibilityRefresh: time.Duration(
+				reporterConfig.EligibilityRefreshSeconds,
+			) * time.Second,
+			AutoWithdrawRewards:     reporterConfig.AutoWithdrawRewards,
+			RewardWithdrawThreshold: reporterConfig.RewardWithdrawThreshold,
+			RewardPayoutAddress:     reporterConfig.RewardPayoutAddress,
+			WSXAddress:              reporterConfig.WSXAddress,
+			SignerType:              signerConfig.Type,
+			SignerURL:               signerConfig.URL,
+			SignerAddress:           signerConfig.Address,
+			SignerTimeout:           time.Duration(signerConfig.TimeoutSeconds) * time.Second,
+			Keys:                    keys,
+			Routing:                 reporterConfig.Routing,
+			BalanceCheck: time.Duration(
+				reporterConfig.BalanceCheckSeconds,
+			) * time.Second,
+			BalanceWarningThreshold:  reporterConfig.BalanceWarningThreshold,
+			BalanceCriticalThreshold: reporterConfig.BalanceCriticalThreshold,
+			LowFundsPausedFunctions:  reporterConfig.LowFundsPausedFunctions,
+			JSONRPCURL:               reporterConfig.RPCURL,
+			JSONRPCWsURL:             reporterConfig.WSRPCURL,
+			RPCTimeout:               time.Duration(reporterConfig.RPCTimeoutSeconds) * time.Second,
+			VerifyOutcomeTimeout:     time.Duration(reporterConfig.VerifyOutcomeTimeoutSeconds) * time.Second,
+			TxConfirmationTimeout:    time.Duration(reporterConfig.TxConfirmationTimeoutSeconds) * time.Second,
+			AMQPPrefetchCount:        reporterConfig.AMQPPrefetchCount,
+			AMQPReconnectDelay:       time.Duration(reporterConfig.AMQPReconnectDelaySeconds) * time.Second,
+			GasMaxTxTries:            gasConfig.MaxTxTries,
+			GasPrice:                 gasConfig.GasPrice,
+			MaxGasPrice:              gasConfig.MaxGasPrice,
+			GasLimitMultiplier:       gasConfig.GasLimitMultiplier,
+			Alerting:                 reporterConfig.Alerting.generateConfig(),
+			VotePolicy:               reporterConfig.VotePolicy.generateConfig(),
 		},
 	}
 }
 
+// Parses the log level overrides by subsystem.
+func subsystemLogLevels(levels map[string]string) map[string]hclog.Level {
+	subsystemLevels := make(map[string]hclog.Level, len(levels))
+	for subsystem, level := range levels {
+		subsystemLevels[subsystem] = hclog.LevelFromString(level)
+	}
+
+	return subsystemLevels
+}
+
+// Generates the log rotation policy, nil if not configured.
+func (c *YAMLLogRotationConfig) generateConfig() *LogRotationConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &LogRotationConfig{
+		MaxSizeMB:  c.MaxSizeMB,
+		Interval:   time.Duration(c.IntervalHours) * time.Hour,
+		MaxBackups: c.MaxBackups,
+		MaxAgeDays: c.MaxAgeDays,
+		Compress:   c.Compress,
+	}
+}
+
+// Generates the trace export config, nil if not configured.
+func (c *YAMLTracingConfig) generateConfig() *TracingConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &TracingConfig{
+		OTLPEndpoint: c.OTLPEndpoint,
+		Protocol:     c.Protocol,
+		Insecure:     c.Insecure,
+		SampleRatio:  c.SampleRatio,
+		ServiceName:  c.ServiceName,
+	}
+}
+
+// Generates the alerting config, nil if not configured.
+func (c *YAMLAlertingConfig) generateConfig() *reporter.AlertingConfig {
+	if c == nil {
+		return nil
+	}
+
+	notifiers := make([]*reporter.NotifierConfig, 0, len(c.Notifiers))
+
+	for _, notifier := range c.Notifiers {
+		if notifier == nil {
+			continue
+		}
+
+		notifiers = append(notifiers, &reporter.NotifierConfig{
+			Type:       reporter.NotifierType(notifier.Type),
+			URL:        notifier.URL,
+			RoutingKey: notifier.RoutingKey,
+			Timeout:    time.Duration(notifier.TimeoutSeconds) * time.Second,
+			Rules:      notifier.Rules,
+		})
+	}
+
+	return &reporter.AlertingConfig{
+		Notifiers:                notifiers,
+		Rules:                    c.Rules,
+		DedupWindow:              time.Duration(c.DedupWindowSeconds) * time.Second,
+		RateLimit:                c.RateLimitPerMinute,
+		VerifyErrorThreshold:     c.VerifyErrorThreshold,
+		VerifyErrorWindow:        time.Duration(c.VerifyErrorWindowSeconds) * time.Second,
+		SubscriptionStallTimeout: time.Duration(c.SubscriptionStallSeconds) * time.Second,
+	}
+}
+
+// Generates the vote policy config, nil if not configured.
+func (c *YAMLVotePolicyConfig) generateConfig() *reporter.VotePolicyConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &reporter.VotePolicyConfig{
+		Policy:              reporter.VotePolicy(c.Policy),
+		InitialBackoff:      time.Duration(c.InitialBackoffSeconds) * time.Second,
+		MaxBackoff:          time.Duration(c.MaxBackoffSeconds) * time.Second,
+		RetryPeriodFraction: c.RetryPeriodFraction,
+	}
+}
+
 // Sets the JSON log format in the server's configuration.
 // It updates the JSONLogFormat field in yamlServerConfig based on the provided 'jsonLogFormat' boolean.
 func (yamlServerConfig *YAMLServerConfig) SetJSONLogFormat(jsonLogFormat bool) {
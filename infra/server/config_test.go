@@ -0,0 +1,98 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `log_level: DEBUG
+reporter:
+  rpc_url: http://localhost:8545
+  gas:
+    max_gas_price: "100"
+`,
+		},
+		{
+			name:    "json",
+			file:    "config.json",
+			content: `{"log_level": "DEBUG", "reporter": {"rpc_url": "http://localhost:8545", "gas": {"max_gas_price": "100"}}}`,
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: `log_level = "DEBUG"
+
+[reporter]
+rpc_url = "http://localhost:8545"
+
+[reporter.gas]
+max_gas_price = "100"
+`,
+		},
+		{
+			name:    "unknown yaml key",
+			file:    "config.yml",
+			content: "log_level: DEBUG\nreporter:\n  rpc_uri: http://localhost:8545\n",
+			wantErr: "field rpc_uri not found",
+		},
+		{
+			name:    "unknown json key",
+			file:    "config.json",
+			content: `{"log_level": "DEBUG", "reporter": {"rpc_uri": "http://localhost:8545"}}`,
+			wantErr: `json: unknown field "rpc_uri"`,
+		},
+		{
+			name:    "unknown toml keys",
+			file:    "config.toml",
+			content: "log_lvl = \"DEBUG\"\n\n[reporter]\nrpc_uri = \"http://localhost:8545\"\n",
+			wantErr: "unknown keys log_lvl, reporter.rpc_uri",
+		},
+		{
+			name:    "unsupported format",
+			file:    "config.ini",
+			content: "log_level = DEBUG",
+			wantErr: "is neither yaml, yml, json nor toml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := ReadConfigFile(path)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ReadConfigFile() error = %v, want %s", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ReadConfigFile() error = %v", err)
+			}
+
+			reporter := config.YAMLReporterConfig
+			if config.LogLevel != "DEBUG" || reporter == nil || reporter.RPCURL != "http://localhost:8545" ||
+				reporter.Gas == nil || reporter.Gas.MaxGasPrice != "100" {
+				t.Errorf("ReadConfigFile() = %+v, want the log level, rpc url and max gas price set", config)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Prefix of the environment variables overriding config options.
+// Variable names join the upper-cased keys of the option's path with underscores,
+// e.g. SXR_LOG_LEVEL or SXR_REPORTER_GAS_MAX_GAS_PRICE.
+const EnvPrefix = "SXR_"
+
+// Overrides the config options set by environment variables, using lookup to read them.
+// String, boolean and numeric options are supported, as are lists of strings separated by commas.
+// Lists of keys and alert notifiers, routing and log level maps can only be set in the config file.
+func (yamlServerConfig *YAMLServerConfig) ApplyEnvOverrides(lookup func(string) (string, bool)) error {
+	_, err := applyEnvOverrides(reflect.ValueOf(yamlServerConfig).Elem(), strings.TrimSuffix(EnvPrefix, "_"), lookup)
+
+	return err
+}
+
+// Sets the fields of the struct value from the environment variables named after their yaml keys.
+// Nil struct pointers are only allocated if an option within them is set. Returns whether any option was set.
+func applyEnvOverrides(value reflect.Value, prefix string, lookup func(string) (string, bool)) (bool, error) {
+	applied := false
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(key)
+		fieldValue := value.Field(i)
+
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.Struct {
+			target := fieldValue
+			if fieldValue.IsNil() {
+				target = reflect.New(fieldValue.Type().Elem())
+			}
+
+			ok, err := applyEnvOverrides(target.Elem(), name, lookup)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				fieldValue.Set(target)
+
+				applied = true
+			}
+
+			continue
+		}
+
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+
+		set, err := setEnvValue(fieldValue, raw)
+		if err != nil {
+			return false, fmt.Errorf("invalid value %q for environment variable %s, %w", raw, name, err)
+		}
+
+		applied = applied || set
+	}
+
+	return applied, nil
+}
+
+// Parses the raw environment variable value into the field. Returns false for unsupported field kinds.
+func setEnvValue(field reflect.Value, raw string) (bool, error) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return false, err
+		}
+
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+
+		field.SetFloat(value)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return false, nil
+		}
+
+		var values []string
+
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		field.Set(reflect.ValueOf(values))
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *YAMLServerConfig
+		env     map[string]string
+		check   func(t *testing.T, c *YAMLServerConfig)
+		wantErr string
+	}{
+		{
+			name: "no overrides leaves sub-structs unallocated",
+			env:  map[string]string{},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				if c.YAMLReporterConfig != nil || c.Tracing != nil || c.LogRotation != nil {
+					t.Errorf("allocated sub-structs without overrides: %+v", c)
+				}
+			},
+		},
+		{
+			name: "top-level options",
+			env: map[string]string{
+				"SXR_LOG_LEVEL":       "DEBUG",
+				"SXR_JSON_LOG_FORMAT": "true",
+				"SXR_VERSION":         "1",
+			},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				if c.LogLevel != "DEBUG" || !c.JSONLogFormat || c.Version != 1 {
+					t.Errorf("log_level, json_log_format, version = %s, %v, %d, want DEBUG, true, 1", c.LogLevel, c.JSONLogFormat, c.Version)
+				}
+			},
+		},
+		{
+			name: "nested option allocates only its sub-structs",
+			env:  map[string]string{"SXR_REPORTER_GAS_MAX_GAS_PRICE": "100000000000"},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				if c.YAMLReporterConfig == nil || c.YAMLReporterConfig.Gas == nil {
+					t.Fatal("reporter gas config not allocated")
+				}
+
+				if c.YAMLReporterConfig.Gas.MaxGasPrice != "100000000000" {
+					t.Errorf("max_gas_price = %s, want 100000000000", c.YAMLReporterConfig.Gas.MaxGasPrice)
+				}
+
+				if reporter := c.YAMLReporterConfig; reporter.Alerting != nil || reporter.VotePolicy != nil || reporter.Signer != nil {
+					t.Errorf("allocated unset reporter sub-structs: %+v", reporter)
+				}
+
+				if c.Tracing != nil || c.LogRotation != nil {
+					t.Error("allocated unset top-level sub-structs")
+				}
+			},
+		},
+		{
+			name: "overrides keep the options set by the config file",
+			config: &YAMLServerConfig{
+				YAMLReporterConfig: &YAMLReporterConfig{RPCURL: "http://file", AMQPQueueName: "queue"},
+			},
+			env: map[string]string{"SXR_REPORTER_RPC_URL": "http://env"},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				if c.YAMLReporterConfig.RPCURL != "http://env" || c.YAMLReporterConfig.AMQPQueueName != "queue" {
+					t.Errorf("rpc_url, amqp_queue_name = %s, %s, want http://env, queue", c.YAMLReporterConfig.RPCURL, c.YAMLReporterConfig.AMQPQueueName)
+				}
+			},
+		},
+		{
+			name: "numeric options",
+			env: map[string]string{
+				"SXR_REPORTER_AMQP_PREFETCH_COUNT":      "5",
+				"SXR_REPORTER_RPC_TIMEOUT_SECONDS":      "30",
+				"SXR_REPORTER_GAS_GAS_LIMIT_MULTIPLIER": "1.5",
+			},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				reporter := c.YAMLReporterConfig
+				if reporter.AMQPPrefetchCount != 5 || reporter.RPCTimeoutSeconds != 30 || reporter.Gas.GasLimitMultiplier != 1.5 {
+					t.Errorf(
+						"amqp_prefetch_count, rpc_timeout_seconds, gas_limit_multiplier = %d, %d, %v, want 5, 30, 1.5",
+						reporter.AMQPPrefetchCount,
+						reporter.RPCTimeoutSeconds,
+						reporter.Gas.GasLimitMultiplier,
+					)
+				}
+			},
+		},
+		{
+			name: "comma separated list",
+			env:  map[string]string{"SXR_REPORTER_LOW_FUNDS_PAUSED_FUNCTIONS": " proposeOutcome, ,voteOutcome,"},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				want := []string{"proposeOutcome", "voteOutcome"}
+				if got := c.YAMLReporterConfig.LowFundsPausedFunctions; !reflect.DeepEqual(got, want) {
+					t.Errorf("low_funds_paused_functions = %q, want %q", got, want)
+				}
+			},
+		},
+		{
+			name: "maps and lists of structs are not overridden",
+			env: map[string]string{
+				"SXR_LOG_LEVELS":       "reporter=DEBUG",
+				"SXR_REPORTER_ROUTING": "a=b",
+				"SXR_REPORTER_KEYS":    "hot",
+			},
+			check: func(t *testing.T, c *YAMLServerConfig) {
+				if c.LogLevels != nil || c.YAMLReporterConfig != nil {
+					t.Errorf("overrode unsupported options: %+v", c)
+				}
+			},
+		},
+		{
+			name:    "invalid bool",
+			env:     map[string]string{"SXR_JSON_LOG_FORMAT": "maybe"},
+			wantErr: `invalid value "maybe" for environment variable SXR_JSON_LOG_FORMAT, strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+		{
+			name:    "invalid int",
+			env:     map[string]string{"SXR_REPORTER_AMQP_PREFETCH_COUNT": "ten"},
+			wantErr: `invalid value "ten" for environment variable SXR_REPORTER_AMQP_PREFETCH_COUNT, strconv.ParseInt: parsing "ten": invalid syntax`,
+		},
+		{
+			name:    "negative unsigned int",
+			env:     map[string]string{"SXR_REPORTER_GAS_MAX_TX_TRIES": "3", "SXR_REPORTER_RPC_TIMEOUT_SECONDS": "-1"},
+			wantErr: `invalid value "-1" for environment variable SXR_REPORTER_RPC_TIMEOUT_SECONDS, strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config == nil {
+				config = &YAMLServerConfig{}
+			}
+
+			err := config.ApplyEnvOverrides(func(name string) (string, bool) {
+				value, ok := tt.env[name]
+
+				return value, ok
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ApplyEnvOverrides() error = %v, want %s", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ApplyEnvOverrides() error = %v", err)
+			}
+
+			tt.check(t, config)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	// Name of the server's root logger, subsystems are named relative to it.
+	rootLoggerName = "sx-reporter-node"
+	// Default size in megabytes a log file reaches before it is rotated.
+	defaultLogMaxSizeMB = 100
+)
+
+// Holds the log levels of the server logger and its subsystems.
+// Levels are read on every log call, so they can be changed at runtime.
+type logLevels struct {
+	level     hclog.Level            // Level of subsystems without an override.
+	overrides map[string]hclog.Level // Level by lower-cased subsystem name, e.g. reporter.tx.
+	sync.RWMutex
+}
+
+// Replaces the default level and the subsystem overrides.
+func (l *logLevels) set(level hclog.Level, overrides map[string]hclog.Level) {
+	normalized := make(map[string]hclog.Level, len(overrides))
+	for subsystem, subsystemLevel := range overrides {
+		normalized[strings.ToLower(subsystem)] = subsystemLevel
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.level = level
+	l.overrides = normalized
+}
+
+// Sets the level of a single subsystem, or the default level for the root logger.
+func (l *logLevels) setLevel(name string, level hclog.Level) {
+	subsystem := subsystemName(name)
+
+	l.Lock()
+	defer l.Unlock()
+
+	if subsystem == "" {
+		l.level = level
+
+		return
+	}
+
+	l.overrides[subsystem] = level
+}
+
+// Returns the level of the named logger, that of its closest subsystem with an override or else the default level.
+// Overriding reporter applies to reporter.tx too, unless reporter.tx has its own override.
+func (l *logLevels) levelOf(name string) hclog.Level {
+	subsystem := subsystemName(name)
+
+	l.RLock()
+	defer l.RUnlock()
+
+	for subsystem != "" {
+		if level, ok := l.overrides[subsystem]; ok {
+			return level
+		}
+
+		i := strings.LastIndex(subsystem, ".")
+		if i < 0 {
+			break
+		}
+
+		subsystem = subsystem[:i]
+	}
+
+	return l.level
+}
+
+// Returns the lower-cased subsystem name of the named logger, empty for the root logger.
+func subsystemName(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(name, rootLoggerName), "."))
+}
+
+// Logger dropping the lines below the level of its subsystem.
+// Sub-loggers are wrapped as well through the sub-logger hook of the root logger.
+type leveledLogger struct {
+	hclog.Logger
+	levels *logLevels
+}
+
+func (l *leveledLogger) enabled(level hclog.Level) bool {
+	return level >= l.levels.levelOf(l.Name())
+}
+
+func (l *leveledLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	if l.enabled(level) {
+		l.Logger.Log(level, msg, args...)
+	}
+}
+
+func (l *leveledLogger) Trace(msg string, args ...interface{}) { l.Log(hclog.Trace, msg, args...) }
+func (l *leveledLogger) Debug(msg string, args ...interface{}) { l.Log(hclog.Debug, msg, args...) }
+func (l *leveledLogger) Info(msg string, args ...interface{})  { l.Log(hclog.Info, msg, args...) }
+func (l *leveledLogger) Warn(msg string, args ...interface{})  { l.Log(hclog.Warn, msg, args...) }
+func (l *leveledLogger) Error(msg string, args ...interface{}) { l.Log(hclog.Error, msg, args...) }
+
+func (l *leveledLogger) IsTrace() bool { return l.enabled(hclog.Trace) }
+func (l *leveledLogger) IsDebug() bool { return l.enabled(hclog.Debug) }
+func (l *leveledLogger) IsInfo() bool  { return l.enabled(hclog.Info) }
+func (l *leveledLogger) IsWarn() bool  { return l.enabled(hclog.Warn) }
+func (l *leveledLogger) IsError() bool { return l.enabled(hclog.Error) }
+
+func (l *leveledLogger) GetLevel() hclog.Level { return l.levels.levelOf(l.Name()) }
+
+// SetLevel sets the level of the logger's subsystem, or the default level if called on the root logger.
+func (l *leveledLogger) SetLevel(level hclog.Level) { l.levels.setLevel(l.Name(), level) }
+
+// Creates the server logger with the configured level and subsystem level overrides.
+// It logs to the configured log file, rotating it by size and optionally by time, or else to standard output.
+// If the log file can't be created the server command will error out.
+func newLogger(serverConfig *ServerConfig) (hclog.Logger, error) {
+	levels := &logLevels{}
+	levels.set(serverConfig.LogLevel, serverConfig.SubsystemLogLevels)
+
+	var output io.Writer = os.Stdout
+
+	if serverConfig.LogFile != "" {
+		logFile, err := newLogFile(serverConfig.LogFile, serverConfig.LogRotation)
+		if err != nil {
+			return nil, err
+		}
+
+		serverConfig.logFile = logFile
+		output = logFile
+	}
+
+	serverConfig.logLevels = levels
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:       rootLoggerName,
+		Level:      hclog.Trace, // lines are filtered by the subsystem levels
+		Output:     output,
+		JSONFormat: serverConfig.JSONLogFormat,
+		SubloggerHook: func(sub hclog.Logger) hclog.Logger {
+			return &leveledLogger{Logger: sub, levels: levels}
+		},
+	})
+
+	return &leveledLogger{Logger: logger, levels: levels}, nil
+}
+
+// Opens the log file for appending, creating it and its directory if needed, and starts its time-based rotation.
+func newLogFile(path string, rotation *LogRotationConfig) (*lumberjack.Logger, error) {
+	if rotation == nil {
+		rotation = &LogRotationConfig{}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("could not create log file directory, %w", err)
+	}
+
+	// the rotating writer opens the file lazily, check it can be written to upfront
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("could not open log file, %w", err)
+	}
+
+	file.Close()
+
+	maxSize := rotation.MaxSizeMB
+	if maxSize <= 0 {
+		maxSize = defaultLogMaxSizeMB
+	}
+
+	logFile := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSize,
+		MaxBackups: rotation.MaxBackups,
+		MaxAge:     rotation.MaxAgeDays,
+		Compress:   rotation.Compress,
+		LocalTime:  true,
+	}
+
+	if rotation.Interval > 0 {
+		go rotateLogFile(logFile, rotation.Interval)
+	}
+
+	return logFile, nil
+}
+
+// Rotates the log file at every interval, in addition to the size-based rotation.
+func rotateLogFile(logFile *lumberjack.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := logFile.Rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to rotate log file %s: %v\n", logFile.Filename, err)
+		}
+	}
+}
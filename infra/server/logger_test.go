@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestLogLevelsLevelOf(t *testing.T) {
+	levels := &logLevels{}
+	levels.set(hclog.Info, map[string]hclog.Level{
+		"Reporter":    hclog.Warn,
+		"reporter.tx": hclog.Debug,
+	})
+
+	tests := []struct {
+		name  string
+		level hclog.Level
+	}{
+		{rootLoggerName, hclog.Info},
+		{rootLoggerName + ".server", hclog.Info},
+		{rootLoggerName + ".reporter", hclog.Warn},
+		{rootLoggerName + ".reporter.mq", hclog.Warn},
+		{rootLoggerName + ".reporter.tx", hclog.Debug},
+		{rootLoggerName + ".reporter.tx.gas", hclog.Debug},
+		{rootLoggerName + ".Reporter.TX", hclog.Debug},
+		{rootLoggerName + ".reporterx", hclog.Info},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if level := levels.levelOf(tt.name); level != tt.level {
+				t.Errorf("levelOf(%s) = %s, want %s", tt.name, level, tt.level)
+			}
+		})
+	}
+}
+
+func TestSubsystemLogLevels(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "logs", "reporter.log")
+
+	serverConfig := &ServerConfig{
+		LogLevel:           hclog.Info,
+		SubsystemLogLevels: map[string]hclog.Level{"reporter.tx": hclog.Debug, "mq": hclog.Error},
+		LogFile:            logPath,
+	}
+
+	logger, err := newLogger(serverConfig)
+	if err != nil {
+		t.Fatalf("newLogger() error = %v", err)
+	}
+
+	defer serverConfig.logFile.Close()
+
+	reporterLogger := logger.Named("reporter")
+	txLogger := reporterLogger.Named("tx")
+	mqLogger := logger.Named("mq")
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	reporterLogger.Debug("reporter debug")
+	txLogger.Debug("tx debug")
+	mqLogger.Warn("mq warn")
+	mqLogger.Error("mq error")
+
+	// levels changed at runtime apply to the existing loggers
+	reporterLogger.SetLevel(hclog.Debug)
+	reporterLogger.Debug("reporter debug after set")
+	serverConfig.logLevels.set(hclog.Info, nil)
+	txLogger.Debug("tx debug after reload")
+
+	if txLogger.IsDebug() || !txLogger.IsInfo() {
+		t.Errorf("tx logger level = %s, want %s", txLogger.GetLevel(), hclog.Info)
+	}
+
+	logs, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	for _, line := range []string{"root info", "tx debug", "mq error", "reporter debug after set"} {
+		if !bytes.Contains(logs, []byte(line)) {
+			t.Errorf("log line %q missing from:\n%s", line, logs)
+		}
+	}
+
+	for _, line := range []string{"root debug", "reporter debug\n", "mq warn", "tx debug after reload"} {
+		if bytes.Contains(logs, []byte(line)) {
+			t.Errorf("log line %q not filtered out of:\n%s", line, logs)
+		}
+	}
+}
+
+func TestLogFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "reporter.log")
+
+	logFile, err := newLogFile(logPath, &LogRotationConfig{MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("newLogFile() error = %v", err)
+	}
+
+	defer logFile.Close()
+
+	line := []byte(strings.Repeat("x", 1023) + "\n")
+
+	// rotated once the file exceeds its max size
+	for i := 0; i < 1024+1; i++ {
+		if _, err := logFile.Write(line); err != nil {
+			t.Fatalf("failed to write log line: %v", err)
+		}
+	}
+
+	if backups := logBackups(t, dir); len(backups) != 1 {
+		t.Fatalf("rotated log files = %v, want 1", backups)
+	}
+
+	if info, err := os.Stat(logPath); err != nil || info.Size() != int64(len(line)) {
+		t.Fatalf("log file after rotation = %v, %v, want %d bytes", info, err, len(line))
+	}
+
+	if err := logFile.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	// rotated files above max backups are removed in the background
+	for i := 0; i < 100 && len(logBackups(t, dir)) != 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if backups := logBackups(t, dir); len(backups) != 1 {
+		t.Errorf("rotated log files = %v, want 1", backups)
+	}
+}
+
+func TestNewLogFileUnwritable(t *testing.T) {
+	dir := t.TempDir()
+
+	// the log file path is an existing directory
+	if _, err := newLogFile(dir, nil); err == nil || !strings.Contains(err.Error(), "could not open log file") {
+		t.Errorf("newLogFile() error = %v, want could not open log file", err)
+	}
+}
+
+// Returns the rotated log files in the directory.
+func logBackups(t *testing.T, dir string) []string {
+	t.Helper()
+
+	backups, err := filepath.Glob(filepath.Join(dir, "reporter-*.log"))
+	if err != nil {
+		t.Fatalf("failed to list rotated log files: %v", err)
+	}
+
+	return backups
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Reads the operator API token from the given file, ignoring surrounding whitespace.
+func readOperatorToken(tokenFile string) (string, error) {
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the operator API token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("operator API token file %s is empty", tokenFile)
+	}
+
+	return token, nil
+}
+
+// Wraps the operator API handler so that only requests carrying the token as a bearer token are served.
+func requireOperatorToken(token string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"missing or invalid operator API token"}`))
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Reports whether the listen address only accepts connections from the local host.
+// An address without a host listens on every interface and is not a loopback address.
+func isLoopbackAddr(listenAddr string) bool {
+	host, _, err := net.SplitHostPort(listenAddr)
+	if err != nil || host == "" {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
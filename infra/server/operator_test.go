@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireOperatorToken(t *testing.T) {
+	handler := requireOperatorToken("secret", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"valid token", "Bearer secret", http.StatusOK},
+		{"missing token", "", http.StatusUnauthorized},
+		{"invalid token", "Bearer other", http.StatusUnauthorized},
+		{"not a bearer token", "secret", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/v1/health", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:9632", true},
+		{"localhost:9632", true},
+		{"[::1]:9632", true},
+		{":9632", false},
+		{"0.0.0.0:9632", false},
+		{"10.0.0.1:9632", false},
+		{"reporter.internal:9632", false},
+	}
+
+	for _, tt := range tests {
+		if got := isLoopbackAddr(tt.addr); got != tt.want {
+			t.Errorf("isLoopbackAddr(%q) = %t, want %t", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Placeholder replacing secret values in displayed configs.
+const redactedValue = "xxxxx"
+
+// Returns a copy of the config safe to display, with passwords and query parameters of URLs redacted.
+func (yamlServerConfig *YAMLServerConfig) Redacted() *YAMLServerConfig {
+	redacted := *yamlServerConfig
+	redacted.SecretsConfig = nil
+
+	if yamlServerConfig.YAMLReporterConfig == nil {
+		return &redacted
+	}
+
+	reporterConfig := *yamlServerConfig.YAMLReporterConfig
+	reporterConfig.AMQPURI = redactURL(reporterConfig.AMQPURI)
+	reporterConfig.VerifyOutcomeAPIURL = redactURL(reporterConfig.VerifyOutcomeAPIURL)
+	reporterConfig.RPCURL = redactEndpointURL(reporterConfig.RPCURL)
+	reporterConfig.WSRPCURL = redactEndpointURL(reporterConfig.WSRPCURL)
+	reporterConfig.Signer = reporterConfig.Signer.redacted()
+	reporterConfig.Alerting = reporterConfig.Alerting.redacted()
+
+	if reporterConfig.Keys != nil {
+		reporterConfig.Keys = make([]*YAMLReporterKeyConfig, len(yamlServerConfig.YAMLReporterConfig.Keys))
+
+		for i, key := range yamlServerConfig.YAMLReporterConfig.Keys {
+			if key == nil {
+				continue
+			}
+
+			reporterConfig.Keys[i] = &YAMLReporterKeyConfig{
+				Name:   key.Name,
+				Signer: key.Signer.redacted(),
+			}
+		}
+	}
+
+	redacted.YAMLReporterConfig = &reporterConfig
+
+	return &redacted
+}
+
+// Returns a copy of the signer config with its URL redacted.
+func (c *YAMLSignerConfig) redacted() *YAMLSignerConfig {
+	if c == nil {
+		return nil
+	}
+
+	redacted := *c
+	redacted.URL = redactURL(c.URL)
+
+	return &redacted
+}
+
+// Returns a copy of the alerting config with its notifier URLs and routing keys redacted.
+func (c *YAMLAlertingConfig) redacted() *YAMLAlertingConfig {
+	if c == nil {
+		return nil
+	}
+
+	redacted := *c
+
+	if c.Notifiers != nil {
+		redacted.Notifiers = make([]*YAMLNotifierConfig, len(c.Notifiers))
+
+		for i, notifier := range c.Notifiers {
+			if notifier == nil {
+				continue
+			}
+
+			redactedNotifier := *notifier
+			redactedNotifier.URL = redactWebhookURL(notifier.URL)
+
+			if notifier.RoutingKey != "" {
+				redactedNotifier.RoutingKey = redactedValue
+			}
+
+			redacted.Notifiers[i] = &redactedNotifier
+		}
+	}
+
+	return &redacted
+}
+
+// Returns a copy of the secrets manager config safe to display, with its token and extra values redacted.
+func RedactedSecretsConfig(config *secrets.SecretsManagerConfig) *secrets.SecretsManagerConfig {
+	if config == nil {
+		return nil
+	}
+
+	redacted := *config
+	redacted.ServerURL = redactURL(config.ServerURL)
+
+	if config.Token != "" {
+		redacted.Token = redactedValue
+	}
+
+	if config.Extra != nil {
+		redacted.Extra = make(map[string]interface{}, len(config.Extra))
+
+		for key := range config.Extra {
+			redacted.Extra[key] = redactedValue
+		}
+	}
+
+	return &redacted
+}
+
+// Redacts the password and query parameter values of the URL, which may hold credentials or API keys.
+// Values that cannot be parsed as URLs are redacted entirely.
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return redactedValue
+	}
+
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for key := range query {
+			query.Set(key, redactedValue)
+		}
+
+		parsed.RawQuery = query.Encode()
+	}
+
+	if password, ok := parsed.User.Password(); ok && password != "" {
+		parsed.User = url.UserPassword(parsed.User.Username(), redactedValue)
+	}
+
+	return parsed.String()
+}
+
+// Redacts the path of the endpoint URL along with its password and query parameter values,
+// as RPC providers such as Infura and Alchemy embed the API key in the path, e.g. /v3/<key>.
+func redactEndpointURL(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return redactedValue
+	}
+
+	if strings.Trim(parsed.Path, "/") != "" {
+		parsed.Path = "/" + redactedValue
+		parsed.RawPath = ""
+	}
+
+	return redactURL(parsed.String())
+}
+
+// Redacts everything but the scheme and host of the webhook URL, as webhooks commonly embed their token in the path.
+func redactWebhookURL(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return redactedValue
+	}
+
+	return parsed.Scheme + "://" + parsed.Host + "/" + redactedValue
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Options applied at runtime when the config is reloaded, by their path of yaml keys.
+// Changes to any other option, such as the keys, signers, endpoints and contract addresses, require a restart.
+var reloadableOptions = map[string]bool{
+	"log_level":                       true,
+	"log_levels":                      true,
+	"reporter.verify_outcome_api_url": true,
+	"reporter.verify_outcome_timeout_seconds":  true,
+	"reporter.rpc_timeout_seconds":             true,
+	"reporter.tx_confirmation_timeout_seconds": true,
+	"reporter.gas":                          true,
+	"reporter.amqp_prefetch_count":          true,
+	"reporter.amqp_reconnect_delay_seconds": true,
+	"reporter.auto_withdraw_rewards":        true,
+	"reporter.reward_withdraw_threshold":    true,
+	"reporter.propose_role":                 true,
+	"reporter.vote_role":                    true,
+	"reporter.report_role":                  true,
+	"reporter.balance_warning_threshold":    true,
+	"reporter.balance_critical_threshold":   true,
+	"reporter.vote_policy":                  true,
+}
+
+// Reload applies the safe changes of the provided config at runtime, such as the log levels, the verify outcome API,
+// timeouts, gas policy, AMQP prefetch and reconnect delay, reward withdrawal, roles, balance thresholds
+// and the vote policy.
+// Changes to other options are ignored and logged, as they require a restart.
+// The provided config is expected to be validated. Nothing is applied if the changes are rejected by the reporter.
+func (serverConfig *ServerConfig) Reload(yamlServerConfig *YAMLServerConfig) error {
+	reloaded := *serverConfig.yamlConfig
+	changed := make([]string, 0)
+
+	for _, option := range mergeReloadableOptions(
+		reflect.ValueOf(&reloaded).Elem(),
+		reflect.ValueOf(yamlServerConfig).Elem(),
+		"",
+	) {
+		if !reloadableOptions[option] {
+			serverConfig.Logger.Warn("config change requires a restart, ignoring it", "option", option)
+
+			continue
+		}
+
+		changed = append(changed, option)
+	}
+
+	if len(changed) == 0 {
+		serverConfig.Logger.Info("config reloaded, no changes to apply")
+
+		return nil
+	}
+
+	reloadedConfig := reloaded.GenerateConfig()
+
+	reporterConfig, err := reloadedConfig.reporterServiceConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := serverConfig.ReporterService.Reload(reporterConfig); err != nil {
+		return fmt.Errorf("unable to reload the reporter config, %w", err)
+	}
+
+	if serverConfig.logLevels != nil {
+		serverConfig.logLevels.set(reloadedConfig.LogLevel, reloadedConfig.SubsystemLogLevels)
+	}
+
+	serverConfig.LogLevel = reloadedConfig.LogLevel
+	serverConfig.SubsystemLogLevels = reloadedConfig.SubsystemLogLevels
+	serverConfig.ReporterConfig = reloadedConfig.ReporterConfig
+	serverConfig.yamlConfig = &reloaded
+
+	serverConfig.Logger.Info("config reloaded", "changed", strings.Join(changed, ", "))
+
+	return nil
+}
+
+// Copies the reloadable options changed in the next config struct value into the current one,
+// recursing into the reporter config. Returns the paths of all changed options, reloadable or not.
+// Options without a yaml key, such as the secrets manager config, are kept.
+func mergeReloadableOptions(current, next reflect.Value, prefix string) []string {
+	changed := make([]string, 0)
+
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+
+		option := prefix + key
+		currentValue, nextValue := current.Field(i), next.Field(i)
+
+		if option == "reporter" {
+			reporterConfig := &YAMLReporterConfig{}
+			if !currentValue.IsNil() {
+				*reporterConfig = *currentValue.Interface().(*YAMLReporterConfig)
+			}
+
+			nextReporterConfig := &YAMLReporterConfig{}
+			if !nextValue.IsNil() {
+				nextReporterConfig = nextValue.Interface().(*YAMLReporterConfig)
+			}
+
+			changed = append(changed, mergeReloadableOptions(
+				reflect.ValueOf(reporterConfig).Elem(),
+				reflect.ValueOf(nextReporterConfig).Elem(),
+				option+".",
+			)...)
+
+			currentValue.Set(reflect.ValueOf(reporterConfig))
+
+			continue
+		}
+
+		if reflect.DeepEqual(currentValue.Interface(), nextValue.Interface()) {
+			continue
+		}
+
+		changed = append(changed, option)
+
+		if reloadableOptions[option] {
+			currentValue.Set(nextValue)
+		}
+	}
+
+	return changed
+}
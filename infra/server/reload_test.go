@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestMergeReloadableOptions(t *testing.T) {
+	current := newValidConfig()
+	current.OperatorAddr = "127.0.0.1:9632"
+
+	next := newValidConfig()
+	next.LogLevel = "DEBUG"
+	next.OperatorAddr = "127.0.0.1:9633"
+	next.YAMLReporterConfig.SXNodeAddress = "0x00000000000000000000000000000000000000b2"
+	next.YAMLReporterConfig.AMQPPrefetchCount = 10
+	next.YAMLReporterConfig.AMQPQueueName = "reporter-2"
+	next.YAMLReporterConfig.Keys = []*YAMLReporterKeyConfig{{Name: "hot"}}
+	next.YAMLReporterConfig.Gas = &YAMLGasConfig{MaxTxTries: 3}
+	next.YAMLReporterConfig.VerifyOutcomeTimeoutSeconds = 20
+
+	currentReporter := current.YAMLReporterConfig
+
+	changed := mergeReloadableOptions(reflect.ValueOf(current).Elem(), reflect.ValueOf(next).Elem(), "")
+	slices.Sort(changed)
+
+	want := []string{
+		"log_level",
+		"operator_addr",
+		"reporter.amqp_prefetch_count",
+		"reporter.amqp_queue_name",
+		"reporter.gas",
+		"reporter.keys",
+		"reporter.sx_node_address",
+		"reporter.verify_outcome_timeout_seconds",
+	}
+	if !slices.Equal(changed, want) {
+		t.Fatalf("mergeReloadableOptions() = %v, want %v", changed, want)
+	}
+
+	// only the reloadable options are applied
+	reporterConfig := current.YAMLReporterConfig
+
+	if current.LogLevel != "DEBUG" || reporterConfig.Gas.MaxTxTries != 3 || reporterConfig.VerifyOutcomeTimeoutSeconds != 20 ||
+		reporterConfig.AMQPPrefetchCount != 10 {
+		t.Errorf("reloadable options not applied, log level %s, gas %+v, verify timeout %d, prefetch %d",
+			current.LogLevel, reporterConfig.Gas, reporterConfig.VerifyOutcomeTimeoutSeconds, reporterConfig.AMQPPrefetchCount)
+	}
+
+	if current.OperatorAddr != "127.0.0.1:9632" || reporterConfig.SXNodeAddress != testAddress ||
+		reporterConfig.AMQPQueueName != "" || reporterConfig.Keys != nil {
+		t.Errorf("restart-only options applied, operator addr %s, sx node address %s, queue %s, keys %v",
+			current.OperatorAddr, reporterConfig.SXNodeAddress, reporterConfig.AMQPQueueName, reporterConfig.Keys)
+	}
+
+	// the reporter config is merged into a copy, leaving the config in use untouched
+	if reporterConfig == currentReporter || currentReporter.Gas != nil {
+		t.Error("reporter config merged in place")
+	}
+}
+
+func TestReloadRejectsRestartOnlyChanges(t *testing.T) {
+	var logs bytes.Buffer
+
+	current := newValidConfig()
+	serverConfig := &ServerConfig{
+		Logger:     hclog.New(&hclog.LoggerOptions{Output: &logs, Level: hclog.Info}),
+		yamlConfig: current,
+	}
+
+	next := newValidConfig()
+	next.DataDir = "/var/lib/sx-reporter"
+	next.YAMLReporterConfig.RPCURL = "http://127.0.0.1:10003"
+	next.YAMLReporterConfig.Keys = []*YAMLReporterKeyConfig{{Name: "hot"}}
+
+	// the reporter service is left untouched, as no change can be applied
+	if err := serverConfig.Reload(next); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if serverConfig.yamlConfig != current || current.DataDir != "" || current.YAMLReporterConfig.Keys != nil {
+		t.Errorf("restart-only changes applied to the config in use")
+	}
+
+	for _, option := range []string{"data_dir", "reporter.rpc_url", "reporter.keys"} {
+		if !strings.Contains(logs.String(), "config change requires a restart, ignoring it: option="+option) {
+			t.Errorf("logs = %s, want the %s change rejected", logs.String(), option)
+		}
+	}
+
+	if !strings.Contains(logs.String(), "config reloaded, no changes to apply") {
+		t.Errorf("logs = %s, want no changes applied", logs.String())
+	}
+}
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
 
-	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/infra/secrets/config"
 	"github.com/sx-network/sx-reporter/reporter"
@@ -18,6 +23,10 @@ func NewServer(serverConfig *ServerConfig) (*ServerConfig, error) {
 	}
 	serverConfig.Logger = logger
 
+	if err := serverConfig.setupTracing(); err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
 	if err := serverConfig.setupSecretsManager(); err != nil {
 		return nil, fmt.Errorf("failed to set up the secrets manager: %w", err)
 	}
@@ -26,18 +35,15 @@ func NewServer(serverConfig *ServerConfig) (*ServerConfig, error) {
 		return nil, err
 	}
 
-	return serverConfig, nil
-}
+	if err := serverConfig.setupOperatorServer(); err != nil {
+		return nil, fmt.Errorf("failed to set up the operator API: %w", err)
+	}
+
+	if err := serverConfig.setupPrometheusServer(); err != nil {
+		return nil, fmt.Errorf("failed to set up the prometheus server: %w", err)
+	}
 
-// Creates a new logger which logs to a specified file.
-// If log file is not set it outputs to standard output ( console ).
-// If log file is specified, and it can't be created the server command will error out
-func newLogger(serverConfig *ServerConfig) (hclog.Logger, error) {
-	return hclog.New(&hclog.LoggerOptions{
-		Name:       "sx-reporter-node",
-		Level:      serverConfig.LogLevel,
-		JSONFormat: serverConfig.JSONLogFormat,
-	}), nil
+	return serverConfig, nil
 }
 
 // Sets up the secrets manager based on the provided configuration.
@@ -62,7 +68,8 @@ func (serverConfig *ServerConfig) setupSecretsManager() error {
 
 	if secretsManagerType == secrets.Local {
 		secretsManagerParams.Extra = map[string]interface{}{
-			secrets.Path: serverConfig.DataDir,
+			secrets.Path:           serverConfig.DataDir,
+			secrets.PassphraseFile: serverConfig.KeystorePassphraseFile,
 		}
 	}
 
@@ -90,6 +97,66 @@ func (serverConfig *ServerConfig) setupSecretsManager() error {
 func (serverConfig *ServerConfig) setupReporterService() error {
 	serverConfig.Logger.Info("setup reporter service")
 
+	reporterConfig, err := serverConfig.reporterServiceConfig()
+	if err != nil {
+		return err
+	}
+
+	reporterService, err := reporter.NewReporterService(
+		serverConfig.Logger,
+		reporterConfig,
+		&serverConfig.SecretsManager,
+	)
+	if err != nil {
+		return err
+	}
+
+	serverConfig.ReporterService = reporterService
+
+	return nil
+}
+
+// Builds the reporter service config from the server's reporter config, parsing its wei amounts.
+func (serverConfig *ServerConfig) reporterServiceConfig() (*reporter.ReporterConfig, error) {
+	rewardWithdrawThreshold, err := parseWei("reward_withdraw_threshold", serverConfig.ReporterConfig.RewardWithdrawThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceWarningThreshold, err := parseWei("balance_warning_threshold", serverConfig.ReporterConfig.BalanceWarningThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceCriticalThreshold, err := parseWei("balance_critical_threshold", serverConfig.ReporterConfig.BalanceCriticalThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := parseWei("gas.gas_price", serverConfig.ReporterConfig.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	maxGasPrice, err := parseWei("gas.max_gas_price", serverConfig.ReporterConfig.MaxGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*reporter.ReporterKeyConfig, 0, len(serverConfig.ReporterConfig.Keys))
+
+	for _, keyConfig := range serverConfig.ReporterConfig.Keys {
+		keys = append(keys, &reporter.ReporterKeyConfig{
+			Name: keyConfig.Name,
+			Signer: &reporter.SignerConfig{
+				Type:    reporter.SignerType(keyConfig.SignerType),
+				URL:     keyConfig.SignerURL,
+				Address: keyConfig.SignerAddress,
+				Timeout: keyConfig.SignerTimeout,
+			},
+		})
+	}
+
 	reporterConfig := &reporter.ReporterConfig{
 		MQConfig: &reporter.MQConfig{
 			AMQPURI:      serverConfig.ReporterConfig.DataFeedAMQPURI,
@@ -97,22 +164,136 @@ func (serverConfig *ServerConfig) setupReporterService() error {
 			QueueConfig: &reporter.QueueConfig{
 				QueueName: serverConfig.ReporterConfig.DataFeedAMQPQueueName,
 			},
+			PrefetchCount:  serverConfig.ReporterConfig.AMQPPrefetchCount,
+			ReconnectDelay: serverConfig.ReporterConfig.AMQPReconnectDelay,
+		},
+		VerifyOutcomeURI:            serverConfig.ReporterConfig.VerifyOutcomeURI,
+		VotingPeriodRefreshInterval: serverConfig.ReporterConfig.VotingPeriodRefresh,
+		OutcomeReporterAddress:      serverConfig.ReporterConfig.OutcomeReporterAddress,
+		SXNodeAddress:               serverConfig.ReporterConfig.SXNodeAddress,
+		ProposeRole:                 serverConfig.ReporterConfig.ProposeRole,
+		VoteRole:                    serverConfig.ReporterConfig.VoteRole,
+		ReportRole:                  serverConfig.ReporterConfig.ReportRole,
+		StakingAddress:              serverConfig.ReporterConfig.StakingAddress,
+		StakingEligibilityFunction:  serverConfig.ReporterConfig.StakingFunction,
+		EligibilityRefreshInterval:  serverConfig.ReporterConfig.EligibilityRefresh,
+		DataDir:                     serverConfig.DataDir,
+		AutoWithdrawRewards:         serverConfig.ReporterConfig.AutoWithdrawRewards,
+		RewardWithdrawThreshold:     rewardWithdrawThreshold,
+		RewardPayoutAddress:         serverConfig.ReporterConfig.RewardPayoutAddress,
+		WSXAddress:                  serverConfig.ReporterConfig.WSXAddress,
+		Signer: &reporter.SignerConfig{
+			Type:    reporter.SignerType(serverConfig.ReporterConfig.SignerType),
+			URL:     serverConfig.ReporterConfig.SignerURL,
+			Address: serverConfig.ReporterConfig.SignerAddress,
+			Timeout: serverConfig.ReporterConfig.SignerTimeout,
+		},
+		Keys:                     keys,
+		Routing:                  serverConfig.ReporterConfig.Routing,
+		BalanceCheckInterval:     serverConfig.ReporterConfig.BalanceCheck,
+		BalanceWarningThreshold:  balanceWarningThreshold,
+		BalanceCriticalThreshold: balanceCriticalThreshold,
+		LowFundsPausedFunctions:  serverConfig.ReporterConfig.LowFundsPausedFunctions,
+		JSONRPCURL:               serverConfig.ReporterConfig.JSONRPCURL,
+		JSONRPCWsURL:             serverConfig.ReporterConfig.JSONRPCWsURL,
+		RPCTimeout:               serverConfig.ReporterConfig.RPCTimeout,
+		VerifyOutcomeTimeout:     serverConfig.ReporterConfig.VerifyOutcomeTimeout,
+		TxConfirmationTimeout:    serverConfig.ReporterConfig.TxConfirmationTimeout,
+		Gas: &reporter.GasConfig{
+			MaxTxTries:         serverConfig.ReporterConfig.GasMaxTxTries,
+			GasPrice:           gasPrice,
+			MaxGasPrice:        maxGasPrice,
+			GasLimitMultiplier: serverConfig.ReporterConfig.GasLimitMultiplier,
 		},
-		VerifyOutcomeURI:       serverConfig.ReporterConfig.VerifyOutcomeURI,
-		OutcomeReporterAddress: serverConfig.ReporterConfig.OutcomeReporterAddress,
-		SXNodeAddress:          serverConfig.ReporterConfig.SXNodeAddress,
+		Alerting:   serverConfig.ReporterConfig.Alerting,
+		VotePolicy: serverConfig.ReporterConfig.VotePolicy,
 	}
 
-	reporterService, err := reporter.NewReporterService(
-		serverConfig.Logger,
-		reporterConfig,
-		&serverConfig.SecretsManager,
+	if serverConfig.tracerProvider != nil {
+		reporterConfig.TracerProvider = serverConfig.tracerProvider
+	}
+
+	return reporterConfig, nil
+}
+
+// Parses an amount in wei from the reporter config option with the given name, returning nil if the value is empty.
+func parseWei(name string, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid reporter '%s' %q, expected an amount in wei", name, value)
+	}
+
+	return amount, nil
+}
+
+// Starts the operator API on the configured listen address.
+// The operator API is not started if no address is configured, and requires the configured token if any.
+func (serverConfig *ServerConfig) setupOperatorServer() error {
+	if serverConfig.OperatorAddr == "" {
+		return nil
+	}
+
+	handler := serverConfig.ReporterService.OperatorHandler()
+
+	if serverConfig.OperatorTokenFile != "" {
+		token, err := readOperatorToken(serverConfig.OperatorTokenFile)
+		if err != nil {
+			return err
+		}
+
+		handler = requireOperatorToken(token, handler)
+	}
+
+	serverConfig.Logger.Info(
+		"setup operator API",
+		"addr", serverConfig.OperatorAddr,
+		"authenticated", serverConfig.OperatorTokenFile != "",
 	)
+
+	serverConfig.operatorServer = &http.Server{
+		Addr:              serverConfig.OperatorAddr,
+		Handler:           handler,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	return serverConfig.listenAndServe(serverConfig.operatorServer, "operator API")
+}
+
+// Starts the prometheus metrics endpoint on the configured listen address.
+// Metrics are not served if no address is configured.
+func (serverConfig *ServerConfig) setupPrometheusServer() error {
+	if serverConfig.PrometheusAddr == "" {
+		return nil
+	}
+
+	serverConfig.Logger.Info("setup prometheus server", "addr", serverConfig.PrometheusAddr)
+
+	serverConfig.prometheusServer = &http.Server{
+		Addr:              serverConfig.PrometheusAddr,
+		Handler:           promhttp.Handler(),
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	return serverConfig.listenAndServe(serverConfig.prometheusServer, "prometheus server")
+}
+
+// Binds the listener for the given HTTP server and serves it in the background.
+// Binding errors are returned immediately, serving errors are logged.
+func (serverConfig *ServerConfig) listenAndServe(httpServer *http.Server, name string) error {
+	listener, err := net.Listen("tcp", httpServer.Addr)
 	if err != nil {
 		return err
 	}
 
-	serverConfig.ReporterService = reporterService
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverConfig.Logger.Error("http server stopped", "server", name, "err", err)
+		}
+	}()
 
 	return nil
 }
@@ -121,4 +302,24 @@ func (serverConfig *ServerConfig) setupReporterService() error {
 func (serverConfig *ServerConfig) Close() {
 	// close the txpool's main loop
 	// serverConfig.txpool.Close()
+
+	for _, httpServer := range []*http.Server{serverConfig.operatorServer, serverConfig.prometheusServer} {
+		if httpServer == nil {
+			continue
+		}
+
+		if err := httpServer.Close(); err != nil {
+			serverConfig.Logger.Error("failed to close http server", "addr", httpServer.Addr, "err", err)
+		}
+	}
+
+	if serverConfig.ReporterService != nil {
+		serverConfig.ReporterService.Close()
+	}
+
+	serverConfig.shutdownTracing()
+
+	if serverConfig.logFile != nil {
+		serverConfig.logFile.Close()
+	}
 }
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Constants representing the supported OTLP protocols.
+const (
+	// OTLPGRPC exports traces with OTLP over gRPC
+	OTLPGRPC = "grpc"
+	// OTLPHTTP exports traces with OTLP over HTTP
+	OTLPHTTP = "http"
+)
+
+const (
+	// Default service name reported with the spans.
+	defaultTracingServiceName = "sx-reporter"
+	// Time to wait for the pending spans to be exported on shutdown.
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// Sets up the export of the reporter's traces to the configured OTLP collector.
+// Tracing is disabled if no collector endpoint is configured.
+func (serverConfig *ServerConfig) setupTracing() error {
+	if serverConfig.Tracing == nil || serverConfig.Tracing.OTLPEndpoint == "" {
+		return nil
+	}
+
+	tracing := serverConfig.Tracing
+
+	serverConfig.Logger.Info("setup tracing", "endpoint", tracing.OTLPEndpoint, "protocol", tracing.protocol())
+
+	var client otlptrace.Client
+
+	switch tracing.protocol() {
+	case OTLPGRPC:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(tracing.OTLPEndpoint)}
+		if tracing.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		client = otlptracegrpc.NewClient(options...)
+	case OTLPHTTP:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(tracing.OTLPEndpoint)}
+		if tracing.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		client = otlptracehttp.NewClient(options...)
+	default:
+		return fmt.Errorf("unsupported OTLP protocol '%s'", tracing.Protocol)
+	}
+
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return fmt.Errorf("unable to create OTLP trace exporter, %w", err)
+	}
+
+	serviceName := tracing.ServiceName
+	if serviceName == "" {
+		serviceName = defaultTracingServiceName
+	}
+
+	serverConfig.tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracing.sampleRatio()))),
+	)
+
+	otel.SetTracerProvider(serverConfig.tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return nil
+}
+
+// Flushes the pending spans and stops exporting traces.
+func (serverConfig *ServerConfig) shutdownTracing() {
+	if serverConfig.tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := serverConfig.tracerProvider.Shutdown(ctx); err != nil {
+		serverConfig.Logger.Error("failed to shut down tracing", "err", err)
+	}
+}
+
+// Returns the OTLP protocol, gRPC if not configured.
+func (c *TracingConfig) protocol() string {
+	if c.Protocol == "" {
+		return OTLPGRPC
+	}
+
+	return c.Protocol
+}
+
+// Returns the ratio of sampled traces, all traces if not configured.
+func (c *TracingConfig) sampleRatio() float64 {
+	if c.SampleRatio == 0 {
+		return 1
+	}
+
+	return c.SampleRatio
+}
@@ -0,0 +1,569 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"github.com/sx-network/sx-reporter/reporter"
+)
+
+// Checks the config for invalid or missing options, returning an error describing every problem found.
+// It is called once defaults, environment and flag overrides have been applied.
+func (yamlServerConfig *YAMLServerConfig) Validate() error {
+	var errs []error
+
+	if yamlServerConfig.Version < 1 || yamlServerConfig.Version > ConfigVersion {
+		errs = append(errs, fmt.Errorf("unsupported config 'version' %d, expected %d", yamlServerConfig.Version, ConfigVersion))
+	}
+
+	if hclog.LevelFromString(yamlServerConfig.LogLevel) == hclog.NoLevel {
+		errs = append(errs, fmt.Errorf(
+			"invalid 'log_level' %q, expected one of TRACE, DEBUG, INFO, WARN or ERROR",
+			yamlServerConfig.LogLevel,
+		))
+	}
+
+	errs = append(errs, yamlServerConfig.validateLogging()...)
+	errs = append(errs, yamlServerConfig.Tracing.validate()...)
+
+	for _, listenAddr := range []struct{ name, addr string }{
+		{"operator_addr", yamlServerConfig.OperatorAddr},
+		{"prometheus_addr", yamlServerConfig.PrometheusAddr},
+	} {
+		if _, _, err := net.SplitHostPort(listenAddr.addr); listenAddr.addr != "" && err != nil {
+			errs = append(errs, fmt.Errorf(
+				"invalid '%s' %q, expected a host:port listen address",
+				listenAddr.name, listenAddr.addr,
+			))
+		}
+	}
+
+	if yamlServerConfig.OperatorAddr != "" && yamlServerConfig.OperatorTokenFile == "" &&
+		!isLoopbackAddr(yamlServerConfig.OperatorAddr) {
+		errs = append(errs, fmt.Errorf(
+			"'operator_addr' %q is not a loopback address, an 'operator_token_file' is required to expose the operator API",
+			yamlServerConfig.OperatorAddr,
+		))
+	}
+
+	if yamlServerConfig.YAMLReporterConfig == nil {
+		errs = append(errs, errors.New("missing 'reporter' config"))
+	} else {
+		errs = append(errs, yamlServerConfig.YAMLReporterConfig.validate()...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Checks the log level overrides and the log file rotation.
+func (yamlServerConfig *YAMLServerConfig) validateLogging() []error {
+	var errs []error
+
+	subsystems := make([]string, 0, len(yamlServerConfig.LogLevels))
+	for subsystem := range yamlServerConfig.LogLevels {
+		subsystems = append(subsystems, subsystem)
+	}
+
+	sort.Strings(subsystems)
+
+	for _, subsystem := range subsystems {
+		level := yamlServerConfig.LogLevels[subsystem]
+
+		if subsystem == "" {
+			errs = append(errs, errors.New("invalid 'log_levels', subsystem names cannot be empty"))
+		}
+
+		if hclog.LevelFromString(level) == hclog.NoLevel {
+			errs = append(errs, fmt.Errorf(
+				"invalid 'log_levels.%s' %q, expected one of TRACE, DEBUG, INFO, WARN or ERROR",
+				subsystem, level,
+			))
+		}
+	}
+
+	rotation := yamlServerConfig.LogRotation
+	if rotation == nil {
+		return errs
+	}
+
+	if yamlServerConfig.LogFile == "" {
+		errs = append(errs, errors.New("'log_rotation' provided but missing a 'log_file'"))
+	}
+
+	for _, option := range []struct {
+		name  string
+		value int
+	}{
+		{"max_size_mb", rotation.MaxSizeMB},
+		{"max_backups", rotation.MaxBackups},
+		{"max_age_days", rotation.MaxAgeDays},
+	} {
+		if option.value < 0 {
+			errs = append(errs, fmt.Errorf("invalid 'log_rotation.%s' %d, expected a non-negative value", option.name, option.value))
+		}
+	}
+
+	return errs
+}
+
+// Checks the trace export options.
+func (c *YAMLTracingConfig) validate() []error {
+	if c == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.OTLPEndpoint); c.OTLPEndpoint != "" && err != nil {
+		errs = append(errs, fmt.Errorf("invalid 'tracing.otlp_endpoint' %q, expected a host:port address", c.OTLPEndpoint))
+	}
+
+	switch c.Protocol {
+	case "", OTLPGRPC, OTLPHTTP:
+	default:
+		errs = append(errs, fmt.Errorf("invalid 'tracing.protocol' %q, expected %s or %s", c.Protocol, OTLPGRPC, OTLPHTTP))
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("invalid 'tracing.sample_ratio' %v, expected a value between 0 and 1", c.SampleRatio))
+	}
+
+	return errs
+}
+
+const (
+	// Default timeout of each JSON-RPC endpoint check.
+	defaultRPCCheckTimeout = 30 * time.Second
+)
+
+// Checks that the JSON-RPC endpoints are reachable and serve the same chain, returning its chain ID.
+// Each endpoint is given the configured RPC timeout to respond.
+func (yamlServerConfig *YAMLServerConfig) CheckRPCEndpoints() (*big.Int, error) {
+	reporterConfig := yamlServerConfig.YAMLReporterConfig
+	if reporterConfig == nil {
+		return nil, errors.New("missing 'reporter' config")
+	}
+
+	timeout := time.Duration(reporterConfig.RPCTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRPCCheckTimeout
+	}
+
+	var chainID *big.Int
+
+	for _, endpoint := range []struct{ name, url string }{
+		{"rpc_url", reporterConfig.RPCURL},
+		{"ws_rpc_url", reporterConfig.WSRPCURL},
+	} {
+		endpointChainID, err := queryChainID(endpoint.url, timeout)
+		if err != nil {
+			// the transport error holds the raw URL, which may hold credentials
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+
+			return nil, fmt.Errorf("reporter '%s' %q is unreachable, %w", endpoint.name, redactEndpointURL(endpoint.url), err)
+		}
+
+		if chainID != nil && chainID.Cmp(endpointChainID) != 0 {
+			return nil, fmt.Errorf(
+				"reporter 'ws_rpc_url' serves chain %s but 'rpc_url' serves chain %s",
+				endpointChainID, chainID,
+			)
+		}
+
+		chainID = endpointChainID
+	}
+
+	return chainID, nil
+}
+
+// Returns the chain ID served by the JSON-RPC endpoint.
+func queryChainID(rawURL string, timeout time.Duration) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ChainID(ctx)
+}
+
+// Checks the reporter config, returning an error for every problem found.
+func (c *YAMLReporterConfig) validate() []error {
+	var errs []error
+
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check(validateAddress("outcome_reporter_address", c.OutcomeReporterAddress, true))
+	check(validateAddress("sx_node_address", c.SXNodeAddress, true))
+	check(validateAddress("reward_payout_address", c.RewardPayoutAddress, false))
+	check(validateAddress("wsx_address", c.WSXAddress, false))
+	check(validateAddress("staking_address", c.StakingAddress, false))
+	check(reporter.ValidateStaking(c.StakingAddress, c.StakingEligibilityFunction))
+
+	check(validateURL("rpc_url", c.RPCURL, true, "http", "https"))
+	check(validateURL("ws_rpc_url", c.WSRPCURL, true, "ws", "wss"))
+	check(validateURL("verify_outcome_api_url", c.VerifyOutcomeAPIURL, false, "http", "https"))
+	check(validateURL("amqp_uri", c.AMQPURI, false, "amqp", "amqps"))
+
+	if c.AMQPURI != "" && c.AMQPExchangeName == "" {
+		errs = append(errs, errors.New("reporter 'amqp_uri' provided but missing a valid 'amqp_exchange_name'"))
+	}
+
+	if c.AMQPURI != "" && c.AMQPQueueName == "" {
+		errs = append(errs, errors.New("reporter 'amqp_uri' provided but missing a valid 'amqp_queue_name'"))
+	}
+
+	if c.AMQPPrefetchCount < 0 {
+		errs = append(errs, fmt.Errorf("invalid reporter 'amqp_prefetch_count' %d, expected a positive count", c.AMQPPrefetchCount))
+	}
+
+	if c.RewardPayoutAddress != "" && c.WSXAddress == "" {
+		errs = append(errs, errors.New("reporter 'reward_payout_address' provided but missing a valid 'wsx_address'"))
+	}
+
+	_, err := parseWei("reward_withdraw_threshold", c.RewardWithdrawThreshold)
+	check(err)
+
+	warning, err := parseWei("balance_warning_threshold", c.BalanceWarningThreshold)
+	check(err)
+
+	critical, err := parseWei("balance_critical_threshold", c.BalanceCriticalThreshold)
+	check(err)
+
+	if warning != nil && critical != nil && warning.Cmp(critical) < 0 {
+		errs = append(errs, errors.New("reporter 'balance_warning_threshold' is below 'balance_critical_threshold'"))
+	}
+
+	if c.Gas != nil {
+		errs = append(errs, c.Gas.validate()...)
+	}
+
+	check(validateSigner("signer", c.Signer))
+
+	// Key names referenced by the routing rules, the default key is used if no keys are configured
+	keyNames := make(map[string]bool, len(c.Keys))
+	if len(c.Keys) == 0 {
+		keyNames[secrets.DefaultReporterKeyName] = true
+	}
+
+	for i, key := range c.Keys {
+		if key == nil || key.Name == "" {
+			errs = append(errs, fmt.Errorf("reporter 'keys[%d]' is missing a 'name'", i))
+
+			continue
+		}
+
+		if err := secrets.ValidateReporterKeyName(key.Name); err != nil {
+			errs = append(errs, fmt.Errorf("invalid reporter 'keys[%d].name', %w", i, err))
+		}
+
+		if keyNames[key.Name] {
+			errs = append(errs, fmt.Errorf("duplicate reporter 'keys[%d].name' %q, key names must be unique", i, key.Name))
+		}
+
+		keyNames[key.Name] = true
+
+		check(validateSigner(fmt.Sprintf("keys[%d].signer", i), key.Signer))
+	}
+
+	errs = append(errs, validateRouting(c.Routing, keyNames)...)
+
+	for _, functionType := range c.LowFundsPausedFunctions {
+		if !slices.Contains(reporter.LowFundsPausableFunctions, functionType) {
+			errs = append(errs, fmt.Errorf(
+				"invalid reporter 'low_funds_paused_functions' entry %q, expected any of %s",
+				functionType, strings.Join(reporter.LowFundsPausableFunctions, ", "),
+			))
+		}
+	}
+
+	if c.Alerting != nil {
+		errs = append(errs, c.Alerting.validate()...)
+	}
+
+	if c.VotePolicy != nil {
+		errs = append(errs, c.VotePolicy.validate()...)
+	}
+
+	return errs
+}
+
+// Checks the routing rules against the configured key names, returning an error for every problem found.
+func validateRouting(routing map[string]string, keyNames map[string]bool) []error {
+	var errs []error
+
+	functionTypes := make([]string, 0, len(routing))
+	for functionType := range routing {
+		functionTypes = append(functionTypes, functionType)
+	}
+
+	sort.Strings(functionTypes)
+
+	for _, functionType := range functionTypes {
+		rule := routing[functionType]
+
+		if !slices.Contains(reporter.RoutedFunctions, functionType) {
+			errs = append(errs, fmt.Errorf(
+				"invalid reporter 'routing.%s', expected rules for any of %s",
+				functionType, strings.Join(reporter.RoutedFunctions, ", "),
+			))
+
+			continue
+		}
+
+		switch rule {
+		case "", reporter.RoundRobin:
+		case reporter.AllKeys:
+			if functionType != reporter.VoteOutcome {
+				errs = append(errs, fmt.Errorf(
+					"invalid reporter 'routing.%s' %q, only supported for %s",
+					functionType, rule, reporter.VoteOutcome,
+				))
+			}
+		default:
+			if !keyNames[rule] {
+				errs = append(errs, fmt.Errorf(
+					"invalid reporter 'routing.%s' %q, expected a configured key name, %s or %s",
+					functionType, rule, reporter.RoundRobin, reporter.AllKeys,
+				))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Checks the vote policy, returning an error for every problem found.
+func (c *YAMLVotePolicyConfig) validate() []error {
+	var errs []error
+
+	switch reporter.VotePolicy(c.Policy) {
+	case "", reporter.DelayVotePolicy, reporter.AbstainVotePolicy:
+	default:
+		errs = append(errs, fmt.Errorf(
+			"invalid reporter 'vote_policy.policy' %q, expected one of %s or %s",
+			c.Policy, reporter.DelayVotePolicy, reporter.AbstainVotePolicy,
+		))
+	}
+
+	if c.InitialBackoffSeconds != 0 && c.MaxBackoffSeconds != 0 && c.InitialBackoffSeconds > c.MaxBackoffSeconds {
+		errs = append(errs, errors.New("reporter 'vote_policy.initial_backoff_seconds' is above 'vote_policy.max_backoff_seconds'"))
+	}
+
+	if c.RetryPeriodFraction < 0 || c.RetryPeriodFraction > 1 {
+		errs = append(errs, fmt.Errorf(
+			"invalid reporter 'vote_policy.retry_period_fraction' %v, expected a fraction between 0 and 1",
+			c.RetryPeriodFraction,
+		))
+	}
+
+	return errs
+}
+
+// Checks the alerting rules and notifiers, returning an error for every problem found.
+func (c *YAMLAlertingConfig) validate() []error {
+	var errs []error
+
+	for _, rule := range c.Rules {
+		if !slices.Contains(reporter.AlertRules, rule) {
+			errs = append(errs, fmt.Errorf(
+				"invalid reporter 'alerting.rules' entry %q, expected any of %s",
+				rule, strings.Join(reporter.AlertRules, ", "),
+			))
+		}
+	}
+
+	for _, count := range []struct {
+		name  string
+		value int
+	}{
+		{"alerting.rate_limit_per_minute", c.RateLimitPerMinute},
+		{"alerting.verify_error_threshold", c.VerifyErrorThreshold},
+	} {
+		if count.value < 0 {
+			errs = append(errs, fmt.Errorf("invalid reporter '%s' %d, expected a positive count", count.name, count.value))
+		}
+	}
+
+	for i, notifier := range c.Notifiers {
+		name := fmt.Sprintf("alerting.notifiers[%d]", i)
+
+		if notifier == nil {
+			errs = append(errs, fmt.Errorf("reporter '%s' is missing a 'type'", name))
+
+			continue
+		}
+
+		if err := validateNotifier(name, notifier); err != nil {
+			errs = append(errs, err)
+		}
+
+		for _, rule := range notifier.Rules {
+			if !slices.Contains(reporter.AlertRules, rule) {
+				errs = append(errs, fmt.Errorf(
+					"invalid reporter '%s.rules' entry %q, expected any of %s",
+					name, rule, strings.Join(reporter.AlertRules, ", "),
+				))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Checks the notifier config found under the given key.
+func validateNotifier(name string, config *YAMLNotifierConfig) error {
+	switch reporter.NotifierType(config.Type) {
+	case reporter.WebhookNotifier, reporter.SlackNotifier:
+		return validateURL(name+".url", config.URL, true, "http", "https")
+	case reporter.PagerDutyNotifier:
+		if config.RoutingKey == "" {
+			return fmt.Errorf("missing reporter '%s.routing_key'", name)
+		}
+
+		return validateURL(name+".url", config.URL, false, "http", "https")
+	default:
+		return fmt.Errorf(
+			"invalid reporter '%s.type' %q, expected one of %s, %s or %s",
+			name, config.Type, reporter.WebhookNotifier, reporter.SlackNotifier, reporter.PagerDutyNotifier,
+		)
+	}
+}
+
+// Checks the gas policy, returning an error for every problem found.
+func (c *YAMLGasConfig) validate() []error {
+	var errs []error
+
+	if c.MaxTxTries < 0 {
+		errs = append(errs, fmt.Errorf("invalid reporter 'gas.max_tx_tries' %d, expected a positive count", c.MaxTxTries))
+	}
+
+	if c.GasLimitMultiplier != 0 && c.GasLimitMultiplier < 1 {
+		errs = append(errs, fmt.Errorf(
+			"invalid reporter 'gas.gas_limit_multiplier' %v, expected a multiplier of at least 1",
+			c.GasLimitMultiplier,
+		))
+	}
+
+	gasPrice, err := parseWei("gas.gas_price", c.GasPrice)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	maxGasPrice, err := parseWei("gas.max_gas_price", c.MaxGasPrice)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if gasPrice != nil && maxGasPrice != nil && gasPrice.Cmp(maxGasPrice) > 0 {
+		errs = append(errs, errors.New("reporter 'gas.gas_price' is above 'gas.max_gas_price'"))
+	}
+
+	return errs
+}
+
+// Checks the signer config found under the given key.
+func validateSigner(name string, config *YAMLSignerConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	switch reporter.SignerType(config.Type) {
+	case "", reporter.LocalSigner, reporter.BackendSigner:
+		return nil
+	case reporter.Web3Signer, reporter.ClefSigner:
+		if err := validateURL(name+".url", config.URL, true, "http", "https"); err != nil {
+			return err
+		}
+
+		return validateAddress(name+".address", config.Address, true)
+	default:
+		return fmt.Errorf(
+			"invalid reporter '%s.type' %q, expected one of %s, %s, %s or %s",
+			name, config.Type, reporter.LocalSigner, reporter.Web3Signer, reporter.ClefSigner, reporter.BackendSigner,
+		)
+	}
+}
+
+// Checks that the reporter option holds a hex encoded address, if set or required.
+func validateAddress(name string, value string, required bool) error {
+	if value == "" {
+		if required {
+			return fmt.Errorf("missing reporter '%s'", name)
+		}
+
+		return nil
+	}
+
+	if !common.IsHexAddress(value) {
+		return fmt.Errorf("invalid reporter '%s' %q, expected a hex encoded address", name, value)
+	}
+
+	return nil
+}
+
+// Checks that the reporter option holds an absolute URL with one of the given schemes, if set or required.
+// The URL is redacted in the error, including its path, as it may hold credentials or API keys.
+func validateURL(name string, value string, required bool, schemes ...string) error {
+	if value == "" {
+		if required {
+			return fmt.Errorf("missing reporter '%s'", name)
+		}
+
+		return nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		// the parse error holds the raw URL, so it is left out
+		return fmt.Errorf("invalid reporter '%s', expected a URL with scheme %s", name, joinSchemes(schemes))
+	}
+
+	if parsed.Host != "" {
+		for _, scheme := range schemes {
+			if parsed.Scheme == scheme {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("invalid reporter '%s' %q, expected a URL with scheme %s", name, redactEndpointURL(value), joinSchemes(schemes))
+}
+
+// Joins URL schemes for error messages, e.g. "http or https".
+func joinSchemes(schemes []string) string {
+	joined := schemes[0]
+
+	for i := 1; i < len(schemes); i++ {
+		separator := ", "
+		if i == len(schemes)-1 {
+			separator = " or "
+		}
+
+		joined += separator + schemes[i]
+	}
+
+	return joined
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testAddress = "0x00000000000000000000000000000000000000a1"
+
+// Returns a valid config with its defaults filled in.
+func newValidConfig() *YAMLServerConfig {
+	config := &YAMLServerConfig{
+		YAMLReporterConfig: &YAMLReporterConfig{
+			OutcomeReporterAddress: testAddress,
+			SXNodeAddress:          testAddress,
+		},
+	}
+	config.SetDefaults()
+
+	return config
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *YAMLServerConfig)
+		wantErr string
+	}{
+		{"valid", func(*YAMLServerConfig) {}, ""},
+		{"unsupported version", func(c *YAMLServerConfig) { c.Version = ConfigVersion + 1 }, "unsupported config 'version'"},
+		{"log level", func(c *YAMLServerConfig) { c.LogLevel = "LOUD" }, "invalid 'log_level' \"LOUD\""},
+		{
+			"subsystem log level",
+			func(c *YAMLServerConfig) { c.LogLevels = map[string]string{"reporter.tx": "LOUD"} },
+			"invalid 'log_levels.reporter.tx' \"LOUD\"",
+		},
+		{
+			"log rotation without a log file",
+			func(c *YAMLServerConfig) { c.LogRotation = &YAMLLogRotationConfig{} },
+			"'log_rotation' provided but missing a 'log_file'",
+		},
+		{
+			"negative log rotation",
+			func(c *YAMLServerConfig) {
+				c.LogFile, c.LogRotation = "reporter.log", &YAMLLogRotationConfig{MaxBackups: -1}
+			},
+			"invalid 'log_rotation.max_backups' -1",
+		},
+		{"listen address", func(c *YAMLServerConfig) { c.PrometheusAddr = "9090" }, "invalid 'prometheus_addr' \"9090\""},
+		{"loopback operator API", func(c *YAMLServerConfig) { c.OperatorAddr = "127.0.0.1:9632" }, ""},
+		{
+			"exposed operator API without a token",
+			func(c *YAMLServerConfig) { c.OperatorAddr = ":9632" },
+			"'operator_addr' \":9632\" is not a loopback address",
+		},
+		{
+			"exposed operator API with a token",
+			func(c *YAMLServerConfig) { c.OperatorAddr, c.OperatorTokenFile = "0.0.0.0:9632", "operator-token" },
+			"",
+		},
+		{"missing reporter", func(c *YAMLServerConfig) { c.YAMLReporterConfig = nil }, "missing 'reporter' config"},
+		{
+			"missing contract address",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.SXNodeAddress = "" },
+			"missing reporter 'sx_node_address'",
+		},
+		{
+			"invalid address",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.WSXAddress = "0x1234" },
+			"invalid reporter 'wsx_address'",
+		},
+		{
+			"rpc url scheme",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.WSRPCURL = "http://127.0.0.1:10002" },
+			"reporter 'ws_rpc_url'",
+		},
+		{
+			"amqp without an exchange",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.AMQPURI, c.YAMLReporterConfig.AMQPQueueName = "amqp://127.0.0.1:5672", "reports"
+			},
+			"reporter 'amqp_uri' provided but missing a valid 'amqp_exchange_name'",
+		},
+		{
+			"payout address without wsx",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.RewardPayoutAddress = testAddress },
+			"reporter 'reward_payout_address' provided but missing a valid 'wsx_address'",
+		},
+		{
+			"balance thresholds",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.BalanceWarningThreshold, c.YAMLReporterConfig.BalanceCriticalThreshold = "10", "20"
+			},
+			"reporter 'balance_warning_threshold' is below 'balance_critical_threshold'",
+		},
+		{
+			"invalid amount",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.RewardWithdrawThreshold = "1.5" },
+			"invalid reporter 'reward_withdraw_threshold' \"1.5\"",
+		},
+		{
+			"gas prices",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.Gas = &YAMLGasConfig{GasPrice: "200", MaxGasPrice: "100"}
+			},
+			"reporter 'gas.gas_price' is above 'gas.max_gas_price'",
+		},
+		{
+			"remote signer without an address",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.Signer = &YAMLSignerConfig{Type: "web3signer", URL: "http://127.0.0.1:9000"}
+			},
+			"missing reporter 'signer.address'",
+		},
+		{
+			"duplicate key names",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.Keys = []*YAMLReporterKeyConfig{{Name: "hot"}, {Name: "hot"}}
+			},
+			"duplicate reporter 'keys[1].name' \"hot\"",
+		},
+		{
+			"routing to an unknown key",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.Keys = []*YAMLReporterKeyConfig{{Name: "hot"}}
+				c.YAMLReporterConfig.Routing = map[string]string{"reportOutcome": "cold"}
+			},
+			"invalid reporter 'routing.reportOutcome' \"cold\"",
+		},
+		{
+			"low funds paused emergency reports",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.LowFundsPausedFunctions = []string{"emergencyReportOutcome"}
+			},
+			"invalid reporter 'low_funds_paused_functions' entry \"emergencyReportOutcome\"",
+		},
+		{
+			"vote policy",
+			func(c *YAMLServerConfig) { c.YAMLReporterConfig.VotePolicy = &YAMLVotePolicyConfig{Policy: "guess"} },
+			"invalid reporter 'vote_policy.policy' \"guess\"",
+		},
+		{
+			"alert notifier",
+			func(c *YAMLServerConfig) {
+				c.YAMLReporterConfig.Alerting = &YAMLAlertingConfig{Notifiers: []*YAMLNotifierConfig{{Type: "pagerduty"}}}
+			},
+			"missing reporter 'alerting.notifiers[0].routing_key'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newValidConfig()
+			tt.modify(config)
+
+			err := config.Validate()
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	config := newValidConfig()
+	config.LogLevel = "LOUD"
+	config.YAMLReporterConfig.OutcomeReporterAddress = ""
+	config.YAMLReporterConfig.AMQPPrefetchCount = -1
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil")
+	}
+
+	for _, want := range []string{"'log_level'", "'outcome_reporter_address'", "'amqp_prefetch_count'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %v, want it to report %s", err, want)
+		}
+	}
+}
+
+func TestConfigTemplateIsValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(ConfigTemplate), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(path, func(string) (string, bool) { return "", false })
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v for the config template", err)
+	}
+}
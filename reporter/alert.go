@@ -0,0 +1,360 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// Default window within which an alert is not sent again at the same severity.
+	defaultAlertDedupWindow = 15 * time.Minute
+	// Default maximum number of alerts sent per minute across rules.
+	defaultAlertRateLimit = 20
+	// Default number of verify outcome API errors within the window raising an alert.
+	defaultVerifyErrorThreshold = 5
+	// Default window the verify outcome API errors are counted in.
+	defaultVerifyErrorWindow = 5 * time.Minute
+	// Default time without new heads after which the event subscriptions are considered stalled.
+	// Blocks are only produced when there are txs to include, so this is kept well above the block time.
+	defaultSubscriptionStallTimeout = 10 * time.Minute
+	// Number of alerts waiting to be sent before new alerts are dropped.
+	alertQueueSize = 64
+)
+
+// Constants representing the alerting rules.
+const (
+	// AlertTxFailed pertains to a reporting tx that could not be sent or mined after all tries
+	AlertTxFailed = "tx_failed"
+	// AlertBalanceLow pertains to a reporter key's balance below the warning or critical threshold
+	AlertBalanceLow = "balance_low"
+	// AlertSubscriptionStalled pertains to event subscriptions that failed or stopped receiving new heads
+	AlertSubscriptionStalled = "subscription_stalled"
+	// AlertVerifyErrors pertains to verify outcome API errors above the threshold
+	AlertVerifyErrors = "verify_errors"
+	// AlertOutcomeDisagreement pertains to outcomes of a market disagreeing with each other
+	AlertOutcomeDisagreement = "outcome_disagreement"
+)
+
+// Constants representing the severity of alerts, following the PagerDuty severities.
+const (
+	AlertWarning  = "warning"
+	AlertCritical = "critical"
+)
+
+// Suppression reasons of alerts.
+const (
+	// Resolutions of alerts that were not sent, which are dropped silently.
+	suppressedNotActive = "not_active"
+	// Alerts dropped as the queue is full, which are sent again once raised again.
+	// Alerts of persistent conditions, such as a low balance or stalled subscriptions, are raised on every check.
+	suppressedQueueFull = "queue_full"
+)
+
+// Rules whose alerts are resolved once their condition clears. Alerts of other rules are forgotten after the dedup window.
+var resolvableAlertRules = map[string]bool{AlertBalanceLow: true, AlertSubscriptionStalled: true, AlertVerifyErrors: true}
+
+// AlertRules lists the supported alerting rules.
+var AlertRules = []string{AlertTxFailed, AlertBalanceLow, AlertSubscriptionStalled, AlertVerifyErrors, AlertOutcomeDisagreement}
+
+// Holds configuration options for alerting.
+type AlertingConfig struct {
+	Notifiers                []*NotifierConfig // Backends alerts are sent to, alerting is disabled if empty.
+	Rules                    []string          // Rules raising alerts, all rules are enabled if nil.
+	DedupWindow              time.Duration     // Window within which an alert is not sent again, defaults if zero.
+	RateLimit                int               // Maximum alerts sent per minute across rules, defaults if zero.
+	VerifyErrorThreshold     int               // Verify outcome API errors within the window raising an alert, defaults if zero.
+	VerifyErrorWindow        time.Duration     // Window the verify outcome API errors are counted in, defaults if zero.
+	SubscriptionStallTimeout time.Duration     // Time without new heads after which subscriptions are stalled, defaults if zero.
+}
+
+// Describes an alert, or the resolution of a previous alert with the same dedup key.
+type Alert struct {
+	Rule     string            `json:"rule"`
+	Severity string            `json:"severity"`
+	Summary  string            `json:"summary"`
+	DedupKey string            `json:"dedupKey"` // Identifies the condition alerted on, e.g. the rule and key.
+	Resolved bool              `json:"resolved"`
+	Details  map[string]string `json:"details,omitempty"`
+	Source   string            `json:"source"` // Host name of the node.
+	Time     time.Time         `json:"time"`
+}
+
+// Checks that the alerting rules are supported.
+func validateAlertRules(rules []string) error {
+	for _, rule := range rules {
+		if !slices.Contains(AlertRules, rule) {
+			return fmt.Errorf("reporter alerting rule '%s' not found", rule)
+		}
+	}
+
+	return nil
+}
+
+// Sends the alerts of the enabled rules to the notifiers, in the background.
+// Alerts already sent at the same severity within the dedup window are suppressed,
+// as are alerts above the rate limit. Resolutions are only sent for alerts that were sent.
+type alerter struct {
+	logger    hclog.Logger
+	metrics   *Metrics
+	config    *AlertingConfig
+	notifiers []*alertNotifier
+	rules     map[string]bool
+	source    string
+	queue     chan *Alert
+	active    map[string]*Alert // Last alert sent by dedup key, until resolved.
+	sent      []time.Time       // Time of the alerts sent within the last minute.
+	verifyErr []time.Time       // Time of the verify outcome API errors within the window.
+	sync.Mutex
+}
+
+// Notifier the alerts of its rules are sent to.
+type alertNotifier struct {
+	Notifier
+	rules []string // Rules whose alerts are sent to the notifier, all if nil.
+}
+
+// Creates the alerter and starts sending its alerts, nil if no notifier is configured.
+func newAlerter(logger hclog.Logger, metrics *Metrics, config *AlertingConfig) (*alerter, error) {
+	if config == nil || len(config.Notifiers) == 0 {
+		return nil, nil
+	}
+
+	if err := validateAlertRules(config.Rules); err != nil {
+		return nil, err
+	}
+
+	rules := config.Rules
+	if rules == nil {
+		rules = AlertRules
+	}
+
+	a := &alerter{
+		logger:  logger.Named("alerter"),
+		metrics: metrics,
+		config:  config,
+		rules:   make(map[string]bool, len(rules)),
+		queue:   make(chan *Alert, alertQueueSize),
+		active:  make(map[string]*Alert),
+	}
+
+	for _, rule := range rules {
+		a.rules[rule] = true
+	}
+
+	for _, notifierConfig := range config.Notifiers {
+		if err := validateAlertRules(notifierConfig.Rules); err != nil {
+			return nil, err
+		}
+
+		notifier, err := newNotifier(notifierConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		a.notifiers = append(a.notifiers, &alertNotifier{Notifier: notifier, rules: notifierConfig.Rules})
+	}
+
+	a.source, _ = os.Hostname()
+
+	go a.startSendLoop()
+
+	return a, nil
+}
+
+// Queues the alert, unless its rule is disabled or it is suppressed.
+func (a *alerter) raise(alert *Alert) {
+	if a == nil || !a.rules[alert.Rule] {
+		return
+	}
+
+	alert.Source = a.source
+	alert.Time = time.Now()
+
+	switch reason := a.enqueue(alert); reason {
+	case "", suppressedNotActive:
+	case suppressedQueueFull:
+		a.logger.Warn("alert queue is full, dropping alert", "rule", alert.Rule, "dedupKey", alert.DedupKey)
+		a.metrics.AlertsSuppressed.WithLabelValues(alert.Rule, reason).Inc()
+	default:
+		a.logger.Debug("suppressed alert", "rule", alert.Rule, "dedupKey", alert.DedupKey, "reason", reason)
+		a.metrics.AlertsSuppressed.WithLabelValues(alert.Rule, reason).Inc()
+	}
+}
+
+// Resolves the alert previously raised with the dedup key, if any.
+func (a *alerter) resolve(rule string, dedupKey string, summary string) {
+	a.raise(&Alert{Rule: rule, DedupKey: dedupKey, Summary: summary, Resolved: true})
+}
+
+// Queues the alert unless it is suppressed, returning the reason it is suppressed, empty if queued.
+// The alert is only recorded as sent once queued, so that dropped alerts are not deduplicated.
+func (a *alerter) enqueue(alert *Alert) string {
+	a.Lock()
+	defer a.Unlock()
+
+	for dedupKey, active := range a.active {
+		if !resolvableAlertRules[active.Rule] && alert.Time.Sub(active.Time) >= a.dedupWindow() {
+			delete(a.active, dedupKey)
+		}
+	}
+
+	active, isActive := a.active[alert.DedupKey]
+
+	switch {
+	case alert.Resolved && !isActive:
+		return suppressedNotActive
+	case alert.Resolved:
+		alert.Severity = active.Severity
+	case isActive && active.Severity == alert.Severity && alert.Time.Sub(active.Time) < a.dedupWindow():
+		return "duplicate"
+	}
+
+	a.sent = pruneBefore(a.sent, alert.Time.Add(-time.Minute))
+
+	if len(a.sent) >= a.rateLimit() {
+		return "rate_limited"
+	}
+
+	select {
+	case a.queue <- alert:
+	default:
+		return suppressedQueueFull
+	}
+
+	a.sent = append(a.sent, alert.Time)
+
+	if alert.Resolved {
+		delete(a.active, alert.DedupKey)
+	} else {
+		a.active[alert.DedupKey] = alert
+	}
+
+	return ""
+}
+
+func (a *alerter) dedupWindow() time.Duration {
+	if a.config.DedupWindow <= 0 {
+		return defaultAlertDedupWindow
+	}
+
+	return a.config.DedupWindow
+}
+
+func (a *alerter) rateLimit() int {
+	if a.config.RateLimit <= 0 {
+		return defaultAlertRateLimit
+	}
+
+	return a.config.RateLimit
+}
+
+// Sends the queued alerts to the notifiers of their rule, logging failures.
+func (a *alerter) startSendLoop() {
+	for alert := range a.queue {
+		for _, notifier := range a.notifiers {
+			if notifier.rules != nil && !slices.Contains(notifier.rules, alert.Rule) {
+				continue
+			}
+
+			result := "success"
+
+			if err := notifier.Notify(alert); err != nil {
+				a.logger.Error("failed to send alert", "notifier", notifier.Type(), "rule", alert.Rule, "dedupKey", alert.DedupKey, "err", err)
+
+				result = "failed"
+			}
+
+			a.metrics.AlertsSent.WithLabelValues(alert.Rule, string(notifier.Type()), result).Inc()
+		}
+	}
+}
+
+// Records a verify outcome API error and raises an alert once the errors within the window reach the threshold.
+func (a *alerter) recordVerifyError(err error) {
+	if a == nil {
+		return
+	}
+
+	threshold := a.config.VerifyErrorThreshold
+	if threshold <= 0 {
+		threshold = defaultVerifyErrorThreshold
+	}
+
+	window := a.config.VerifyErrorWindow
+	if window <= 0 {
+		window = defaultVerifyErrorWindow
+	}
+
+	now := time.Now()
+
+	a.Lock()
+	a.verifyErr = append(pruneBefore(a.verifyErr, now.Add(-window)), now)
+	count := len(a.verifyErr)
+	a.Unlock()
+
+	if count < threshold {
+		return
+	}
+
+	a.raise(&Alert{
+		Rule:     AlertVerifyErrors,
+		Severity: AlertWarning,
+		Summary:  fmt.Sprintf("%d verify outcome API errors within %s", count, window),
+		DedupKey: AlertVerifyErrors,
+		Details: map[string]string{
+			"errors":    fmt.Sprint(count),
+			"window":    window.String(),
+			"lastError": err.Error(),
+		},
+	})
+}
+
+// Resolves the verify outcome API errors alert once the errors within the window are below the threshold.
+func (a *alerter) recordVerifySuccess() {
+	if a == nil {
+		return
+	}
+
+	threshold := a.config.VerifyErrorThreshold
+	if threshold <= 0 {
+		threshold = defaultVerifyErrorThreshold
+	}
+
+	window := a.config.VerifyErrorWindow
+	if window <= 0 {
+		window = defaultVerifyErrorWindow
+	}
+
+	a.Lock()
+	a.verifyErr = pruneBefore(a.verifyErr, time.Now().Add(-window))
+	count := len(a.verifyErr)
+	a.Unlock()
+
+	if count < threshold {
+		a.resolve(AlertVerifyErrors, AlertVerifyErrors, "verify outcome API errors are below the threshold")
+	}
+}
+
+// Returns the time after which the event subscriptions are considered stalled without new heads.
+func (a *alerter) subscriptionStallTimeout() time.Duration {
+	if a == nil || a.config.SubscriptionStallTimeout <= 0 {
+		return defaultSubscriptionStallTimeout
+	}
+
+	return a.config.SubscriptionStallTimeout
+}
+
+// Drops the times before the cutoff from the sorted times.
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+
+	return times[i:]
+}
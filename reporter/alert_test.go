@@ -0,0 +1,230 @@
+package reporter
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Creates an alerter whose queued alerts are left in the queue of the given size, without sending them.
+func newQueuedAlerter(config *AlertingConfig, queueSize int) *alerter {
+	a := &alerter{
+		logger:  hclog.NewNullLogger(),
+		metrics: sharedTestMetrics(),
+		config:  config,
+		rules:   make(map[string]bool),
+		queue:   make(chan *Alert, queueSize),
+		active:  make(map[string]*Alert),
+	}
+
+	for _, rule := range AlertRules {
+		a.rules[rule] = true
+	}
+
+	return a
+}
+
+// Returns the alerts left in the queue.
+func drainAlerts(a *alerter) []*Alert {
+	var alerts []*Alert
+
+	for {
+		select {
+		case alert := <-a.queue:
+			alerts = append(alerts, alert)
+		default:
+			return alerts
+		}
+	}
+}
+
+func newBalanceAlert(severity string) *Alert {
+	return &Alert{Rule: AlertBalanceLow, Severity: severity, Summary: "balance is low", DedupKey: "balance_low/default"}
+}
+
+func TestAlerterDedup(t *testing.T) {
+	a := newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+	a.raise(newBalanceAlert(AlertWarning))
+	a.raise(newBalanceAlert(AlertWarning))
+
+	if alerts := drainAlerts(a); len(alerts) != 1 {
+		t.Fatalf("queued %d alerts for a repeated warning, want 1", len(alerts))
+	}
+
+	// a change of severity is sent right away
+	a.raise(newBalanceAlert(AlertCritical))
+
+	if alerts := drainAlerts(a); len(alerts) != 1 || alerts[0].Severity != AlertCritical {
+		t.Fatalf("queued %v for an escalation, want the critical alert", alerts)
+	}
+
+	// the same alert is sent again once the dedup window has passed
+	a.active["balance_low/default"].Time = time.Now().Add(-defaultAlertDedupWindow)
+	a.raise(newBalanceAlert(AlertCritical))
+
+	if alerts := drainAlerts(a); len(alerts) != 1 {
+		t.Fatalf("queued %d alerts after the dedup window, want 1", len(alerts))
+	}
+
+	a.resolve(AlertBalanceLow, "balance_low/default", "balance is above the thresholds")
+	a.resolve(AlertBalanceLow, "balance_low/default", "balance is above the thresholds")
+
+	alerts := drainAlerts(a)
+	if len(alerts) != 1 || !alerts[0].Resolved || alerts[0].Severity != AlertCritical {
+		t.Fatalf("queued %v for two resolutions, want one critical resolution", alerts)
+	}
+
+	if _, ok := a.active["balance_low/default"]; ok {
+		t.Error("resolved alert is still active")
+	}
+}
+
+func TestAlerterForgetsUnresolvableAlerts(t *testing.T) {
+	a := newQueuedAlerter(&AlertingConfig{DedupWindow: time.Minute}, alertQueueSize)
+
+	alert := &Alert{Rule: AlertTxFailed, Severity: AlertCritical, Summary: "tx failed", DedupKey: "tx_failed/market"}
+	a.raise(alert)
+	alert.Time = time.Now().Add(-time.Minute)
+
+	a.raise(&Alert{Rule: AlertBalanceLow, Severity: AlertWarning, DedupKey: "balance_low/default"})
+
+	if _, ok := a.active["tx_failed/market"]; ok {
+		t.Error("tx failed alert is still active after the dedup window")
+	}
+
+	if _, ok := a.active["balance_low/default"]; !ok {
+		t.Error("balance alert is not active")
+	}
+}
+
+func TestAlerterRateLimit(t *testing.T) {
+	a := newQueuedAlerter(&AlertingConfig{RateLimit: 2}, alertQueueSize)
+
+	for _, dedupKey := range []string{"a", "b", "c"} {
+		a.raise(&Alert{Rule: AlertTxFailed, Severity: AlertCritical, DedupKey: dedupKey})
+	}
+
+	alerts := drainAlerts(a)
+	if len(alerts) != 2 || alerts[0].DedupKey != "a" || alerts[1].DedupKey != "b" {
+		t.Fatalf("queued %v above the rate limit, want a and b", alerts)
+	}
+
+	// the rate limited alert was not recorded as sent, so it is sent once the minute has passed
+	for i := range a.sent {
+		a.sent[i] = a.sent[i].Add(-time.Minute)
+	}
+
+	a.raise(&Alert{Rule: AlertTxFailed, Severity: AlertCritical, DedupKey: "c"})
+
+	if alerts := drainAlerts(a); len(alerts) != 1 || alerts[0].DedupKey != "c" {
+		t.Fatalf("queued %v after the rate limit window, want c", alerts)
+	}
+}
+
+func TestAlerterQueueFull(t *testing.T) {
+	a := newQueuedAlerter(&AlertingConfig{}, 1)
+
+	a.raise(&Alert{Rule: AlertTxFailed, Severity: AlertCritical, DedupKey: "a"})
+	a.raise(newBalanceAlert(AlertWarning))
+
+	if _, ok := a.active["balance_low/default"]; ok {
+		t.Fatal("dropped alert was recorded as sent")
+	}
+
+	if len(a.sent) != 1 {
+		t.Fatalf("recorded %d alerts as sent, want 1", len(a.sent))
+	}
+
+	drainAlerts(a)
+
+	// the dropped alert is not deduplicated once raised again
+	a.raise(newBalanceAlert(AlertWarning))
+
+	if alerts := drainAlerts(a); len(alerts) != 1 || alerts[0].Rule != AlertBalanceLow {
+		t.Fatalf("queued %v after the queue drained, want the balance alert", alerts)
+	}
+}
+
+func TestAlerterDisabledRule(t *testing.T) {
+	a := newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+	a.rules = map[string]bool{AlertTxFailed: true}
+
+	a.raise(newBalanceAlert(AlertCritical))
+
+	if alerts := drainAlerts(a); len(alerts) != 0 {
+		t.Fatalf("queued %v of a disabled rule, want none", alerts)
+	}
+
+	var disabled *alerter
+	disabled.raise(newBalanceAlert(AlertCritical))
+}
+
+func TestNewAlerterValidation(t *testing.T) {
+	if a, err := newAlerter(hclog.NewNullLogger(), sharedTestMetrics(), &AlertingConfig{}); a != nil || err != nil {
+		t.Errorf("newAlerter() without notifiers = %v, %v, want alerting disabled", a, err)
+	}
+
+	for name, config := range map[string]*AlertingConfig{
+		"unknown rule": {
+			Rules:     []string{"tx_mined"},
+			Notifiers: []*NotifierConfig{{Type: WebhookNotifier, URL: "https://alerts.example.com"}},
+		},
+		"unknown notifier rule": {
+			Notifiers: []*NotifierConfig{{Type: WebhookNotifier, URL: "https://alerts.example.com", Rules: []string{"tx_mined"}}},
+		},
+		"invalid notifier": {
+			Notifiers: []*NotifierConfig{{Type: PagerDutyNotifier}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newAlerter(hclog.NewNullLogger(), sharedTestMetrics(), config); err == nil {
+				t.Error("newAlerter() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestDisagreementAlertNotifierRules(t *testing.T) {
+	disagreementServer, disagreementRequests := newNotifierServer(t, http.StatusOK)
+	allServer, allRequests := newNotifierServer(t, http.StatusOK)
+
+	a, err := newAlerter(hclog.NewNullLogger(), sharedTestMetrics(), &AlertingConfig{
+		Notifiers: []*NotifierConfig{
+			{Type: WebhookNotifier, URL: disagreementServer.URL, Rules: []string{AlertOutcomeDisagreement}},
+			{Type: WebhookNotifier, URL: allServer.URL},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newAlerter() error = %v", err)
+	}
+
+	d := newTestReporterService(t, nil, nil)
+	d.alerter = a
+
+	a.raise(&Alert{Rule: AlertTxFailed, Severity: AlertCritical, Summary: "tx failed", DedupKey: "tx_failed/market"})
+
+	d.recordVerifiedOutcome(testMarketHash, 1)
+	d.recordProposedOutcome(testMarketHash, 2)
+
+	// alerts are sent in order, so the first alert the restricted notifier receives is the disagreement
+	var alert Alert
+	if err := json.Unmarshal(nextNotifierRequest(t, disagreementRequests).body, &alert); err != nil {
+		t.Fatalf("invalid webhook payload, %v", err)
+	}
+
+	if alert.Rule != AlertOutcomeDisagreement || alert.Details["marketHash"] != testMarketHash ||
+		alert.Details["kind"] != DisagreementProposed ||
+		alert.Details["proposedOutcome"] != "2" || alert.Details["verifiedOutcome"] != "1" {
+		t.Errorf("disagreement alert = %+v, want the proposed outcome disagreement", alert)
+	}
+
+	for _, rule := range []string{AlertTxFailed, AlertOutcomeDisagreement} {
+		if err := json.Unmarshal(nextNotifierRequest(t, allRequests).body, &alert); err != nil || alert.Rule != rule {
+			t.Errorf("unrestricted notifier received %s, %v, want %s", alert.Rule, err, rule)
+		}
+	}
+}
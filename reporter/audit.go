@@ -0,0 +1,251 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	// File name of the market audit trail within the data directory.
+	auditLogFile = "audit.log"
+	// Maximum length of the verify API response bodies recorded in the audit trail.
+	maxAuditResponseLength = 4096
+	// Maximum length of a line read from the audit trail.
+	maxAuditLineLength = 1024 * 1024
+)
+
+// Constants representing the events recorded in the audit trail of a market.
+const (
+	// AuditMQMessage pertains to an outcome to propose received from the message queue
+	AuditMQMessage = "mqMessage"
+	// AuditVerifyRequest pertains to a request to the verify outcome API
+	AuditVerifyRequest = "verifyRequest"
+	// AuditVerifyResponse pertains to a response, or failure, of the verify outcome API
+	AuditVerifyResponse = "verifyResponse"
+	// AuditDecision pertains to the outcome chosen for a reporting tx
+	AuditDecision = "decision"
+	// AuditSkipped pertains to a reporting tx that was not sent, along with the reason
+	AuditSkipped = "skipped"
+	// AuditTxSent pertains to a reporting tx sent to the node
+	AuditTxSent = "txSent"
+	// AuditTxReceipt pertains to the receipt of a mined reporting tx
+	AuditTxReceipt = "txReceipt"
+	// AuditTxFailed pertains to a reporting tx that could not be sent or mined
+	AuditTxFailed = "txFailed"
+	// AuditDisagreement pertains to outcomes of the market disagreeing with each other
+	AuditDisagreement = "disagreement"
+	// AuditVoteDelayed pertains to a vote delayed to retry verifying the market, or until a key may vote, along with the retry time
+	AuditVoteDelayed = "voteDelayed"
+	// AuditAbstained pertains to a vote abstained from because the market could not be verified
+	AuditAbstained = "abstained"
+	// AuditEmergencyReport pertains to an emergency report requested by an operator, along with the reason and requester
+	AuditEmergencyReport = "emergencyReport"
+)
+
+var errAuditNoMarketHash = errors.New("audit trail query requires a market hash")
+
+// Entry of the audit trail, recording a single event in the handling of a market.
+type AuditEntry struct {
+	Time       time.Time       `json:"time"`
+	MarketHash string          `json:"marketHash"`
+	Event      string          `json:"event"`
+	Function   string          `json:"function,omitempty"`
+	Key        string          `json:"key,omitempty"`
+	Outcome    *int32          `json:"outcome,omitempty"`
+	Message    json.RawMessage `json:"message,omitempty"`    // Body of the MQ message.
+	Request    string          `json:"request,omitempty"`    // Verify API request URL, without credentials or query.
+	StatusCode int             `json:"statusCode,omitempty"` // Verify API response status code.
+	Response   string          `json:"response,omitempty"`   // Verify API response body, truncated.
+	TxHash     string          `json:"txHash,omitempty"`
+	Nonce      *uint64         `json:"nonce,omitempty"`
+	GasUsed    uint64          `json:"gasUsed,omitempty"`
+	Status     *uint64         `json:"status,omitempty"` // Receipt status, 1 on success.
+	Block      uint64          `json:"block,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	Requester  string          `json:"requester,omitempty"` // Origin of an emergency report request.
+	Error      string          `json:"error,omitempty"`
+	// Outcomes of the market that disagree with each other.
+	Disagreement *Disagreement `json:"disagreement,omitempty"`
+}
+
+// Append-only JSON lines log of the events in the handling of each market.
+type auditLog struct {
+	path string // Path of the log file, entries are only logged if empty.
+	sync.Mutex
+}
+
+// Creates the audit trail within the given data directory.
+func newAuditLog(dataDir string) *auditLog {
+	if dataDir == "" {
+		return &auditLog{}
+	}
+
+	return &auditLog{path: filepath.Join(dataDir, auditLogFile)}
+}
+
+// Appends an entry to the audit trail file.
+func (a *auditLog) append(entry *AuditEntry) error {
+	if a.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log (%s), %w", a.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write audit log (%s), %w", a.path, err)
+	}
+
+	return file.Sync()
+}
+
+// Calls fn with each entry of the audit trail recorded for the market, or with every entry if marketHash is empty.
+// Lines that cannot be parsed, such as a line being written, are skipped.
+func (a *auditLog) scan(marketHash string, fn func(entry *AuditEntry, line []byte) error) error {
+	if a.path == "" {
+		return nil
+	}
+
+	file, err := os.Open(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to open audit log (%s), %w", a.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxAuditLineLength)
+
+	for scanner.Scan() {
+		entry := &AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			continue
+		}
+
+		if marketHash != "" && !strings.EqualFold(entry.MarketHash, marketHash) {
+			continue
+		}
+
+		if err := fn(entry, scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Records an event in the audit trail of its market, logging failures to write it.
+// Events unrelated to a market, such as reward withdrawals, are not recorded.
+func (d *ReporterService) audit(entry *AuditEntry) {
+	if entry.MarketHash == "" {
+		return
+	}
+
+	entry.Time = time.Now()
+
+	if err := d.auditLog.append(entry); err != nil {
+		d.logger.Error("failed to record audit trail entry", "marketHash", entry.MarketHash, "event", entry.Event, "err", err)
+	}
+}
+
+// Records the receipt of a mined reporting tx in the audit trail.
+func (d *ReporterService) auditReceipt(reportingTx *ReportingTx, nonce uint64, receipt *ethgo.Receipt) {
+	d.audit(&AuditEntry{
+		MarketHash: reportingTx.report.GetMarketHash(),
+		Event:      AuditTxReceipt,
+		Function:   reportingTx.functionType,
+		Key:        reportingTx.key.name,
+		TxHash:     receipt.TransactionHash.String(),
+		Nonce:      &nonce,
+		GasUsed:    receipt.GasUsed,
+		Status:     &receipt.Status,
+		Block:      receipt.BlockNumber,
+	})
+}
+
+// Records a reporting tx that could not be sent or mined in the audit trail.
+func (d *ReporterService) auditTxFailure(reportingTx *ReportingTx, err error) {
+	d.audit(&AuditEntry{
+		MarketHash: reportingTx.report.GetMarketHash(),
+		Event:      AuditTxFailed,
+		Function:   reportingTx.functionType,
+		Key:        reportingTx.key.name,
+		Error:      err.Error(),
+	})
+}
+
+// AuditTrail returns the audit trail entries recorded for the market, oldest first.
+func (d *ReporterService) AuditTrail(marketHash string) ([]*AuditEntry, error) {
+	if marketHash == "" {
+		return nil, errAuditNoMarketHash
+	}
+
+	entries := make([]*AuditEntry, 0)
+
+	err := d.auditLog.scan(marketHash, func(entry *AuditEntry, _ []byte) error {
+		entries = append(entries, entry)
+
+		return nil
+	})
+
+	return entries, err
+}
+
+// ExportAuditTrail writes the audit trail entries recorded for the market as JSON lines,
+// or every entry if marketHash is empty.
+func (d *ReporterService) ExportAuditTrail(w io.Writer, marketHash string) error {
+	return d.auditLog.scan(marketHash, func(_ *AuditEntry, line []byte) error {
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+
+		_, err := w.Write([]byte{'\n'})
+
+		return err
+	})
+}
+
+// Returns the URL recorded in the audit trail, without the user info and query that may hold credentials.
+func auditURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	parsed.User = nil
+	parsed.RawQuery = ""
+
+	return parsed.String()
+}
+
+// Returns the response body recorded in the audit trail, truncated to the maximum length.
+func auditResponse(body []byte) string {
+	if len(body) > maxAuditResponseLength {
+		return string(body[:maxAuditResponseLength]) + "..."
+	}
+
+	return string(body)
+}
@@ -0,0 +1,253 @@
+package reporter
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	// Default interval between scheduled balance checks.
+	defaultBalanceCheckInterval = time.Minute
+)
+
+// Constants representing the level of a reporter key's balance relative to the configured thresholds.
+const (
+	// BalanceUnknown pertains to a key whose balance has not been retrieved yet
+	BalanceUnknown = "unknown"
+	// BalanceOK pertains to a balance at or above the warning threshold
+	BalanceOK = "ok"
+	// BalanceWarning pertains to a balance below the warning threshold
+	BalanceWarning = "warning"
+	// BalanceCritical pertains to a balance below the critical threshold, pausing the low funds functions
+	BalanceCritical = "critical"
+)
+
+// LowFundsPausableFunctions lists the functions low funds protection can pause. Emergency reports are never paused.
+var LowFundsPausableFunctions = []string{ProposeOutcome, VoteOutcome, ReportOutcome, WithdrawRewards, TransferRewards}
+
+// Functions paused by default while a key's balance is critical, keeping votes and reports flowing.
+var defaultLowFundsPausedFunctions = []string{ProposeOutcome, WithdrawRewards, TransferRewards}
+
+// Tracks the native balance of a reporter key's account.
+type keyBalance struct {
+	amount    *big.Int        // Last retrieved balance in wei, nil if unknown.
+	level     string          // Level of the balance relative to the thresholds.
+	lastCheck time.Time       // Time of the last successful balance check.
+	paused    map[string]bool // Functions paused while the balance is critical.
+	sync.RWMutex
+}
+
+// Describes the balance of a single reporter key.
+type BalanceStatus struct {
+	Balance   *big.Int  `json:"balance"`   // Last retrieved balance in wei, null if unknown.
+	Level     string    `json:"level"`     // Level of the balance relative to the thresholds.
+	LastCheck time.Time `json:"lastCheck"` // Time of the last successful balance check.
+}
+
+// Returns the set of functions paused while a key's balance is critical, validating the configured functions.
+// The default functions are used if none are configured. Emergency reports are never paused.
+func lowFundsPausedFunctions(functions []string) (map[string]bool, error) {
+	if functions == nil {
+		functions = defaultLowFundsPausedFunctions
+	}
+
+	paused := make(map[string]bool, len(functions))
+
+	for _, functionType := range functions {
+		if !slices.Contains(LowFundsPausableFunctions, functionType) {
+			return nil, fmt.Errorf("reporter low funds protection cannot pause unsupported function '%s'", functionType)
+		}
+
+		paused[functionType] = true
+	}
+
+	return paused, nil
+}
+
+// Checks that the warning threshold is not below the critical threshold.
+func validateBalanceThresholds(warning, critical *big.Int) error {
+	if warning != nil && critical != nil && warning.Cmp(critical) < 0 {
+		return fmt.Errorf("reporter balance warning threshold %s is below the critical threshold %s", warning, critical)
+	}
+
+	return nil
+}
+
+// Returns whether the function is paused by low funds protection, along with the reason if so.
+func (b *keyBalance) isPaused(functionType string) (bool, string) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.level != BalanceCritical || !b.paused[functionType] {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("balance of %s wei is below the critical threshold, low funds protection is active", b.amount)
+}
+
+// Returns a snapshot of the key's balance.
+func (b *keyBalance) status() *BalanceStatus {
+	b.RLock()
+	defer b.RUnlock()
+
+	status := &BalanceStatus{
+		Level:     b.level,
+		LastCheck: b.lastCheck,
+	}
+
+	if b.amount != nil {
+		status.Balance = new(big.Int).Set(b.amount)
+	}
+
+	return status
+}
+
+// Returns the level of the balance relative to the configured thresholds.
+func (d *ReporterService) balanceLevel(balance *big.Int) string {
+	config := d.config.Load()
+
+	switch {
+	case config.BalanceCriticalThreshold != nil && balance.Cmp(config.BalanceCriticalThreshold) < 0:
+		return BalanceCritical
+	case config.BalanceWarningThreshold != nil && balance.Cmp(config.BalanceWarningThreshold) < 0:
+		return BalanceWarning
+	default:
+		return BalanceOK
+	}
+}
+
+// Retrieves the balance of each reporter key and updates its level, logging when it changes and updating metrics.
+// The balance alert is raised, or resolved, on every check, so that an alert dropped by the alerter is sent again
+// while the balance stays low, repeats being suppressed by its dedup window.
+// Keys whose balance cannot be retrieved keep their previous level.
+func (d *ReporterService) checkBalances() {
+	for _, key := range d.keys {
+		balance, err := d.txService.client.Eth().GetBalance(key.address(), ethgo.Latest)
+		if err != nil {
+			d.logger.Error("failed to check balance", "key", key.name, "address", key.address(), "err", err)
+
+			continue
+		}
+
+		level := d.balanceLevel(balance)
+
+		key.balance.Lock()
+		previous := key.balance.level
+		key.balance.amount = balance
+		key.balance.level = level
+		key.balance.lastCheck = time.Now()
+		key.balance.Unlock()
+
+		amount, _ := new(big.Float).SetInt(balance).Float64()
+
+		d.metrics.Balance.WithLabelValues(key.name).Set(amount)
+		d.metrics.BalanceLevel.WithLabelValues(key.name).Set(float64(balanceLevelValue(level)))
+
+		d.alertBalanceLevel(key, balance, level)
+
+		if level == previous {
+			continue
+		}
+
+		switch level {
+		case BalanceCritical:
+			d.logger.Error(
+				"reporter key balance is below the critical threshold, pausing low funds functions",
+				"key", key.name,
+				"address", key.address(),
+				"balance", balance,
+				"threshold", d.config.Load().BalanceCriticalThreshold,
+			)
+		case BalanceWarning:
+			d.logger.Warn(
+				"reporter key balance is below the warning threshold",
+				"key", key.name,
+				"address", key.address(),
+				"balance", balance,
+				"threshold", d.config.Load().BalanceWarningThreshold,
+			)
+		default:
+			if previous != BalanceUnknown {
+				d.logger.Info("reporter key balance is above the thresholds", "key", key.name, "balance", balance)
+			}
+		}
+	}
+}
+
+// Raises a balance alert for the key's balance level, or resolves it once the balance is above the thresholds.
+func (d *ReporterService) alertBalanceLevel(key *reporterKey, balance *big.Int, level string) {
+	dedupKey := fmt.Sprintf("%s/%s", AlertBalanceLow, key.name)
+
+	if level == BalanceOK {
+		d.alerter.resolve(AlertBalanceLow, dedupKey, fmt.Sprintf("balance of key %s is above the thresholds", key.name))
+
+		return
+	}
+
+	config := d.config.Load()
+
+	severity, threshold := AlertWarning, config.BalanceWarningThreshold
+	if level == BalanceCritical {
+		severity, threshold = AlertCritical, config.BalanceCriticalThreshold
+	}
+
+	d.alerter.raise(&Alert{
+		Rule:     AlertBalanceLow,
+		Severity: severity,
+		Summary:  fmt.Sprintf("balance of key %s is below the %s threshold", key.name, level),
+		DedupKey: dedupKey,
+		Details: map[string]string{
+			"key":       key.name,
+			"address":   key.address().String(),
+			"balance":   balance.String(),
+			"threshold": threshold.String(),
+		},
+	})
+}
+
+// Returns the metric value of a balance level, 0 for ok, 1 for warning, 2 for critical and -1 if unknown.
+func balanceLevelValue(level string) int {
+	switch level {
+	case BalanceOK:
+		return 0
+	case BalanceWarning:
+		return 1
+	case BalanceCritical:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// Returns the most severe balance level among the reporter keys.
+func (d *ReporterService) worstBalanceLevel() string {
+	worst := BalanceUnknown
+
+	for _, key := range d.keys {
+		if level := key.balance.status().Level; balanceLevelValue(level) > balanceLevelValue(worst) {
+			worst = level
+		}
+	}
+
+	return worst
+}
+
+// Periodically re-checks the balances using the configured check interval.
+func (d *ReporterService) startBalanceCheckLoop() {
+	interval := d.config.Load().BalanceCheckInterval
+	if interval <= 0 {
+		interval = defaultBalanceCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		d.checkBalances()
+	}
+}
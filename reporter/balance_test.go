@@ -0,0 +1,175 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestLowFundsPausedFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		functions []string
+		want      []string
+		wantErr   string
+	}{
+		{"defaults", nil, defaultLowFundsPausedFunctions, ""},
+		{"configured", []string{VoteOutcome, ReportOutcome}, []string{VoteOutcome, ReportOutcome}, ""},
+		{"none", []string{}, nil, ""},
+		{
+			"emergency reports",
+			[]string{EmergencyReportOutcome},
+			nil,
+			"reporter low funds protection cannot pause unsupported function 'emergencyReportOutcome'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paused, err := lowFundsPausedFunctions(tt.functions)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("lowFundsPausedFunctions() error = %v, want %s", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil || len(paused) != len(tt.want) {
+				t.Fatalf("lowFundsPausedFunctions() = %v, %v, want %v", paused, err, tt.want)
+			}
+
+			for _, functionType := range tt.want {
+				if !paused[functionType] {
+					t.Errorf("lowFundsPausedFunctions() = %v, want %s paused", paused, functionType)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBalanceThresholds(t *testing.T) {
+	if err := validateBalanceThresholds(big.NewInt(100), big.NewInt(50)); err != nil {
+		t.Errorf("validateBalanceThresholds() error = %v", err)
+	}
+
+	if err := validateBalanceThresholds(nil, big.NewInt(50)); err != nil {
+		t.Errorf("validateBalanceThresholds() error = %v without a warning threshold", err)
+	}
+
+	if err := validateBalanceThresholds(big.NewInt(50), big.NewInt(100)); err == nil {
+		t.Error("validateBalanceThresholds() error = nil for a warning threshold below the critical one")
+	}
+}
+
+func TestCheckBalances(t *testing.T) {
+	d := newTestReporterService(t, &ReporterConfig{
+		BalanceWarningThreshold:  big.NewInt(100),
+		BalanceCriticalThreshold: big.NewInt(50),
+	}, nil)
+	d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+	key := d.keys[0]
+
+	paused, err := lowFundsPausedFunctions([]string{ProposeOutcome, WithdrawRewards})
+	if err != nil {
+		t.Fatalf("lowFundsPausedFunctions() error = %v", err)
+	}
+
+	key.balance.paused = paused
+
+	var balance *big.Int
+
+	chain := newFakeChain(t, d)
+	chain.handle("eth_getBalance", func([]json.RawMessage) (interface{}, error) {
+		if balance == nil {
+			return nil, errors.New("connection refused")
+		}
+
+		return fmt.Sprintf("0x%x", balance), nil
+	})
+
+	tests := []struct {
+		name      string
+		balance   *big.Int
+		wantLevel string
+		wantAlert string // Severity of the alert raised, "resolved" if resolved and empty if none.
+	}{
+		{"above the thresholds", big.NewInt(200), BalanceOK, ""},
+		{"below the warning threshold", big.NewInt(80), BalanceWarning, AlertWarning},
+		{"below the critical threshold", big.NewInt(30), BalanceCritical, AlertCritical},
+		{"unchanged level", big.NewInt(20), BalanceCritical, ""},
+		{"failed check keeps the level", nil, BalanceCritical, ""},
+		{"back above the thresholds", big.NewInt(100), BalanceOK, "resolved"},
+	}
+
+	for _, tt := range tests {
+		balance = tt.balance
+		d.checkBalances()
+
+		if level := key.balance.status().Level; level != tt.wantLevel {
+			t.Fatalf("%s: level = %s, want %s", tt.name, level, tt.wantLevel)
+		}
+
+		if worst := d.worstBalanceLevel(); worst != tt.wantLevel {
+			t.Errorf("%s: worst level = %s, want %s", tt.name, worst, tt.wantLevel)
+		}
+
+		alerts := drainAlerts(d.alerter)
+
+		switch {
+		case tt.wantAlert == "" && len(alerts) != 0:
+			t.Errorf("%s: queued %v, want no alert", tt.name, alerts)
+		case tt.wantAlert == "resolved" && (len(alerts) != 1 || !alerts[0].Resolved):
+			t.Errorf("%s: queued %v, want the balance alert resolved", tt.name, alerts)
+		case tt.wantAlert != "" && tt.wantAlert != "resolved" &&
+			(len(alerts) != 1 || alerts[0].Rule != AlertBalanceLow || string(alerts[0].Severity) != tt.wantAlert):
+			t.Errorf("%s: queued %v, want a %s balance alert", tt.name, alerts, tt.wantAlert)
+		}
+
+		// only the configured low funds functions are paused, and only while the balance is critical
+		critical := tt.wantLevel == BalanceCritical
+
+		for _, functionType := range []string{ProposeOutcome, VoteOutcome, ReportOutcome, EmergencyReportOutcome, WithdrawRewards} {
+			want := critical && paused[functionType]
+
+			if isPaused, _ := key.balance.isPaused(functionType); isPaused != want {
+				t.Errorf("%s: %s paused = %t, want %t", tt.name, functionType, isPaused, want)
+			}
+
+			if allowed, _ := key.isActionAllowed(functionType); allowed == want {
+				t.Errorf("%s: %s allowed = %t, want %t", tt.name, functionType, allowed, !want)
+			}
+		}
+	}
+}
+
+func TestCheckBalancesResendsDroppedAlert(t *testing.T) {
+	d := newTestReporterService(t, &ReporterConfig{
+		BalanceWarningThreshold:  big.NewInt(100),
+		BalanceCriticalThreshold: big.NewInt(50),
+	}, nil)
+
+	// the alert queue is full, dropping the first balance alert
+	d.alerter = newQueuedAlerter(&AlertingConfig{}, 0)
+
+	chain := newFakeChain(t, d)
+	chain.handle("eth_getBalance", func([]json.RawMessage) (interface{}, error) {
+		return "0x50", nil
+	})
+
+	d.checkBalances()
+
+	d.alerter.queue = make(chan *Alert, alertQueueSize)
+
+	// the balance is still low at the next check, so the dropped alert is sent, and only once
+	d.checkBalances()
+	d.checkBalances()
+
+	alerts := drainAlerts(d.alerter)
+	if len(alerts) != 1 || alerts[0].Rule != AlertBalanceLow || alerts[0].Severity != AlertWarning {
+		t.Fatalf("queued %v, want a single warning balance alert", alerts)
+	}
+}
@@ -8,23 +8,33 @@ import (
 	"github.com/umbracle/ethgo/contract"
 )
 
+// View functions called on the OutcomeReporter contract, as declared in its ABI in contracts/abis.
 var functions = []string{
 	"function _votingPeriod() view returns (uint256)",
+	"function hasRole(bytes32 role, address account) view returns (bool)",
+	"function getRoleAdmin(bytes32 role) view returns (bytes32)",
+	"function OUTCOME_EMERGENCY_REPORTER_ROLE() view returns (bytes32)",
+	"function getReportTime(bytes32 marketHash) view returns (uint256)",
 }
 
 const (
 	VotingPeriod string = "_votingPeriod"
+	HasRole      string = "hasRole"
+	GetRoleAdmin string = "getRoleAdmin"
+
+	EmergencyReporterRole string = "OUTCOME_EMERGENCY_REPORTER_ROLE"
+	GetReportTime         string = "getReportTime"
 )
 
 func (d *ReporterService) sendCall(
 	functionType string,
+	functionArgs ...interface{},
 ) interface{} {
 	var functionName string
-	var functionArgs []interface{}
 
 	switch functionType {
-	case VotingPeriod:
-		functionName = VotingPeriod
+	case VotingPeriod, HasRole, GetRoleAdmin, EmergencyReporterRole, GetReportTime:
+		functionName = functionType
 	}
 
 	abiContract, err := ethgoabi.NewABIFromList(functions)
@@ -40,12 +50,12 @@ func (d *ReporterService) sendCall(
 	}
 
 	c := contract.NewContract(
-		ethgo.Address(ethgo.HexToAddress(d.config.OutcomeReporterAddress)),
+		ethgo.Address(ethgo.HexToAddress(d.config.Load().OutcomeReporterAddress)),
 		abiContract,
 		contract.WithJsonRPC(d.txService.client.Eth()),
 	)
 
-	res, err := c.Call(functionName, ethgo.Latest)
+	res, err := c.Call(functionName, ethgo.Latest, functionArgs...)
 	if err != nil {
 		d.txService.logger.Error(
 			"failed to call via ethgo",
@@ -58,8 +68,8 @@ func (d *ReporterService) sendCall(
 	}
 
 	switch functionType {
-	case VotingPeriod:
-		votingPeriod, ok := res["0"].(*big.Int)
+	case VotingPeriod, GetReportTime:
+		value, ok := res["0"].(*big.Int)
 		if !ok {
 			d.txService.logger.Error(
 				"failed to convert result to big int",
@@ -70,7 +80,31 @@ func (d *ReporterService) sendCall(
 			)
 			return nil
 		}
-		return votingPeriod
+		return value
+	case HasRole:
+		result, ok := res["0"].(bool)
+		if !ok {
+			d.txService.logger.Error(
+				"failed to convert result to bool",
+				"function", functionName,
+				"functionArgs", functionArgs,
+				"functionSig", abiContract,
+			)
+			return nil
+		}
+		return result
+	case GetRoleAdmin, EmergencyReporterRole:
+		role, ok := res["0"].([32]byte)
+		if !ok {
+			d.txService.logger.Error(
+				"failed to convert result to bytes32",
+				"function", functionName,
+				"functionArgs", functionArgs,
+				"functionSig", abiContract,
+			)
+			return nil
+		}
+		return role
 	}
 
 	return nil
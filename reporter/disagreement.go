@@ -0,0 +1,218 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Time after which the outcomes of a market that was never reported are no longer tracked.
+const marketOutcomesRetention = 7 * 24 * time.Hour
+
+// Constants representing the kinds of outcome disagreements.
+const (
+	// DisagreementProposed pertains to a verified outcome differing from the proposed outcome
+	DisagreementProposed = "proposed"
+	// DisagreementReported pertains to a voted, or else verified, outcome differing from the reported outcome
+	DisagreementReported = "reported"
+)
+
+// Describes a disagreement between the outcomes of a market, alerted on
+// and recorded in the audit trail. Outcomes that are not known yet are omitted.
+type Disagreement struct {
+	Time            time.Time `json:"time"`
+	MarketHash      string    `json:"marketHash"`
+	Kind            string    `json:"kind"`
+	ProposedOutcome *int32    `json:"proposedOutcome,omitempty"` // Outcome of the ProposeOutcome event.
+	VerifiedOutcome *int32    `json:"verifiedOutcome,omitempty"` // Outcome answered by the verify outcome API.
+	VotedOutcome    *int32    `json:"votedOutcome,omitempty"`    // Outcome of our mined vote tx.
+	ReportedOutcome *int32    `json:"reportedOutcome,omitempty"` // Outcome of the OutcomeReported event.
+}
+
+// Outcomes of a market known to the node, compared once the market is reported.
+type marketOutcomes struct {
+	proposed *int32    // Outcome of the ProposeOutcome event.
+	verified *int32    // Outcome answered by the verify outcome API.
+	voted    *int32    // Outcome of our mined vote tx.
+	updated  time.Time // Time the outcomes were last updated.
+}
+
+// Tracks the outcomes of the markets being voted on, until they are reported.
+type outcomeTracker struct {
+	markets map[string]*marketOutcomes // Outcomes by lower-cased market hash.
+	sync.Mutex
+}
+
+func newOutcomeTracker() *outcomeTracker {
+	return &outcomeTracker{markets: make(map[string]*marketOutcomes)}
+}
+
+// Updates the outcomes of the market with fn and returns a copy of them.
+// Markets not updated within the retention period are dropped.
+func (t *outcomeTracker) update(marketHash string, fn func(outcomes *marketOutcomes)) marketOutcomes {
+	t.Lock()
+	defer t.Unlock()
+
+	now := time.Now()
+
+	for hash, outcomes := range t.markets {
+		if now.Sub(outcomes.updated) > marketOutcomesRetention {
+			delete(t.markets, hash)
+		}
+	}
+
+	hash := strings.ToLower(marketHash)
+
+	outcomes, ok := t.markets[hash]
+	if !ok {
+		outcomes = &marketOutcomes{}
+		t.markets[hash] = outcomes
+	}
+
+	fn(outcomes)
+	outcomes.updated = now
+
+	return *outcomes
+}
+
+// Removes the market and returns its outcomes, if tracked.
+func (t *outcomeTracker) remove(marketHash string) (marketOutcomes, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	hash := strings.ToLower(marketHash)
+
+	outcomes, ok := t.markets[hash]
+	if !ok {
+		return marketOutcomes{}, false
+	}
+
+	delete(t.markets, hash)
+
+	return *outcomes, true
+}
+
+// Records the outcome proposed for the market, comparing it with the verified outcome if already known.
+func (d *ReporterService) recordProposedOutcome(marketHash string, outcome int32) {
+	outcomes := d.outcomes.update(marketHash, func(outcomes *marketOutcomes) {
+		outcomes.proposed = &outcome
+	})
+
+	if outcomes.verified != nil && *outcomes.verified != outcome {
+		d.reportDisagreement(&Disagreement{
+			MarketHash:      marketHash,
+			Kind:            DisagreementProposed,
+			ProposedOutcome: outcomes.proposed,
+			VerifiedOutcome: outcomes.verified,
+		})
+	}
+}
+
+// Records the outcome answered by the verify outcome API, comparing it with the proposed outcome if known.
+func (d *ReporterService) recordVerifiedOutcome(marketHash string, outcome int32) {
+	outcomes := d.outcomes.update(marketHash, func(outcomes *marketOutcomes) {
+		outcomes.verified = &outcome
+	})
+
+	if outcomes.proposed != nil && *outcomes.proposed != outcome {
+		d.reportDisagreement(&Disagreement{
+			MarketHash:      marketHash,
+			Kind:            DisagreementProposed,
+			ProposedOutcome: outcomes.proposed,
+			VerifiedOutcome: outcomes.verified,
+		})
+	}
+}
+
+// Records the outcome of our mined vote tx.
+func (d *ReporterService) recordVotedOutcome(marketHash string, outcome int32) {
+	d.outcomes.update(marketHash, func(outcomes *marketOutcomes) {
+		outcomes.voted = &outcome
+	})
+}
+
+// Compares the outcome reported for the market with our vote, or with the verified outcome if no vote was mined,
+// and stops tracking the market. Markets the node did not verify, or whose outcomes were lost on restart, are skipped.
+func (d *ReporterService) checkReportedOutcome(marketHash string, outcome int32) {
+	outcomes, ok := d.outcomes.remove(marketHash)
+	if !ok {
+		return
+	}
+
+	ours := outcomes.voted
+	if ours == nil {
+		ours = outcomes.verified
+	}
+
+	if ours == nil || *ours == outcome {
+		return
+	}
+
+	d.reportDisagreement(&Disagreement{
+		MarketHash:      marketHash,
+		Kind:            DisagreementReported,
+		ProposedOutcome: outcomes.proposed,
+		VerifiedOutcome: outcomes.verified,
+		VotedOutcome:    outcomes.voted,
+		ReportedOutcome: &outcome,
+	})
+}
+
+// Logs the disagreement, records it in the audit trail and metrics, and alerts on it.
+func (d *ReporterService) reportDisagreement(disagreement *Disagreement) {
+	disagreement.Time = time.Now()
+
+	d.logger.Warn(
+		"outcome disagreement detected",
+		"marketHash", disagreement.MarketHash,
+		"kind", disagreement.Kind,
+		"proposedOutcome", outcomeField(disagreement.ProposedOutcome),
+		"verifiedOutcome", outcomeField(disagreement.VerifiedOutcome),
+		"votedOutcome", outcomeField(disagreement.VotedOutcome),
+		"reportedOutcome", outcomeField(disagreement.ReportedOutcome),
+	)
+
+	d.metrics.Disagreements.WithLabelValues(disagreement.Kind).Inc()
+	d.audit(&AuditEntry{
+		MarketHash:   disagreement.MarketHash,
+		Event:        AuditDisagreement,
+		Reason:       disagreement.Kind,
+		Disagreement: disagreement,
+	})
+
+	d.alerter.raise(&Alert{
+		Rule:     AlertOutcomeDisagreement,
+		Severity: AlertWarning,
+		Summary:  fmt.Sprintf("%s outcome disagreement on market %s", disagreement.Kind, disagreement.MarketHash),
+		DedupKey: fmt.Sprintf("%s/%s/%s", AlertOutcomeDisagreement, disagreement.Kind, disagreement.MarketHash),
+		Details:  disagreement.details(),
+	})
+}
+
+// Returns the known outcomes of the disagreement as alert details.
+func (d *Disagreement) details() map[string]string {
+	details := map[string]string{"marketHash": d.MarketHash, "kind": d.Kind}
+
+	for name, outcome := range map[string]*int32{
+		"proposedOutcome": d.ProposedOutcome,
+		"verifiedOutcome": d.VerifiedOutcome,
+		"votedOutcome":    d.VotedOutcome,
+		"reportedOutcome": d.ReportedOutcome,
+	} {
+		if outcome != nil {
+			details[name] = fmt.Sprint(*outcome)
+		}
+	}
+
+	return details
+}
+
+// Returns the outcome as a log field, nil if unknown.
+func outcomeField(outcome *int32) interface{} {
+	if outcome == nil {
+		return nil
+	}
+
+	return *outcome
+}
@@ -0,0 +1,176 @@
+package reporter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutcomeDisagreements(t *testing.T) {
+	tests := []struct {
+		name   string
+		record func(d *ReporterService)
+		// details of the disagreement alerts raised, without the market hash
+		disagreements []map[string]string
+	}{
+		{
+			"verified agrees with proposed",
+			func(d *ReporterService) {
+				d.recordProposedOutcome(testMarketA, 1)
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.recordVotedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 1)
+			},
+			nil,
+		},
+		{
+			"verified differs from proposed",
+			func(d *ReporterService) {
+				d.recordProposedOutcome(testMarketA, 2)
+				d.recordVerifiedOutcome(testMarketA, 1)
+			},
+			[]map[string]string{
+				{"kind": DisagreementProposed, "proposedOutcome": "2", "verifiedOutcome": "1"},
+			},
+		},
+		{
+			"proposed after verified",
+			func(d *ReporterService) {
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.recordProposedOutcome(strings.ToUpper(testMarketA), 0)
+			},
+			[]map[string]string{
+				{"kind": DisagreementProposed, "proposedOutcome": "0", "verifiedOutcome": "1"},
+			},
+		},
+		{
+			"vote differs from reported",
+			func(d *ReporterService) {
+				d.recordProposedOutcome(testMarketA, 1)
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.recordVotedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			[]map[string]string{
+				{"kind": DisagreementReported, "proposedOutcome": "1", "verifiedOutcome": "1", "votedOutcome": "1", "reportedOutcome": "2"},
+			},
+		},
+		{
+			"vote agrees with reported over verified",
+			func(d *ReporterService) {
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.recordVotedOutcome(testMarketA, 2)
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			nil,
+		},
+		{
+			"verified differs from reported without a vote",
+			func(d *ReporterService) {
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 0)
+			},
+			[]map[string]string{
+				{"kind": DisagreementReported, "verifiedOutcome": "1", "reportedOutcome": "0"},
+			},
+		},
+		{
+			"reported without verifying",
+			func(d *ReporterService) {
+				d.recordProposedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			nil,
+		},
+		{
+			"untracked market reported",
+			func(d *ReporterService) {
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			nil,
+		},
+		{
+			"reported market no longer tracked",
+			func(d *ReporterService) {
+				d.recordVerifiedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 1)
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			nil,
+		},
+		{
+			"markets tracked separately",
+			func(d *ReporterService) {
+				d.recordProposedOutcome(testMarketA, 1)
+				d.recordVerifiedOutcome(testMarketB, 2)
+				d.checkReportedOutcome(testMarketA, 2)
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestReporterService(t, nil, nil)
+			d.auditLog = newAuditLog(t.TempDir())
+			d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+			tt.record(d)
+
+			alerts := drainAlerts(d.alerter)
+			if len(alerts) != len(tt.disagreements) {
+				t.Fatalf("raised %d alerts %+v, want %d", len(alerts), alerts, len(tt.disagreements))
+			}
+
+			entries, err := d.AuditTrail(testMarketA)
+			if err != nil {
+				t.Fatalf("AuditTrail() error = %v", err)
+			}
+
+			if len(entries) != len(tt.disagreements) {
+				t.Fatalf("audit trail = %+v, want %d disagreements", entries, len(tt.disagreements))
+			}
+
+			for i, want := range tt.disagreements {
+				details := make(map[string]string, len(alerts[i].Details))
+				for name, value := range alerts[i].Details {
+					details[name] = value
+				}
+
+				if details["marketHash"] != testMarketA && details["marketHash"] != strings.ToUpper(testMarketA) {
+					t.Errorf("alert market hash = %s, want %s", details["marketHash"], testMarketA)
+				}
+
+				delete(details, "marketHash")
+
+				if alerts[i].Rule != AlertOutcomeDisagreement || !reflect.DeepEqual(details, want) {
+					t.Errorf("alert %s details = %v, want %v", alerts[i].Rule, details, want)
+				}
+
+				if entries[i].Event != AuditDisagreement || entries[i].Reason != want["kind"] ||
+					entries[i].Disagreement == nil || entries[i].Disagreement.Kind != want["kind"] {
+					t.Errorf("audit entry = %+v, want a %s disagreement", entries[i], want["kind"])
+				}
+			}
+		})
+	}
+}
+
+func TestOutcomeTrackerRetention(t *testing.T) {
+	tracker := newOutcomeTracker()
+
+	tracker.update(testMarketA, func(outcomes *marketOutcomes) {})
+	tracker.markets[testMarketA].updated = time.Now().Add(-marketOutcomesRetention - time.Minute)
+
+	// stale markets are dropped on the next update
+	tracker.update(testMarketB, func(outcomes *marketOutcomes) {})
+
+	if _, ok := tracker.remove(testMarketA); ok {
+		t.Error("stale market still tracked")
+	}
+
+	if _, ok := tracker.remove(testMarketB); !ok {
+		t.Error("updated market not tracked")
+	}
+}
@@ -0,0 +1,389 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/helper/keccak"
+	"github.com/umbracle/ethgo"
+	ethgoabi "github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/contract"
+)
+
+const (
+	// Default interval between scheduled eligibility checks.
+	defaultEligibilityRefreshInterval = 5 * time.Minute
+	// Name of the OpenZeppelin AccessControl admin role, whose identifier is the zero hash.
+	defaultAdminRoleName = "DEFAULT_ADMIN_ROLE"
+
+	// Role name disabling the role check of an action, the default as the OutcomeReporter contract
+	// exposes no proposer, voter or reporter role.
+	UncheckedRole = "none"
+
+	// Staking eligibility of voting and reporting, as reported by the operator API health endpoint.
+	StakingChecked   = "checked"
+	StakingUnchecked = "unchecked"
+)
+
+// Describes whether a reporter key is permitted to perform a reporting action.
+type ActionStatus struct {
+	Allowed bool   `json:"allowed"`          // Whether txs for the action are sent.
+	Reason  string `json:"reason,omitempty"` // Why the action is disabled, or why its status is unknown.
+}
+
+// Tracks which reporting actions a reporter key is permitted to perform.
+// Actions are allowed until a check confirms the key lacks the required role or staking eligibility.
+type eligibility struct {
+	actions   map[string]*ActionStatus // Status by function type.
+	lastCheck time.Time                // Time of the last completed eligibility check.
+	sync.RWMutex
+}
+
+// Returns whether the key is permitted to perform the given action, along with the reason if not.
+// Actions paused by low funds protection are not permitted either.
+func (k *reporterKey) isActionAllowed(functionType string) (bool, string) {
+	k.eligibility.RLock()
+	status, ok := k.eligibility.actions[functionType]
+	k.eligibility.RUnlock()
+
+	if ok && !status.Allowed {
+		return false, status.Reason
+	}
+
+	if paused, reason := k.balance.isPaused(functionType); paused {
+		return false, reason
+	}
+
+	return true, ""
+}
+
+// Returns whether any key selected by the routing rules is permitted to perform the given action,
+// along with the reasons if not.
+func (d *ReporterService) isActionAllowed(functionType string) (bool, string) {
+	keys, reason := d.router.route(functionType, false)
+
+	return len(keys) > 0, reason
+}
+
+// Describes the eligibility of a single reporter key.
+type KeyStatus struct {
+	Address   string                  `json:"address"`   // Reporter address of the key.
+	Actions   map[string]ActionStatus `json:"actions"`   // Status by function type.
+	LastCheck time.Time               `json:"lastCheck"` // Time of the last completed eligibility check.
+	Balance   *BalanceStatus          `json:"balance"`   // Native balance of the key's account.
+}
+
+// Returns a snapshot of the per-action eligibility statuses, aggregated over the keys selected by the routing rules,
+// the statuses of each key, and the time of the oldest last check.
+func (d *ReporterService) EligibilityStatus() (map[string]ActionStatus, map[string]*KeyStatus, time.Time) {
+	var (
+		statuses  = make(map[string]ActionStatus)
+		keys      = make(map[string]*KeyStatus, len(d.keys))
+		lastCheck time.Time
+	)
+
+	for _, key := range d.keys {
+		key.eligibility.RLock()
+
+		keyStatus := &KeyStatus{
+			Address:   key.address().String(),
+			Actions:   make(map[string]ActionStatus, len(key.eligibility.actions)),
+			LastCheck: key.eligibility.lastCheck,
+			Balance:   key.balance.status(),
+		}
+
+		for functionType, status := range key.eligibility.actions {
+			keyStatus.Actions[functionType] = *status
+			statuses[functionType] = ActionStatus{}
+		}
+
+		key.eligibility.RUnlock()
+
+		if lastCheck.IsZero() || keyStatus.LastCheck.Before(lastCheck) {
+			lastCheck = keyStatus.LastCheck
+		}
+
+		keys[key.name] = keyStatus
+	}
+
+	for functionType := range statuses {
+		allowed, reason := d.isActionAllowed(functionType)
+		statuses[functionType] = ActionStatus{Allowed: allowed, Reason: reason}
+	}
+
+	return statuses, keys, lastCheck
+}
+
+// Checks which reporting actions each reporter key is permitted to perform and updates the eligibility state.
+// The emergency reporting action is only checked on demand, when an emergency report is requested.
+// Actions whose status cannot be determined because of a failed call keep their previous status.
+func (d *ReporterService) checkEligibility() {
+	for _, key := range d.keys {
+		for _, functionType := range []string{ProposeOutcome, VoteOutcome, ReportOutcome} {
+			status, err := d.checkAction(functionType, key.address())
+			if err != nil {
+				d.logger.Error("failed to check eligibility", "key", key.name, "function", functionType, "err", err)
+
+				continue
+			}
+
+			d.setActionStatus(key, functionType, status)
+		}
+
+		key.eligibility.Lock()
+		key.eligibility.lastCheck = time.Now()
+		key.eligibility.Unlock()
+	}
+}
+
+// Checks whether the given account holds the role and the staking eligibility required for an action.
+// Staking eligibility is only required for voting and reporting, and only checked if the staking contract is configured.
+func (d *ReporterService) checkAction(functionType string, account ethgo.Address) (*ActionStatus, error) {
+	var (
+		config          = d.config.Load()
+		roleName        string
+		requiresStaking bool
+	)
+
+	switch functionType {
+	case ProposeOutcome:
+		roleName = requiredRole(config.ProposeRole)
+	case VoteOutcome:
+		roleName = requiredRole(config.VoteRole)
+		requiresStaking = true
+	case ReportOutcome:
+		roleName = requiredRole(config.ReportRole)
+		requiresStaking = true
+	case EmergencyReportOutcome:
+		roleName = EmergencyReporterRole
+	}
+
+	if roleName != "" {
+		role, err := d.resolveRole(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		hasRole, ok := d.sendCall(HasRole, role, account).(bool)
+		if !ok {
+			return nil, fmt.Errorf("unable to query role %s", roleName)
+		}
+
+		if !hasRole {
+			reason := fmt.Sprintf("reporter %s is missing role %s", account, roleName)
+
+			if roleAdmin, ok := d.sendCall(GetRoleAdmin, role).([32]byte); ok {
+				reason = fmt.Sprintf("%s, which can be granted by holders of role %s", reason, hex.EncodeToHex(roleAdmin[:]))
+			}
+
+			return &ActionStatus{Allowed: false, Reason: reason}, nil
+		}
+	}
+
+	if requiresStaking && d.isStakingChecked() {
+		eligible, err := d.checkStaking(account)
+		if err != nil {
+			return nil, err
+		}
+
+		if !eligible {
+			return &ActionStatus{
+				Allowed: false,
+				Reason:  fmt.Sprintf("reporter %s is not eligible on staking contract %s", account, config.StakingAddress),
+			}, nil
+		}
+	}
+
+	return &ActionStatus{Allowed: true}, nil
+}
+
+// Returns the role required for an action from the configured role, or no role if it is empty or UncheckedRole.
+func requiredRole(configured string) string {
+	if configured == UncheckedRole {
+		return ""
+	}
+
+	return configured
+}
+
+// Returns whether the staking eligibility of voting and reporting is checked,
+// which requires both the staking contract and its eligibility function to be configured.
+func (d *ReporterService) isStakingChecked() bool {
+	config := d.config.Load()
+
+	return config.StakingAddress != "" && config.StakingEligibilityFunction != ""
+}
+
+// Returns StakingChecked if the staking eligibility of voting and reporting is checked, StakingUnchecked otherwise.
+func (d *ReporterService) StakingEligibility() string {
+	if d.isStakingChecked() {
+		return StakingChecked
+	}
+
+	return StakingUnchecked
+}
+
+// Calls the configured eligibility function of the staking contract for the given account.
+func (d *ReporterService) checkStaking(account ethgo.Address) (bool, error) {
+	config := d.config.Load()
+
+	method, err := ParseStakingEligibilityFunction(config.StakingEligibilityFunction)
+	if err != nil {
+		return false, err
+	}
+
+	abiContract, err := ethgoabi.NewABIFromList([]string{config.StakingEligibilityFunction})
+	if err != nil {
+		return false, err
+	}
+
+	c := contract.NewContract(
+		ethgo.HexToAddress(config.StakingAddress),
+		abiContract,
+		contract.WithJsonRPC(d.txService.client.Eth()),
+	)
+
+	res, err := c.Call(method.Name, ethgo.Latest, account)
+	if err != nil {
+		return false, fmt.Errorf("unable to query staking eligibility: %w", err)
+	}
+
+	for _, value := range res {
+		if eligible, ok := value.(bool); ok {
+			return eligible, nil
+		}
+	}
+
+	return false, errors.New("unable to query staking eligibility: result is not a bool")
+}
+
+// Parses the signature of a staking contract eligibility function,
+// e.g. "function isValidator(address account) view returns (bool)".
+// The function must take a single address and return a single bool.
+func ParseStakingEligibilityFunction(signature string) (*ethgoabi.Method, error) {
+	method, err := ethgoabi.NewMethod(signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid staking eligibility function %q: %w", signature, err)
+	}
+
+	inputs, outputs := method.Inputs.TupleElems(), method.Outputs.TupleElems()
+	if len(inputs) != 1 || inputs[0].Elem.Kind() != ethgoabi.KindAddress ||
+		len(outputs) != 1 || outputs[0].Elem.Kind() != ethgoabi.KindBool {
+		return nil, fmt.Errorf("invalid staking eligibility function %q, expected a single address argument and bool result", signature)
+	}
+
+	return method, nil
+}
+
+// Checks that the staking contract and its eligibility function are either both configured or both left empty.
+func ValidateStaking(stakingAddress string, eligibilityFunction string) error {
+	switch {
+	case stakingAddress == "" && eligibilityFunction == "":
+		return nil
+	case stakingAddress == "":
+		return errors.New("reporter 'staking_eligibility_function' provided but missing a valid 'staking_address'")
+	case eligibilityFunction == "":
+		return errors.New("reporter 'staking_address' provided but missing a valid 'staking_eligibility_function'")
+	}
+
+	_, err := ParseStakingEligibilityFunction(eligibilityFunction)
+
+	return err
+}
+
+// Stores the status of an action for the key, logging and updating metrics when it changes.
+func (d *ReporterService) setActionStatus(key *reporterKey, functionType string, status *ActionStatus) {
+	key.eligibility.Lock()
+	previous, ok := key.eligibility.actions[functionType]
+	key.eligibility.actions[functionType] = status
+	key.eligibility.Unlock()
+
+	if status.Allowed {
+		d.metrics.ActionAllowed.WithLabelValues(key.name, functionType).Set(1)
+	} else {
+		d.metrics.ActionAllowed.WithLabelValues(key.name, functionType).Set(0)
+	}
+
+	switch {
+	case !status.Allowed && (!ok || previous.Allowed):
+		d.logger.Warn(
+			"reporter key is not permitted to perform action, disabling it",
+			"key", key.name,
+			"function", functionType,
+			"reason", status.Reason,
+		)
+	case status.Allowed && ok && !previous.Allowed:
+		d.logger.Info("reporter key is now permitted to perform action, enabling it", "key", key.name, "function", functionType)
+	}
+}
+
+// Requests an eligibility check from the refresh loop without blocking.
+// Requests made while a check is already pending are merged into it.
+func (d *ReporterService) requestEligibilityCheck() {
+	select {
+	case d.eligibilityChecks <- struct{}{}:
+	default:
+	}
+}
+
+// Periodically re-checks eligibility using the configured refresh interval, and whenever a check is requested.
+func (d *ReporterService) startEligibilityRefreshLoop() {
+	interval := d.config.Load().EligibilityRefreshInterval
+	if interval <= 0 {
+		interval = defaultEligibilityRefreshInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.eligibilityChecks:
+		}
+
+		d.checkEligibility()
+	}
+}
+
+// Resolves a role name to its AccessControl identifier.
+// The emergency reporter role is read from the contract, any other role is resolved with roleID.
+func (d *ReporterService) resolveRole(name string) ([32]byte, error) {
+	if name != EmergencyReporterRole {
+		return roleID(name)
+	}
+
+	role, ok := d.sendCall(EmergencyReporterRole).([32]byte)
+	if !ok {
+		return role, fmt.Errorf("unable to query role %s", name)
+	}
+
+	return role, nil
+}
+
+// Resolves a role name to its AccessControl identifier.
+// DEFAULT_ADMIN_ROLE maps to the zero hash, 0x-prefixed 32 byte values are used as-is,
+// and any other name is hashed with keccak256 as done by the contract's role constants.
+func roleID(name string) ([32]byte, error) {
+	var role [32]byte
+
+	switch {
+	case name == defaultAdminRoleName:
+		return role, nil
+	case strings.HasPrefix(name, "0x"):
+		buf, err := hex.DecodeHex(name)
+		if err != nil || len(buf) != len(role) {
+			return role, fmt.Errorf("invalid role identifier %s", name)
+		}
+
+		copy(role[:], buf)
+	default:
+		copy(role[:], keccak.Keccak256(nil, []byte(name)))
+	}
+
+	return role, nil
+}
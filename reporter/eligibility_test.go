@@ -0,0 +1,302 @@
+package reporter
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/sx-network/sx-reporter/helper/keccak"
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	testOutcomeReporterAddress = "0x00000000000000000000000000000000000000c1"
+	testVoterRole              = "OUTCOME_VOTER_ROLE"
+	testReporterRole           = "OUTCOME_REPORTER_ROLE"
+)
+
+// Identifier of the role the emergency reporter role is read as from the contract.
+var testEmergencyRole = [32]byte{0xee}
+
+// Answers the role queries of the OutcomeReporter contract, granting the roles of each account.
+// Role admins are the default admin role. Queries of roles in reverted revert.
+func handleRoleCalls(t *testing.T, chain *fakeChain, granted map[ethgo.Address][]string, reverted ...string) {
+	t.Helper()
+
+	roleNames := make(map[[32]byte]string)
+	for _, name := range []string{testVoterRole, testReporterRole, "OUTCOME_PROPOSER_ROLE"} {
+		role, _ := roleID(name)
+		roleNames[role] = name
+	}
+
+	roleNames[testEmergencyRole] = EmergencyReporterRole
+
+	chain.handleCall(t, "function hasRole(bytes32 role, address account) view returns (bool)",
+		func(inputs map[string]interface{}) interface{} {
+			name := roleNames[inputs["role"].([32]byte)]
+
+			for _, revertedName := range reverted {
+				if name == revertedName {
+					return nil
+				}
+			}
+
+			for _, grantedName := range granted[inputs["account"].(ethgo.Address)] {
+				if name == grantedName {
+					return true
+				}
+			}
+
+			return false
+		})
+	chain.handleCall(t, "function getRoleAdmin(bytes32 role) view returns (bytes32)",
+		func(map[string]interface{}) interface{} {
+			return [32]byte{}
+		})
+	chain.handleCall(t, "function OUTCOME_EMERGENCY_REPORTER_ROLE() view returns (bytes32)",
+		func(map[string]interface{}) interface{} {
+			return testEmergencyRole
+		})
+}
+
+func newEligibilityTestService(t *testing.T, granted []string, reverted ...string) (*ReporterService, *fakeChain) {
+	t.Helper()
+
+	d := newTestReporterService(t, &ReporterConfig{
+		OutcomeReporterAddress: testOutcomeReporterAddress,
+		ProposeRole:            UncheckedRole,
+		VoteRole:               testVoterRole,
+		ReportRole:             testReporterRole,
+	}, nil)
+
+	chain := newFakeChain(t, d)
+	handleRoleCalls(t, chain, map[ethgo.Address][]string{ethgo.HexToAddress(testKeyAddress): granted}, reverted...)
+
+	return d, chain
+}
+
+func TestCheckAction(t *testing.T) {
+	d, chain := newEligibilityTestService(t, []string{testVoterRole})
+	account := ethgo.HexToAddress(testKeyAddress)
+
+	tests := []struct {
+		function string
+		allowed  bool
+		reason   string
+	}{
+		// the propose role is set to none, so proposing is not checked
+		{ProposeOutcome, true, ""},
+		{VoteOutcome, true, ""},
+		{
+			ReportOutcome,
+			false,
+			"reporter " + account.String() + " is missing role " + testReporterRole +
+				", which can be granted by holders of role 0x" + strings.Repeat("00", 32),
+		},
+		{EmergencyReportOutcome, false, "missing role " + EmergencyReporterRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			status, err := d.checkAction(tt.function, account)
+			if err != nil {
+				t.Fatalf("checkAction() error = %v", err)
+			}
+
+			if status.Allowed != tt.allowed || !strings.Contains(status.Reason, tt.reason) {
+				t.Errorf("checkAction() = %+v, want allowed %t with reason %q", status, tt.allowed, tt.reason)
+			}
+		})
+	}
+
+	if calls := chain.count("hasRole"); calls != 3 {
+		t.Errorf("hasRole called %d times, want 3 for the configured and emergency roles", calls)
+	}
+}
+
+func TestCheckActionFailedCall(t *testing.T) {
+	d, _ := newEligibilityTestService(t, []string{testVoterRole, testReporterRole}, testReporterRole)
+
+	if _, err := d.checkAction(ReportOutcome, ethgo.HexToAddress(testKeyAddress)); err == nil {
+		t.Error("checkAction() error = nil, want an error for the reverted role query")
+	}
+}
+
+// Checks the action for the default key, failing the test on error.
+func mustCheckAction(t *testing.T, d *ReporterService, function string) *ActionStatus {
+	t.Helper()
+
+	status, err := d.checkAction(function, ethgo.HexToAddress(testKeyAddress))
+	if err != nil {
+		t.Fatalf("checkAction(%s) error = %v", function, err)
+	}
+
+	return status
+}
+
+func TestCheckActionUncheckedByDefault(t *testing.T) {
+	d, chain := newEligibilityTestService(t, nil)
+
+	for _, roles := range []*ReporterConfig{
+		{OutcomeReporterAddress: testOutcomeReporterAddress},
+		{OutcomeReporterAddress: testOutcomeReporterAddress, ProposeRole: UncheckedRole, VoteRole: UncheckedRole, ReportRole: UncheckedRole},
+	} {
+		d.config.Store(roles)
+
+		for _, function := range []string{ProposeOutcome, VoteOutcome, ReportOutcome} {
+			if status := mustCheckAction(t, d, function); !status.Allowed {
+				t.Errorf("checkAction(%s) = %+v, want it allowed without a configured role", function, status)
+			}
+		}
+	}
+
+	if calls := chain.count(HasRole); calls != 0 {
+		t.Errorf("hasRole called %d times, want no role checked", calls)
+	}
+}
+
+func TestCheckActionStaking(t *testing.T) {
+	const stakingFunction = "function isValidator(address account) view returns (bool)"
+
+	d, chain := newEligibilityTestService(t, []string{testVoterRole, testReporterRole})
+
+	if d.StakingEligibility() != StakingUnchecked {
+		t.Fatalf("staking eligibility = %s without a staking contract, want %s", d.StakingEligibility(), StakingUnchecked)
+	}
+
+	d.config.Store(&ReporterConfig{
+		OutcomeReporterAddress:     testOutcomeReporterAddress,
+		ProposeRole:                UncheckedRole,
+		VoteRole:                   testVoterRole,
+		ReportRole:                 testReporterRole,
+		StakingAddress:             "0x00000000000000000000000000000000000000c2",
+		StakingEligibilityFunction: stakingFunction,
+	})
+
+	var validator interface{} = false
+	chain.handleCall(t, stakingFunction, func(inputs map[string]interface{}) interface{} {
+		if inputs["account"] != ethgo.HexToAddress(testKeyAddress) {
+			t.Errorf("staking eligibility checked for %v, want %s", inputs["account"], testKeyAddress)
+		}
+
+		return validator
+	})
+
+	if d.StakingEligibility() != StakingChecked {
+		t.Errorf("staking eligibility = %s, want %s", d.StakingEligibility(), StakingChecked)
+	}
+
+	// staking is only required for voting and reporting
+	if !mustCheckAction(t, d, ProposeOutcome).Allowed {
+		t.Error("proposeOutcome disabled for staking eligibility, which it does not require")
+	}
+
+	for _, function := range []string{VoteOutcome, ReportOutcome} {
+		if status := mustCheckAction(t, d, function); status.Allowed || !strings.Contains(status.Reason, "staking contract") {
+			t.Errorf("checkAction(%s) = %+v, want it disabled for staking eligibility", function, status)
+		}
+	}
+
+	validator = true
+
+	if !mustCheckAction(t, d, VoteOutcome).Allowed {
+		t.Error("voteOutcome disabled for an eligible validator")
+	}
+
+	validator = nil
+
+	if _, err := d.checkAction(VoteOutcome, ethgo.HexToAddress(testKeyAddress)); err == nil {
+		t.Error("checkAction() error = nil, want an error for the reverted staking query")
+	}
+}
+
+func TestValidateStaking(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		function string
+		wantErr  bool
+	}{
+		{"unchecked", "", "", false},
+		{"checked", "0x00000000000000000000000000000000000000c2", "function isValidator(address) view returns (bool)", false},
+		{"missing function", "0x00000000000000000000000000000000000000c2", "", true},
+		{"missing address", "", "function isValidator(address) view returns (bool)", true},
+		{"invalid signature", "0x00000000000000000000000000000000000000c2", "isValidator", true},
+		{"wrong argument", "0x00000000000000000000000000000000000000c2", "function isValidator(uint256) view returns (bool)", true},
+		{"wrong result", "0x00000000000000000000000000000000000000c2", "function stakeOf(address) view returns (uint256)", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateStaking(tt.address, tt.function); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStaking() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckEligibility(t *testing.T) {
+	d, _ := newEligibilityTestService(t, []string{testVoterRole})
+	key := d.keys[0]
+
+	// the failed check keeps the previous status
+	d.setActionStatus(key, ProposeOutcome, &ActionStatus{Allowed: false, Reason: "stale"})
+	d.config.Store(&ReporterConfig{
+		OutcomeReporterAddress: testOutcomeReporterAddress,
+		ProposeRole:            "0xzz",
+		VoteRole:               testVoterRole,
+		ReportRole:             testReporterRole,
+	})
+
+	d.checkEligibility()
+
+	for function, allowed := range map[string]bool{ProposeOutcome: false, VoteOutcome: true, ReportOutcome: false} {
+		if got, _ := key.isActionAllowed(function); got != allowed {
+			t.Errorf("isActionAllowed(%s) = %t, want %t", function, got, allowed)
+		}
+	}
+
+	if _, reason := key.isActionAllowed(ProposeOutcome); reason != "stale" {
+		t.Errorf("proposeOutcome reason = %q, want the previous status kept", reason)
+	}
+
+	statuses, keys, lastCheck := d.EligibilityStatus()
+
+	if statuses[ReportOutcome].Allowed || !statuses[VoteOutcome].Allowed {
+		t.Errorf("eligibility statuses = %+v, want voting allowed and reporting disabled", statuses)
+	}
+
+	if keys["default"].Address != ethgo.HexToAddress(testKeyAddress).String() || lastCheck.IsZero() {
+		t.Errorf("key status = %+v, last check %s, want the default key checked", keys["default"], lastCheck)
+	}
+}
+
+func TestRoleID(t *testing.T) {
+	hashed := keccak.Keccak256(nil, []byte(testVoterRole))
+
+	tests := []struct {
+		name    string
+		role    string
+		want    string
+		wantErr bool
+	}{
+		{"default admin", defaultAdminRoleName, strings.Repeat("00", 32), false},
+		{"name", testVoterRole, hex.EncodeToString(hashed), false},
+		{"identifier", "0x" + strings.Repeat("ab", 32), strings.Repeat("ab", 32), false},
+		{"short identifier", "0xabcd", "", true},
+		{"invalid identifier", "0x" + strings.Repeat("zz", 32), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := roleID(tt.role)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("roleID() error = %v, want error %t", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && hex.EncodeToString(role[:]) != tt.want {
+				t.Errorf("roleID() = %x, want %s", role, tt.want)
+			}
+		})
+	}
+}
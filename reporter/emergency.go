@@ -0,0 +1,203 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sx-network/sx-reporter/helper/hex"
+	"github.com/sx-network/sx-reporter/helper/types"
+	"github.com/sx-network/sx-reporter/reporter/proto"
+	"github.com/umbracle/ethgo"
+)
+
+var (
+	errEmergencyNotConfirmed = errors.New("emergency report requires explicit confirmation")
+	errEmergencyNoReason     = errors.New("emergency report requires a reason")
+	errEmergencyNoMarketHash = errors.New("emergency report requires a market hash")
+	errInvalidMarketHash     = errors.New("invalid market hash")
+	errEmergencyNotPermitted = errors.New("reporter does not hold OUTCOME_EMERGENCY_REPORTER_ROLE")
+	errEmergencyBusy         = errors.New("too many emergency reports in progress, retry once they are sent")
+)
+
+// Request to report a market outcome through the emergency path.
+type EmergencyReportRequest struct {
+	MarketHash string `json:"marketHash"` // Hash of the market to report.
+	Outcome    int32  `json:"outcome"`    // Outcome to report.
+	Reason     string `json:"reason"`     // Why the emergency path is used, recorded in the audit trail.
+	Confirm    bool   `json:"confirm"`    // Explicit operator confirmation.
+	Requester  string `json:"-"`          // Origin of the request, recorded in the audit trail.
+}
+
+// Result of an emergency report, returned once its tx is sent, or once it is mined or fails.
+type EmergencyReportResult struct {
+	Time       time.Time `json:"time"`
+	MarketHash string    `json:"marketHash"`
+	Outcome    int32     `json:"outcome"`
+	Reason     string    `json:"reason"`
+	Requester  string    `json:"requester"`
+	Reporter   string    `json:"reporter"`
+	Key        string    `json:"key"`
+	TxHash     string    `json:"txHash,omitempty"`
+	Nonce      *uint64   `json:"nonce,omitempty"`
+	Pending    bool      `json:"pending,omitempty"` // Whether the tx was sent but is not mined yet.
+	Success    bool      `json:"success"`
+}
+
+// Sends an emergencyReportOutcome tx for the requested market and outcome.
+// The request must be confirmed and carry a reason, and the reporter key selected by the routing rules
+// must hold OUTCOME_EMERGENCY_REPORTER_ROLE, which is verified on-chain before sending.
+// The tx is sent ahead of the key's queued txs, right after the tx the key is sending, if any.
+// It returns once the tx is sent, with its hash and nonce, as mining it may take up to every try's confirmation timeout.
+// Every report is recorded in the audit trail of its market along with its reason and requester,
+// followed by the entries of its tx being sent and mined, or failing.
+func (d *ReporterService) EmergencyReport(request *EmergencyReportRequest) (*EmergencyReportResult, error) {
+	switch {
+	case !request.Confirm:
+		return nil, errEmergencyNotConfirmed
+	case request.Reason == "":
+		return nil, errEmergencyNoReason
+	case request.MarketHash == "":
+		return nil, errEmergencyNoMarketHash
+	}
+
+	if err := ValidateMarketHash(request.MarketHash); err != nil {
+		return nil, err
+	}
+
+	if _, err := ParseOutcome(int64(request.Outcome)); err != nil {
+		return nil, err
+	}
+
+	key, err := d.routeEmergencyReport()
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Warn(
+		"sending emergency outcome report",
+		"key", key.name,
+		"marketHash", request.MarketHash,
+		"outcome", request.Outcome,
+		"reason", request.Reason,
+		"requester", request.Requester,
+	)
+
+	d.audit(&AuditEntry{
+		MarketHash: request.MarketHash,
+		Event:      AuditEmergencyReport,
+		Function:   EmergencyReportOutcome,
+		Key:        key.name,
+		Outcome:    &request.Outcome,
+		Reason:     request.Reason,
+		Requester:  request.Requester,
+	})
+
+	sentCh := make(chan *sentTx, 1)
+	resultCh := make(chan *ethgo.Receipt, 1)
+
+	err = d.enqueuePriorityTx(key, &ReportingTx{
+		functionType: EmergencyReportOutcome,
+		report: &proto.Report{
+			MarketHash: request.MarketHash,
+			Outcome:    request.Outcome,
+		},
+		result: resultCh,
+		sent:   sentCh,
+	})
+	if err != nil {
+		d.logger.Error("failed to queue emergency report", "key", key.name, "marketHash", request.MarketHash, "err", err)
+		d.audit(&AuditEntry{
+			MarketHash: request.MarketHash,
+			Event:      AuditSkipped,
+			Function:   EmergencyReportOutcome,
+			Key:        key.name,
+			Reason:     errEmergencyBusy.Error(),
+		})
+
+		return nil, errEmergencyBusy
+	}
+
+	result := &EmergencyReportResult{
+		MarketHash: request.MarketHash,
+		Outcome:    request.Outcome,
+		Reason:     request.Reason,
+		Requester:  request.Requester,
+		Reporter:   key.address().String(),
+		Key:        key.name,
+	}
+
+	select {
+	case sent := <-sentCh:
+		pending := *result
+		pending.Time = time.Now()
+		pending.TxHash = sent.hash.String()
+		pending.Nonce = &sent.nonce
+		pending.Pending = true
+
+		go func() {
+			d.finishEmergencyReport(result, <-resultCh)
+		}()
+
+		return &pending, nil
+	case receipt := <-resultCh:
+		return d.finishEmergencyReport(result, receipt)
+	}
+}
+
+// Logs the result of the emergency report tx, failing if it was not mined successfully.
+// The tx itself is recorded in the audit trail as it is sent and mined, or fails.
+func (d *ReporterService) finishEmergencyReport(
+	result *EmergencyReportResult,
+	receipt *ethgo.Receipt,
+) (*EmergencyReportResult, error) {
+	result.Time = time.Now()
+	result.Success = receipt != nil
+
+	if receipt == nil {
+		d.logger.Error("emergency report tx failed", "key", result.Key, "marketHash", result.MarketHash)
+
+		return result, fmt.Errorf("emergency report tx for market %s failed", result.MarketHash)
+	}
+
+	result.TxHash = receipt.TransactionHash.String()
+
+	d.logger.Info("emergency report tx mined", "key", result.Key, "marketHash", result.MarketHash, "txHash", result.TxHash)
+
+	return result, nil
+}
+
+// ValidateMarketHash returns an error if the market hash is not a 0x-prefixed 32 byte hex string.
+func ValidateMarketHash(marketHash string) error {
+	if !strings.HasPrefix(marketHash, "0x") {
+		return fmt.Errorf("%w %s, expected a 0x-prefixed hex string", errInvalidMarketHash, marketHash)
+	}
+
+	buf, err := hex.DecodeHex(marketHash)
+	if err != nil || len(buf) != types.HashLength {
+		return fmt.Errorf("%w %s, expected %d hex encoded bytes", errInvalidMarketHash, marketHash, types.HashLength)
+	}
+
+	return nil
+}
+
+// Checks on-chain which of the keys the emergency routing rule may select hold OUTCOME_EMERGENCY_REPORTER_ROLE,
+// then selects the key sending the emergency report among them.
+func (d *ReporterService) routeEmergencyReport() (*reporterKey, error) {
+	for _, key := range d.router.selectable(EmergencyReportOutcome) {
+		status, err := d.checkAction(EmergencyReportOutcome, key.address())
+		if err != nil {
+			return nil, err
+		}
+
+		d.setActionStatus(key, EmergencyReportOutcome, status)
+	}
+
+	keys, _ := d.router.route(EmergencyReportOutcome, true)
+	if len(keys) == 0 {
+		return nil, errEmergencyNotPermitted
+	}
+
+	return keys[0], nil
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -22,14 +23,24 @@ import (
 // a reference to the reporter service for processing events, and an Ethereum client for interacting
 // with the Ethereum blockchain.
 type EventListener struct {
-	logger          hclog.Logger
-	reporterService *ReporterService
-	client          *ethclient.Client
+	logger             hclog.Logger
+	reporterService    *ReporterService
+	client             *ethclient.Client
+	heads              *headWatchdog // Detects stalled subscriptions from the time of the last new head.
+	votingPeriodChecks chan struct{} // Signals new heads to the voting period check loop, merged while a check is pending.
+	resubscribeDelay   time.Duration // Delay before each attempt to re-subscribe after a subscription error.
+}
+
+// Tracks the time of the last new head received, to detect stalled event subscriptions.
+type headWatchdog struct {
+	lastHead time.Time // Time the last new head was received, or the subscriptions were started.
+	stalled  bool      // Whether the subscriptions are considered stalled.
+	sync.Mutex
 }
 
-// Represents the host address for connecting to an Ethereum JSON-RPC WebSocket server.
 const (
-	JSONRPCWsHost = "wss://ws.sx-rollup-testnet.t.raas.gelato.cloud"
+	// Delay before each attempt to re-subscribe after a subscription error.
+	defaultResubscribeDelay = 5 * time.Second
 )
 
 // Creates a new event listener with the provided logger and reporter service.
@@ -39,11 +50,21 @@ const (
 func newEventListener(logger hclog.Logger, reporterService *ReporterService) (*EventListener, error) {
 
 	eventListener := &EventListener{
-		logger:          logger.Named("eventListener"),
-		reporterService: reporterService,
+		logger:             logger.Named("eventListener"),
+		reporterService:    reporterService,
+		heads:              &headWatchdog{lastHead: time.Now()},
+		votingPeriodChecks: make(chan struct{}, 1),
+		resubscribeDelay:   defaultResubscribeDelay,
+	}
+
+	if reporterService.config.Load().JSONRPCWsURL == "" {
+		return nil, fmt.Errorf("reporter JSON-RPC WebSocket URL is missing")
 	}
 
-	client, err := ethclient.Dial(JSONRPCWsHost)
+	ctx, cancel := context.WithTimeout(context.Background(), reporterService.rpcTimeout())
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, reporterService.config.Load().JSONRPCWsURL)
 	if err != nil {
 		logger.Error("error while dialing ws rpc url", "err", err)
 		return nil, err
@@ -51,6 +72,7 @@ func newEventListener(logger hclog.Logger, reporterService *ReporterService) (*E
 	eventListener.client = client
 
 	go eventListener.startListeningLoop()
+	go eventListener.startStallCheckLoop()
 
 	return eventListener, nil
 }
@@ -67,7 +89,7 @@ func (e EventListener) startListeningLoop() {
 		return
 	}
 
-	outcomeReporterAddress := common.HexToAddress(e.reporterService.config.OutcomeReporterAddress)
+	outcomeReporterAddress := common.HexToAddress(e.reporterService.config.Load().OutcomeReporterAddress)
 
 	proposeOutcomeSub, proposeOutcomeLogs, err := e.subscribeToProposeOutcome(contractAbi, outcomeReporterAddress)
 	if err != nil {
@@ -79,26 +101,77 @@ func (e EventListener) startListeningLoop() {
 		panic(fmt.Errorf("fatal error while subscribing to OutcomeReported logs: %w", err))
 	}
 
+	newHeadsSub, newHeads, err := e.subscribeToNewHeads()
+	if err != nil {
+		panic(fmt.Errorf("fatal error while subscribing to new heads: %w", err))
+	}
+
+	go e.startVotingPeriodCheckLoop()
+
+	reporterAccounts := make([]common.Address, 0, len(e.reporterService.keys))
+	for _, address := range e.reporterService.keyAddresses() {
+		reporterAccounts = append(reporterAccounts, common.Address(address))
+	}
+
+	roleChangesSub, roleChangesLogs, err := e.subscribeToRoleChanges(contractAbi, outcomeReporterAddress, reporterAccounts)
+	if err != nil {
+		panic(fmt.Errorf("fatal error while subscribing to role change logs: %w", err))
+	}
+
 	e.logger.Debug("listening for events...")
 
 	for {
 		select {
 		case err := <-proposeOutcomeSub.Err():
-			e.logger.Error("error listening to ProposeOutcome events, re-connecting after 5 seconds..", "err", err)
-			time.Sleep(5 * time.Second)
-			proposeOutcomeSub, proposeOutcomeLogs, err = e.subscribeToProposeOutcome(contractAbi, outcomeReporterAddress)
-			if err != nil {
-				e.logger.Error("fatal error while re-subscribing to ProposeOutcome logs", "err", err)
-			}
+			e.logger.Error("error listening to ProposeOutcome events, re-connecting..", "err", err)
+
+			e.resubscribe("ProposeOutcome logs", func() (err error) {
+				proposeOutcomeSub, proposeOutcomeLogs, err = e.subscribeToProposeOutcome(contractAbi, outcomeReporterAddress)
+
+				return err
+			})
 
 		case err := <-outcomeReportedSub.Err():
-			e.logger.Error("error listening to OutcomeReported events, re-connecting after 5 seconds..", "err", err)
+			e.logger.Error("error listening to OutcomeReported events, re-connecting..", "err", err)
 
-			time.Sleep(5 * time.Second)
-			outcomeReportedSub, outcomeReportedLogs, err = e.subscribeToOutcomeReported(contractAbi, outcomeReporterAddress)
-			if err != nil {
-				e.logger.Error("fatal error while re-subscribing to OutcomeReported logs", "err", err)
-			}
+			e.resubscribe("OutcomeReported logs", func() (err error) {
+				outcomeReportedSub, outcomeReportedLogs, err = e.subscribeToOutcomeReported(contractAbi, outcomeReporterAddress)
+
+				return err
+			})
+
+		case err := <-newHeadsSub.Err():
+			e.logger.Error("error listening to new heads, re-connecting..", "err", err)
+
+			e.resubscribe("new heads", func() (err error) {
+				newHeadsSub, newHeads, err = e.subscribeToNewHeads()
+
+				return err
+			})
+
+		case err := <-roleChangesSub.Err():
+			e.logger.Error("error listening to role change events, re-connecting..", "err", err)
+
+			e.resubscribe("role change logs", func() (err error) {
+				roleChangesSub, roleChangesLogs, err = e.subscribeToRoleChanges(contractAbi, outcomeReporterAddress, reporterAccounts)
+
+				return err
+			})
+
+		case vLog := <-roleChangesLogs:
+			e.logger.Info(
+				"received role change event for reporter, re-checking eligibility",
+				"txHash", vLog.TxHash,
+				"role", vLog.Topics[1],
+				"account", common.BytesToAddress(vLog.Topics[2].Bytes()),
+			)
+
+			e.reporterService.requestEligibilityCheck()
+
+		case header := <-newHeads:
+			e.receivedHead()
+			e.reporterService.scheduler.updateChainTime(header.Time)
+			e.requestVotingPeriodCheck()
 
 		case vLog := <-proposeOutcomeLogs:
 			results, err := contractAbi.Unpack("ProposeOutcome", vLog.Data)
@@ -113,25 +186,26 @@ func (e EventListener) startListeningLoop() {
 
 			marketHash, ok := results[0].([32]byte)
 			if !ok { // type assertion failed
-				e.logger.Error("type assertion failed for [32]byte", "marketHash", results[0], "got type", reflect.TypeOf(results[0]).String())
+				e.logger.Error("type assertion failed for [32]byte", "marketHash", results[0], "type", reflect.TypeOf(results[0]).String())
 			}
 
 			outcome, ok := results[1].(uint8)
 			if !ok { // type assertion failed
-				e.logger.Error("type assertion failed for int", "outcome", results[1], "got type", reflect.TypeOf(results[1]).String())
+				e.logger.Error("type assertion failed for int", "outcome", results[1], "type", reflect.TypeOf(results[1]).String())
 			}
 
 			blockTimestamp, ok := results[2].(*big.Int)
 			if !ok { // type assertion failed
-				e.logger.Error("type assertion failed for int", "timestamp", results[2], "got type", reflect.TypeOf(results[2]).String())
+				e.logger.Error("type assertion failed for int", "timestamp", results[2], "type", reflect.TypeOf(results[2]).String())
 			}
 
 			marketHashStr := fmt.Sprintf("0x%s", hex.EncodeToString(marketHash[:]))
 			e.logger.Debug("received ProposeOutcome event", "marketHash", marketHashStr, "outcome", outcome, "blockTime", blockTimestamp)
 
-			e.reporterService.syncVotingPeriod()
-			e.reporterService.queueReportingTx(VoteOutcome, marketHashStr, -1)
-			e.reporterService.storeProcessor.store.add(marketHashStr, uint64(blockTimestamp.Int64()))
+			e.reporterService.recordProposedOutcome(marketHashStr, int32(outcome))
+			e.reporterService.scheduler.updateChainTime(blockTimestamp.Uint64())
+			e.reporterService.voteScheduler.submit(context.Background(), marketHashStr, blockTimestamp.Uint64())
+			e.reporterService.scheduler.schedule(marketHashStr, blockTimestamp.Uint64())
 		case vLog := <-outcomeReportedLogs:
 			results, err := contractAbi.Unpack("OutcomeReported", vLog.Data)
 
@@ -145,18 +219,20 @@ func (e EventListener) startListeningLoop() {
 
 			marketHash, ok := results[0].([32]byte)
 			if !ok { // type assertion failed
-				e.logger.Error("type assertion failed for [32]byte", "marketHash", results[0], "got type", reflect.TypeOf(results[0]).String())
+				e.logger.Error("type assertion failed for [32]byte", "marketHash", results[0], "type", reflect.TypeOf(results[0]).String())
 			}
 
 			outcome, ok := results[1].(uint8)
 			if !ok { // type assertion failed
-				e.logger.Error("type assertion failed for int", "outcome", results[1], "got type", reflect.TypeOf(results[1]).String())
+				e.logger.Error("type assertion failed for int", "outcome", results[1], "type", reflect.TypeOf(results[1]).String())
 			}
 
 			marketHashStr := fmt.Sprintf("0x%s", hex.EncodeToString(marketHash[:]))
 			e.logger.Debug("received OutcomeReported event", "marketHash", marketHashStr, "outcome", outcome)
 
-			e.reporterService.storeProcessor.store.remove(marketHashStr)
+			e.reporterService.scheduler.cancel(marketHashStr)
+			e.reporterService.voteScheduler.cancel(marketHashStr)
+			e.reporterService.checkReportedOutcome(marketHashStr, int32(outcome))
 		}
 	}
 }
@@ -211,3 +287,159 @@ func (e EventListener) subscribeToOutcomeReported(contractAbi abi.ABI, outcomeRe
 
 	return outcomeReportedSub, outcomeReportedLogs, nil
 }
+
+// Subscribes to the RoleGranted and RoleRevoked events emitted for the given accounts by the outcome reporter contract.
+// It constructs a filter query matching either event ID with any of the accounts as indexed topic, then subscribes to logs matching the query.
+// It returns a subscription handle, a channel for receiving logs, and any error encountered during subscription.
+func (e EventListener) subscribeToRoleChanges(
+	contractAbi abi.ABI,
+	outcomeReporterAddress common.Address,
+	accounts []common.Address,
+) (ethereum.Subscription, <-chan types.Log, error) {
+	accountTopics := make([]common.Hash, len(accounts))
+	for i, account := range accounts {
+		accountTopics[i] = common.BytesToHash(account.Bytes())
+	}
+
+	roleChangesQuery := ethereum.FilterQuery{
+		Addresses: []common.Address{outcomeReporterAddress},
+		Topics: [][]common.Hash{
+			{contractAbi.Events["RoleGranted"].ID, contractAbi.Events["RoleRevoked"].ID},
+			nil,
+			accountTopics,
+		},
+	}
+
+	roleChangesLogs := make(chan types.Log)
+	roleChangesSub, err := e.client.SubscribeFilterLogs(context.Background(), roleChangesQuery, roleChangesLogs)
+	if err != nil {
+		e.logger.Error("error in SubscribeFilterLogs call", "err", err)
+
+		return nil, nil, err
+	}
+
+	return roleChangesSub, roleChangesLogs, nil
+}
+
+// Subscribes to new block headers, used to advance chain time and to check the voting period on every block.
+// It returns a subscription handle, a channel for receiving headers, and any error encountered during subscription.
+func (e EventListener) subscribeToNewHeads() (ethereum.Subscription, <-chan *types.Header, error) {
+	newHeads := make(chan *types.Header)
+	newHeadsSub, err := e.client.SubscribeNewHead(context.Background(), newHeads)
+	if err != nil {
+		e.logger.Error("error in SubscribeNewHead call", "err", err)
+
+		return nil, nil, err
+	}
+
+	return newHeadsSub, newHeads, nil
+}
+
+// Requests a voting period check from the check loop without blocking.
+// Requests made while a check is already pending are merged into it.
+func (e EventListener) requestVotingPeriodCheck() {
+	select {
+	case e.votingPeriodChecks <- struct{}{}:
+	default:
+	}
+}
+
+// Reads the on-chain voting period whenever a check is requested on a new head, off the listening loop so a slow RPC
+// does not hold up events. Changes are picked up within a block of being made, whether setVotingPeriod was called
+// directly or through a proxy or multisig, and the scheduled markets are rescheduled accordingly.
+func (e EventListener) startVotingPeriodCheckLoop() {
+	for range e.votingPeriodChecks {
+		if err := e.reporterService.syncVotingPeriod(); err != nil {
+			e.logger.Error("failed to check voting period", "err", err)
+		}
+	}
+}
+
+// Re-subscribes after the resubscribe delay, retrying until subscribe succeeds.
+// The subscription is never left unset, as the listening loop reads from it on every iteration.
+// A subscription alert is raised while re-subscribing fails and resolved once it succeeds.
+func (e EventListener) resubscribe(subscription string, subscribe func() error) {
+	for {
+		time.Sleep(e.resubscribeDelay)
+
+		err := subscribe()
+		e.alertResubscription(subscription, err)
+
+		if err == nil {
+			return
+		}
+
+		e.logger.Error("error while re-subscribing, retrying..", "subscription", subscription, "err", err)
+	}
+}
+
+// Raises a subscription alert if re-subscribing failed, or resolves it once re-subscribed.
+func (e EventListener) alertResubscription(subscription string, err error) {
+	alerter := e.reporterService.alerter
+	dedupKey := fmt.Sprintf("%s/%s", AlertSubscriptionStalled, subscription)
+
+	if err == nil {
+		alerter.resolve(AlertSubscriptionStalled, dedupKey, fmt.Sprintf("re-subscribed to %s", subscription))
+
+		return
+	}
+
+	alerter.raise(&Alert{
+		Rule:     AlertSubscriptionStalled,
+		Severity: AlertCritical,
+		Summary:  fmt.Sprintf("failed to re-subscribe to %s", subscription),
+		DedupKey: dedupKey,
+		Details:  map[string]string{"subscription": subscription, "err": err.Error()},
+	})
+}
+
+// Records a new head, resolving the stalled subscriptions alert if raised.
+func (e EventListener) receivedHead() {
+	e.heads.Lock()
+	stalled := e.heads.stalled
+	e.heads.lastHead = time.Now()
+	e.heads.stalled = false
+	e.heads.Unlock()
+
+	if stalled {
+		e.logger.Info("receiving new heads again")
+		e.reporterService.alerter.resolve(AlertSubscriptionStalled, AlertSubscriptionStalled, "receiving new heads again")
+	}
+}
+
+// Periodically checks that new heads were received within the stall timeout, logging once they stop.
+// The stalled subscriptions alert is raised on every check while stalled, and resolved on every check otherwise,
+// so that an alert or resolution dropped by the alerter is sent again, repeats being suppressed by the alerter.
+func (e EventListener) startStallCheckLoop() {
+	timeout := e.reporterService.alerter.subscriptionStallTimeout()
+
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		e.heads.Lock()
+		lastHead := e.heads.lastHead
+		wasStalled := e.heads.stalled
+		stalled := wasStalled || time.Since(lastHead) > timeout
+		e.heads.stalled = stalled
+		e.heads.Unlock()
+
+		if !stalled {
+			e.reporterService.alerter.resolve(AlertSubscriptionStalled, AlertSubscriptionStalled, "receiving new heads again")
+
+			continue
+		}
+
+		if !wasStalled {
+			e.logger.Warn("no new heads received within the stall timeout, event subscriptions may be stalled", "lastHead", lastHead, "timeout", timeout)
+		}
+
+		e.reporterService.alerter.raise(&Alert{
+			Rule:     AlertSubscriptionStalled,
+			Severity: AlertCritical,
+			Summary:  fmt.Sprintf("no new heads received for %s, event subscriptions may be stalled", timeout),
+			DedupKey: AlertSubscriptionStalled,
+			Details:  map[string]string{"lastHead": lastHead.Format(time.RFC3339), "timeout": timeout.String()},
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package reporter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestResubscribeRetriesUntilSubscribed(t *testing.T) {
+	d := newTestReporterService(t, nil, nil)
+	d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+	e := EventListener{
+		logger:           hclog.NewNullLogger(),
+		reporterService:  d,
+		resubscribeDelay: time.Millisecond,
+	}
+
+	attempts := 0
+	e.resubscribe("new heads", func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+
+	if attempts != 3 {
+		t.Fatalf("re-subscribed in %d attempts, want 3", attempts)
+	}
+
+	alerts := drainAlerts(d.alerter)
+	if len(alerts) != 2 || alerts[0].Resolved || !alerts[1].Resolved {
+		t.Fatalf("queued %v, want a subscription alert and its resolution", alerts)
+	}
+}
@@ -0,0 +1,89 @@
+package reporter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// Default number of attempts to send and mine a tx.
+	defaultMaxTxTries = 4
+)
+
+// Holds the gas policy for reporting transactions.
+type GasConfig struct {
+	MaxTxTries         int      // Attempts to send and mine a tx before giving up, defaults if zero.
+	GasPrice           *big.Int // Fixed gas price in wei, the node's gas price is used if nil.
+	MaxGasPrice        *big.Int // Upper bound of the gas price in wei, unbounded if nil.
+	GasLimitMultiplier float64  // Multiplier applied to the estimated gas limit, 1 is used if zero.
+}
+
+// Checks that the gas policy is consistent.
+func (c *GasConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MaxTxTries < 0 {
+		return fmt.Errorf("reporter gas max tx tries %d is negative", c.MaxTxTries)
+	}
+
+	if c.GasLimitMultiplier != 0 && c.GasLimitMultiplier < 1 {
+		return fmt.Errorf("reporter gas limit multiplier %v is below 1", c.GasLimitMultiplier)
+	}
+
+	if c.GasPrice != nil && c.MaxGasPrice != nil && c.GasPrice.Cmp(c.MaxGasPrice) > 0 {
+		return fmt.Errorf("reporter gas price %s is above the max gas price %s", c.GasPrice, c.MaxGasPrice)
+	}
+
+	return nil
+}
+
+// Returns the number of attempts to send and mine a tx.
+func (c *GasConfig) maxTxTries() uint64 {
+	if c == nil || c.MaxTxTries <= 0 {
+		return defaultMaxTxTries
+	}
+
+	return uint64(c.MaxTxTries)
+}
+
+// Returns the gas price of a tx, the fixed gas price if set or else the node's price, bounded by the max gas price.
+func (d *ReporterService) gasPrice() (uint64, error) {
+	gas := d.config.Load().Gas
+
+	var price *big.Int
+
+	if gas != nil && gas.GasPrice != nil {
+		price = gas.GasPrice
+	} else {
+		nodePrice, err := d.txService.client.Eth().GasPrice()
+		if err != nil {
+			return 0, err
+		}
+
+		price = new(big.Int).SetUint64(nodePrice)
+	}
+
+	if gas != nil && gas.MaxGasPrice != nil && price.Cmp(gas.MaxGasPrice) > 0 {
+		d.logger.Warn("gas price is above the configured max gas price, capping it", "gasPrice", price, "maxGasPrice", gas.MaxGasPrice)
+
+		price = gas.MaxGasPrice
+	}
+
+	if !price.IsUint64() {
+		return 0, fmt.Errorf("gas price %s is out of range", price)
+	}
+
+	return price.Uint64(), nil
+}
+
+// Returns the gas limit of a tx from its estimated gas, applying the configured multiplier.
+func (c *GasConfig) gasLimit(estimate uint64) uint64 {
+	if c == nil || c.GasLimitMultiplier <= 1 {
+		return estimate
+	}
+
+	return uint64(math.Ceil(float64(estimate) * c.GasLimitMultiplier))
+}
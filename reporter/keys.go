@@ -0,0 +1,319 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"github.com/umbracle/ethgo"
+)
+
+// Constants representing the routing rules that select keys by strategy rather than by name.
+const (
+	// RoundRobin rotates between the keys permitted to perform the action
+	RoundRobin = "round-robin"
+	// AllKeys sends the tx from every key permitted to perform the action, only supported for votes
+	AllKeys = "all"
+)
+
+// RoutedFunctions lists the function types that can be given a routing rule.
+var RoutedFunctions = []string{ProposeOutcome, VoteOutcome, ReportOutcome, EmergencyReportOutcome}
+
+const (
+	// Capacity of the tx queue of each reporter key.
+	keyTxQueueSize = 100
+	// Capacity of the priority tx queue of each reporter key, holding emergency reports.
+	keyPriorityTxQueueSize = 4
+)
+
+var errPriorityQueueFull = errors.New("priority tx queue is full")
+
+// Holds configuration options for a named reporter key.
+type ReporterKeyConfig struct {
+	Name   string        // Name of the key, used in routing rules, metrics and logs.
+	Signer *SignerConfig // Signer for the key's transactions, the secrets manager key of the same name is used if nil.
+}
+
+// Represents a reporter identity operated by the node, with its own signer and tx pipeline.
+type reporterKey struct {
+	name        string            // Name of the key.
+	signer      Signer            // Signer for the key's transactions.
+	txChan      chan *ReportingTx // Queue of the key's reporting transactions.
+	priorityCh  chan *ReportingTx // Queue of the key's emergency transactions, sent before any other queued tx.
+	nonces      *nonceManager     // Nonces of the key's account.
+	eligibility eligibility       // Actions the key is permitted to perform.
+	rewards     *rewardsLedger    // Juiced reporting rewards earned by the key.
+	balance     keyBalance        // Native balance of the key's account.
+}
+
+// Returns the reporter address of the key.
+func (k *reporterKey) address() ethgo.Address {
+	return k.signer.Address()
+}
+
+// Selects the keys sending reporting txs according to the routing rule of each function type.
+// Function types without a rule are sent from the primary key, the first configured key.
+type keyRouter struct {
+	keys   []*reporterKey          // Keys in configuration order.
+	byName map[string]*reporterKey // Keys by name.
+	rules  map[string]string       // Routing rule by function type, a key name, RoundRobin or AllKeys.
+	next   map[string]int          // Position of the next round-robin key by function type.
+	sync.Mutex
+}
+
+// Creates a router for the given keys, validating the routing rules against them.
+func newKeyRouter(keys []*reporterKey, rules map[string]string) (*keyRouter, error) {
+	router := &keyRouter{
+		keys:   keys,
+		byName: make(map[string]*reporterKey, len(keys)),
+		rules:  make(map[string]string, len(rules)),
+		next:   make(map[string]int),
+	}
+
+	for _, key := range keys {
+		router.byName[key.name] = key
+	}
+
+	for functionType, rule := range rules {
+		if !slices.Contains(RoutedFunctions, functionType) {
+			return nil, fmt.Errorf("reporter routing rule for unsupported function '%s'", functionType)
+		}
+
+		switch rule {
+		case "", RoundRobin:
+		case AllKeys:
+			if functionType != VoteOutcome {
+				return nil, fmt.Errorf("reporter routing rule '%s' is only supported for %s", AllKeys, VoteOutcome)
+			}
+		default:
+			if _, ok := router.byName[rule]; !ok {
+				return nil, fmt.Errorf("reporter routing rule for %s references unknown key '%s'", functionType, rule)
+			}
+		}
+
+		router.rules[functionType] = rule
+	}
+
+	return router, nil
+}
+
+// Returns the primary key, used for function types without a routing rule.
+func (r *keyRouter) primary() *reporterKey {
+	return r.keys[0]
+}
+
+// Returns the keys the routing rule of the function type may select, in order of preference.
+// Must be called with the lock held.
+func (r *keyRouter) candidates(functionType string) []*reporterKey {
+	switch rule := r.rules[functionType]; rule {
+	case "":
+		return []*reporterKey{r.primary()}
+	case RoundRobin, AllKeys:
+		start := r.next[functionType] % len(r.keys)
+
+		return append(append(make([]*reporterKey, 0, len(r.keys)), r.keys[start:]...), r.keys[:start]...)
+	default:
+		return []*reporterKey{r.byName[rule]}
+	}
+}
+
+// Returns the keys the routing rule of the function type may select, in order of preference.
+func (r *keyRouter) selectable(functionType string) []*reporterKey {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.candidates(functionType)
+}
+
+// Selects the keys sending a tx of the given function type among those permitted to perform it.
+// The round-robin position only moves forward if advance is set, so that the selection can be previewed.
+// If no key is selected, the reasons the candidate keys are not permitted are returned.
+func (r *keyRouter) route(functionType string, advance bool) ([]*reporterKey, string) {
+	r.Lock()
+	defer r.Unlock()
+
+	var (
+		selected []*reporterKey
+		reasons  []string
+	)
+
+	for _, key := range r.candidates(functionType) {
+		allowed, reason := key.isActionAllowed(functionType)
+		if !allowed {
+			reasons = append(reasons, fmt.Sprintf("key %s: %s", key.name, reason))
+
+			continue
+		}
+
+		selected = append(selected, key)
+
+		if r.rules[functionType] != AllKeys {
+			break
+		}
+	}
+
+	if len(selected) > 0 && advance && r.rules[functionType] == RoundRobin {
+		for i, key := range r.keys {
+			if key == selected[0] {
+				r.next[functionType] = i + 1
+			}
+		}
+	}
+
+	return selected, strings.Join(reasons, "; ")
+}
+
+// Creates the configured reporter keys, or the default key using the top-level signer if none are configured.
+// Key names must be unique, as must the addresses they sign for, so that their nonces do not collide.
+func (d *ReporterService) setupKeys() ([]*reporterKey, error) {
+	reporterConfig := d.config.Load()
+
+	configs := reporterConfig.Keys
+	if len(configs) == 0 {
+		configs = []*ReporterKeyConfig{{Name: secrets.DefaultReporterKeyName, Signer: reporterConfig.Signer}}
+	}
+
+	var (
+		keys           = make([]*reporterKey, 0, len(configs))
+		names          = make(map[string]bool, len(configs))
+		addresses      = make(map[ethgo.Address]string, len(configs))
+		backendSigners = 0
+	)
+
+	paused, err := lowFundsPausedFunctions(reporterConfig.LowFundsPausedFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, fmt.Errorf("reporter key is missing a name")
+		}
+
+		if err := secrets.ValidateReporterKeyName(config.Name); err != nil {
+			return nil, err
+		}
+
+		if names[config.Name] {
+			return nil, fmt.Errorf("reporter key '%s' is configured more than once", config.Name)
+		}
+
+		names[config.Name] = true
+
+		// the backend signs with the single account configured for the secrets manager
+		if config.Signer != nil && config.Signer.Type == BackendSigner {
+			if backendSigners++; backendSigners > 1 {
+				return nil, fmt.Errorf("only one reporter key can use the %s signer", BackendSigner)
+			}
+		}
+
+		signer, err := newSigner(config.Signer, d.secretsManager, secrets.NamedReporterKey(config.Name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to set up signer for reporter key '%s', %w", config.Name, err)
+		}
+
+		if other, ok := addresses[signer.Address()]; ok {
+			return nil, fmt.Errorf("reporter keys '%s' and '%s' share address %s", other, config.Name, signer.Address())
+		}
+
+		addresses[signer.Address()] = config.Name
+
+		rewards, err := loadRewardsLedger(reporterConfig.DataDir, config.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		key := &reporterKey{
+			name:       config.Name,
+			signer:     signer,
+			txChan:     make(chan *ReportingTx, keyTxQueueSize),
+			priorityCh: make(chan *ReportingTx, keyPriorityTxQueueSize),
+			rewards:    rewards,
+			eligibility: eligibility{
+				actions: make(map[string]*ActionStatus),
+			},
+			balance: keyBalance{
+				level:  BalanceUnknown,
+				paused: paused,
+			},
+		}
+		key.nonces = newNonceManager(signer.Address().String(), d.getCurrentNonce, d.metrics.Nonce.WithLabelValues(key.name))
+
+		d.logger.Info("using reporter key", "key", key.name, "type", signer.Type(), "address", signer.Address())
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Queues the reporting tx on the pipeline of the given key.
+func (d *ReporterService) enqueueTx(key *reporterKey, reportingTx *ReportingTx) {
+	reportingTx.key = key
+
+	key.txChan <- reportingTx
+	d.metrics.QueuedTxs.WithLabelValues(key.name).Set(float64(len(key.txChan)))
+}
+
+// Queues the reporting tx on the priority queue of the given key, to be sent once the key's current tx is done.
+// It fails rather than blocks if the priority queue is full.
+func (d *ReporterService) enqueuePriorityTx(key *reporterKey, reportingTx *ReportingTx) error {
+	reportingTx.key = key
+
+	select {
+	case key.priorityCh <- reportingTx:
+		return nil
+	default:
+		return errPriorityQueueFull
+	}
+}
+
+// Returns the next tx of the key to send, taking txs from the priority queue first.
+// It returns false once the key's tx queue is closed.
+func (k *reporterKey) nextTx() (*ReportingTx, bool) {
+	select {
+	case reportingTx := <-k.priorityCh:
+		return reportingTx, true
+	default:
+	}
+
+	select {
+	case reportingTx := <-k.priorityCh:
+		return reportingTx, true
+	case reportingTx, ok := <-k.txChan:
+		return reportingTx, ok
+	}
+}
+
+// Returns the addresses of all reporter keys.
+func (d *ReporterService) keyAddresses() []ethgo.Address {
+	addresses := make([]ethgo.Address, len(d.keys))
+	for i, key := range d.keys {
+		addresses[i] = key.address()
+	}
+
+	return addresses
+}
+
+// Close stops the report scheduler and zeroes the reporter keys held in memory by the in-process signers.
+// Reporting txs can no longer be signed afterwards.
+func (d *ReporterService) Close() {
+	if d.scheduler != nil {
+		d.scheduler.close()
+	}
+
+	for _, key := range d.keys {
+		closer, ok := key.signer.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		if err := closer.Close(); err != nil {
+			d.logger.Error("failed to close signer", "key", key.name, "err", err)
+		}
+	}
+}
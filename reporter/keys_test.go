@@ -0,0 +1,195 @@
+package reporter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Creates keys with the given names, permitted to perform every action.
+func newRoutedKeys(names ...string) []*reporterKey {
+	keys := make([]*reporterKey, 0, len(names))
+
+	for _, name := range names {
+		keys = append(keys, &reporterKey{
+			name:        name,
+			eligibility: eligibility{actions: make(map[string]*ActionStatus)},
+		})
+	}
+
+	return keys
+}
+
+// Returns the names of the keys.
+func keyNames(keys []*reporterKey) string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Returns the config of a web3signer key signing for the given address.
+func web3SignerKey(name, address string) *ReporterKeyConfig {
+	return &ReporterKeyConfig{
+		Name:   name,
+		Signer: &SignerConfig{Type: Web3Signer, URL: "http://127.0.0.1:9000", Address: address},
+	}
+}
+
+func TestNextTxPrefersPriorityQueue(t *testing.T) {
+	d := newTestReporterService(t, nil, nil)
+	key := d.keys[0]
+
+	d.enqueueTx(key, &ReportingTx{functionType: ReportOutcome})
+
+	if err := d.enqueuePriorityTx(key, &ReportingTx{functionType: EmergencyReportOutcome}); err != nil {
+		t.Fatalf("enqueuePriorityTx() error = %v", err)
+	}
+
+	for _, want := range []string{EmergencyReportOutcome, ReportOutcome} {
+		if tx, ok := key.nextTx(); !ok || tx.functionType != want {
+			t.Fatalf("nextTx() = %+v, %t, want a %s tx", tx, ok, want)
+		}
+	}
+
+	// a closed tx queue stops the key's processing
+	close(key.txChan)
+
+	if _, ok := key.nextTx(); ok {
+		t.Error("nextTx() returned a tx once the tx queue was closed")
+	}
+}
+
+func TestEnqueuePriorityTxFull(t *testing.T) {
+	d := newTestReporterService(t, nil, nil)
+	key := d.keys[0]
+
+	for i := 0; i < keyPriorityTxQueueSize; i++ {
+		if err := d.enqueuePriorityTx(key, &ReportingTx{functionType: EmergencyReportOutcome}); err != nil {
+			t.Fatalf("enqueuePriorityTx() error = %v", err)
+		}
+	}
+
+	// a full priority queue fails instead of blocking the caller
+	if err := d.enqueuePriorityTx(key, &ReportingTx{functionType: EmergencyReportOutcome}); !errors.Is(err, errPriorityQueueFull) {
+		t.Errorf("enqueuePriorityTx() error = %v, want %v", err, errPriorityQueueFull)
+	}
+}
+
+func TestKeyRouterRoute(t *testing.T) {
+	keys := newRoutedKeys("a", "b", "c")
+
+	router, err := newKeyRouter(keys, map[string]string{
+		ProposeOutcome: "b",
+		VoteOutcome:    AllKeys,
+		ReportOutcome:  RoundRobin,
+	})
+	if err != nil {
+		t.Fatalf("newKeyRouter() error = %v", err)
+	}
+
+	// key b cannot report
+	keys[1].eligibility.actions[ReportOutcome] = &ActionStatus{Reason: "missing role"}
+
+	tests := []struct {
+		name         string
+		functionType string
+		advance      bool
+		want         string
+	}{
+		{"named key", ProposeOutcome, true, "b"},
+		{"all keys", VoteOutcome, true, "a,b,c"},
+		{"no rule uses the primary key", EmergencyReportOutcome, true, "a"},
+		{"round-robin preview", ReportOutcome, false, "a"},
+		{"round-robin", ReportOutcome, true, "a"},
+		{"round-robin skips keys not permitted", ReportOutcome, true, "c"},
+		{"round-robin wraps around", ReportOutcome, true, "a"},
+	}
+
+	for _, tt := range tests {
+		if selected, reason := router.route(tt.functionType, tt.advance); keyNames(selected) != tt.want {
+			t.Errorf("%s: route(%s) = %s (%s), want %s", tt.name, tt.functionType, keyNames(selected), reason, tt.want)
+		}
+	}
+
+	// the reasons are returned once no candidate key is permitted
+	keys[1].eligibility.actions[ProposeOutcome] = &ActionStatus{Reason: "missing role"}
+
+	if selected, reason := router.route(ProposeOutcome, true); len(selected) != 0 || reason != "key b: missing role" {
+		t.Errorf("route(%s) = %s (%s), want no key as key b is missing its role", ProposeOutcome, keyNames(selected), reason)
+	}
+}
+
+func TestNewKeyRouterRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]string
+		wantErr string
+	}{
+		{"unsupported function", map[string]string{WithdrawRewards: "a"}, "reporter routing rule for unsupported function 'withdrawJuicedRewards'"},
+		{"all keys for a single tx", map[string]string{ProposeOutcome: AllKeys}, "reporter routing rule 'all' is only supported for voteOutcome"},
+		{"unknown key", map[string]string{ReportOutcome: "z"}, "reporter routing rule for reportOutcome references unknown key 'z'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newKeyRouter(newRoutedKeys("a", "b"), tt.rules); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("newKeyRouter() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetupKeys(t *testing.T) {
+	const otherAddress = "0x00000000000000000000000000000000000000b2"
+
+	tests := []struct {
+		name    string
+		keys    []*ReporterKeyConfig
+		wantErr string
+	}{
+		{"missing name", []*ReporterKeyConfig{web3SignerKey("", testKeyAddress)}, "reporter key is missing a name"},
+		{
+			"duplicate name",
+			[]*ReporterKeyConfig{web3SignerKey("hot", testKeyAddress), web3SignerKey("hot", otherAddress)},
+			"reporter key 'hot' is configured more than once",
+		},
+		{
+			"shared address",
+			[]*ReporterKeyConfig{web3SignerKey("hot", testKeyAddress), web3SignerKey("cold", strings.ToLower(testKeyAddress))},
+			"reporter keys 'hot' and 'cold' share address " + testKeyAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestReporterService(t, &ReporterConfig{Keys: tt.keys}, nil)
+
+			if _, err := d.setupKeys(); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("setupKeys() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	d := newTestReporterService(t, &ReporterConfig{
+		Keys: []*ReporterKeyConfig{web3SignerKey("hot", testKeyAddress), web3SignerKey("cold", otherAddress)},
+	}, nil)
+
+	keys, err := d.setupKeys()
+	if err != nil {
+		t.Fatalf("setupKeys() error = %v", err)
+	}
+
+	// every key gets its own queues and a nonce manager for its own account
+	if keyNames(keys) != "hot,cold" || keys[0].txChan == keys[1].txChan || keys[0].nonces == keys[1].nonces {
+		t.Fatalf("setupKeys() = %s, want keys hot and cold with their own queues and nonces", keyNames(keys))
+	}
+
+	for i, address := range []string{testKeyAddress, otherAddress} {
+		if !strings.EqualFold(keys[i].nonces.address, address) {
+			t.Errorf("key %s manages the nonces of %s, want %s", keys[i].name, keys[i].nonces.address, address)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package reporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Namespace used for all reporter prometheus metrics.
+	metricsNamespace = "sx_reporter"
+)
+
+// Holds the prometheus metrics exposed by the reporter service.
+type Metrics struct {
+	VotingPeriodSeconds     prometheus.Gauge       // Cached on-chain outcome voting period in seconds.
+	VotingPeriodLastRefresh prometheus.Gauge       // Unix timestamp of the last successful voting period refresh.
+	ActionAllowed           *prometheus.GaugeVec   // Whether each reporter key is permitted to perform each action.
+	RewardsAccrued          *prometheus.GaugeVec   // Total juiced reporting rewards earned by each key, in wei.
+	RewardsWithdrawn        *prometheus.GaugeVec   // Total juiced reporting rewards withdrawn by each key, in wei.
+	RewardedMarkets         *prometheus.GaugeVec   // Number of markets reported by each key that earned a reward.
+	TxsSent                 *prometheus.CounterVec // Reporting txs sent by each key, by function and result.
+	Nonce                   *prometheus.GaugeVec   // Next nonce of each key's account.
+	QueuedTxs               *prometheus.GaugeVec   // Reporting txs queued on each key's pipeline.
+	Balance                 *prometheus.GaugeVec   // Native balance of each key's account, in wei.
+	BalanceLevel            *prometheus.GaugeVec   // Level of each key's balance relative to the thresholds.
+	Disagreements           *prometheus.CounterVec // Outcome disagreements detected, by kind.
+	AlertsSent              *prometheus.CounterVec // Alerts sent, by rule, notifier and result.
+	AlertsSuppressed        *prometheus.CounterVec // Alerts suppressed, by rule and reason.
+	PendingVotes            prometheus.Gauge       // Proposed markets waiting to be verified and voted on.
+	VoteRetries             prometheus.Counter     // Votes delayed to retry verification.
+	VotesAbstained          prometheus.Counter     // Votes abstained from after verification failed.
+}
+
+// Creates the reporter metrics and registers them with the default prometheus registry.
+func newMetrics() *Metrics {
+	return &Metrics{
+		VotingPeriodSeconds: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "voting_period_seconds",
+			Help:      "Current on-chain outcome voting period in seconds",
+		}),
+		VotingPeriodLastRefresh: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "voting_period_last_refresh_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful on-chain voting period refresh",
+		}),
+		ActionAllowed: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "action_allowed",
+			Help:      "Whether the reporter key is permitted to perform the reporting action (1) or not (0)",
+		}, []string{"key", "function"}),
+		RewardsAccrued: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "rewards_accrued_wei",
+			Help:      "Total juiced reporting rewards earned by the reporter key, in wei",
+		}, []string{"key"}),
+		RewardsWithdrawn: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "rewards_withdrawn_wei",
+			Help:      "Total juiced reporting rewards withdrawn by the reporter key, in wei",
+		}, []string{"key"}),
+		RewardedMarkets: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "rewarded_markets",
+			Help:      "Number of markets reported by the reporter key that earned a juiced reward",
+		}, []string{"key"}),
+		TxsSent: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "txs_sent_total",
+			Help:      "Reporting txs sent by the reporter key, by function and result (success or failed)",
+		}, []string{"key", "function", "result"}),
+		Nonce: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "nonce",
+			Help:      "Next nonce of the reporter key's account",
+		}, []string{"key"}),
+		QueuedTxs: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "queued_txs",
+			Help:      "Reporting txs queued on the reporter key's pipeline",
+		}, []string{"key"}),
+		Balance: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "balance_wei",
+			Help:      "Native balance of the reporter key's account, in wei",
+		}, []string{"key"}),
+		BalanceLevel: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "balance_level",
+			Help:      "Level of the reporter key's balance, ok (0), below the warning (1) or critical (2) threshold, or unknown (-1)",
+		}, []string{"key"}),
+		Disagreements: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "outcome_disagreements_total",
+			Help:      "Outcome disagreements detected, by kind (verified vs proposed, or voted vs reported)",
+		}, []string{"kind"}),
+		AlertsSent: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "alerts_sent_total",
+			Help:      "Alerts sent to the notifiers, by rule, notifier and result (success or failed)",
+		}, []string{"rule", "notifier", "result"}),
+		AlertsSuppressed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "alerts_suppressed_total",
+			Help:      "Alerts not sent, by rule and reason (duplicate, rate_limited or queue_full)",
+		}, []string{"rule", "reason"}),
+		PendingVotes: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "pending_votes",
+			Help:      "Proposed markets waiting to be verified and voted on",
+		}),
+		VoteRetries: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "vote_verify_retries_total",
+			Help:      "Votes delayed to retry the verification of a market that could not be verified",
+		}),
+		VotesAbstained: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "votes_abstained_total",
+			Help:      "Votes abstained from after the market could not be verified",
+		}),
+	}
+}
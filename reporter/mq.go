@@ -11,18 +11,35 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/sx-network/sx-reporter/infra/common"
 	"github.com/sx-network/sx-reporter/reporter/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	// Sets the concurrency level for message queue consumers.
 	mqConsumerConcurrency = 1
+	// Default number of messages prefetched by each consumer.
+	defaultMQPrefetchCount = 4
+	// Default delay before restarting a failed consumer.
+	defaultMQReconnectDelay = 2 * time.Second
+	// Reason MQ messages with a missing outcome, or an outcome not allowed by the contract, are skipped.
+	mqSkippedInvalidOutcome = "invalid outcome"
 )
 
+// Outcome of an MQ message, decoded apart from the report as the report decodes a missing outcome as void.
+type mqOutcome struct {
+	Outcome *int32 `json:"outcome"`
+}
+
 // Holds configuration settings for the message queue.
 type MQConfig struct {
 	AMQPURI      string       // AMQPURI is the URI for connecting to the AMQP broker.
 	ExchangeName string       // ExchangeName is the name of the exchange.
 	QueueConfig  *QueueConfig // QueueConfig holds configuration settings for the message queue.
+	// PrefetchCount is the number of messages prefetched by each consumer, defaults if zero.
+	PrefetchCount int
+	// ReconnectDelay is the delay before restarting a failed consumer, defaults if zero.
+	ReconnectDelay time.Duration
 }
 
 // Represents a message queue service.
@@ -38,6 +55,12 @@ type Connection struct {
 	Channel *amqp.Channel // Channel is the AMQP channel used for communication.
 }
 
+// Represents a report consumed from the message queue along with the trace context of its message.
+type mqReport struct {
+	ctx    context.Context // Trace context propagated in the message headers, if any.
+	report *proto.Report   // Report parsed from the message body.
+}
+
 // Holds configuration settings for the message queue queue.
 type QueueConfig struct {
 	QueueName string // QueueName is the name of the queue.
@@ -87,7 +110,8 @@ func getConnection(rabbitMQURL string) (Connection, error) {
 func (mq *MQService) startConsumeLoop() {
 	mq.logger.Debug("listening for MQ messages...")
 
-	ctx, _ := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	reports, errors, err := mq.startConsumer(ctx, mqConsumerConcurrency)
 
@@ -99,34 +123,51 @@ func (mq *MQService) startConsumeLoop() {
 	for {
 		select {
 		case report := <-reports:
-			mq.reporterService.queueReportingTx(ProposeOutcome, report.MarketHash, report.Outcome)
+			mq.reporterService.queueReportingTx(report.ctx, ProposeOutcome, report.report.MarketHash, report.report.Outcome)
 		case err = <-errors:
 			mq.logger.Error("error while consuming from message queue", "err", err)
 			mq.logger.Debug("Restarting consumer...")
-			time.Sleep(2 * time.Second)
+			time.Sleep(mq.reconnectDelay())
 			reports, errors, err = mq.startConsumer(ctx, mqConsumerConcurrency)
 			if err != nil {
-				mq.logger.Error("Got Error during consumer restart", err)
+				mq.logger.Error("Got Error during consumer restart", "err", err)
 			}
 		case <-common.GetTerminationSignalCh():
 			mq.logger.Debug("got sigterm, shuttown down mq consumer")
 			mq.logger.Debug("Restarting consumer...")
-			time.Sleep(2 * time.Second)
+			time.Sleep(mq.reconnectDelay())
 			reports, errors, err = mq.startConsumer(ctx, mqConsumerConcurrency)
 			if err != nil {
-				mq.logger.Error("Got Error during consumer restart", err)
+				mq.logger.Error("Got Error during consumer restart", "err", err)
 			}
 
 		}
 	}
 }
 
+// Prefetches as many messages per consumer as configured. The prefetch count is set on the whole channel,
+// which only holds this service's consumers, as a per-consumer count would only apply to consumers started afterwards
+// and a reloaded count could not take effect until the consumer restarts.
+func (mq *MQService) applyPrefetchCount(concurrency int) error {
+	return mq.connection.Channel.Qos(mqPrefetchCount(mq.reporterService.config.Load())*concurrency, 0, true)
+}
+
+// Returns the number of messages prefetched by each consumer under the config.
+func mqPrefetchCount(config *ReporterConfig) int {
+	if config.MQConfig == nil || config.MQConfig.PrefetchCount <= 0 {
+		return defaultMQPrefetchCount
+	}
+
+	return config.MQConfig.PrefetchCount
+}
+
 // Initiates message consumption from the queue, receiving deliveries on the 'deliveries' channel.
 // It creates the queue if it doesn't exist, binds it to the exchange, and sets prefetching to optimize concurrency.
 // Returns parsed deliveries within the reports channel and any encountered errors within the errors channel.
+// Each delivery is traced, continuing the trace context propagated in its headers.
 func (mq *MQService) startConsumer(
 	ctx context.Context, concurrency int,
-) (<-chan *proto.Report, <-chan error, error) {
+) (<-chan *mqReport, <-chan error, error) {
 	// create the queue if it doesn't already exist
 	_, err := mq.connection.Channel.QueueDeclare(mq.config.QueueConfig.QueueName, true, false, false, false, nil)
 	if err != nil {
@@ -138,10 +179,9 @@ func (mq *MQService) startConsumer(
 	if err != nil {
 		return nil, nil, err
 	}
-	// prefetch 4x as many messages as we can handle at once
-	prefetchCount := concurrency * 4
 
-	err = mq.connection.Channel.Qos(prefetchCount, 0, false)
+	// prefetch as many messages per consumer as configured
+	err = mq.applyPrefetchCount(concurrency)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -161,13 +201,13 @@ func (mq *MQService) startConsumer(
 		return nil, nil, err
 	}
 
-	reports := make(chan *proto.Report)
+	reports := make(chan *mqReport)
 	errors := make(chan error)
 
 	for i := 0; i < concurrency; i++ {
 		go func() {
 			for delivery := range deliveries {
-				report, err := mq.parseDelivery(delivery)
+				report, err := mq.receive(delivery)
 				if err != nil {
 					errors <- err
 					//delivery.Nack(false, true) //nolint:errcheck
@@ -175,7 +215,10 @@ func (mq *MQService) startConsumer(
 					delivery.Ack(false) //nolint:errcheck
 				} else {
 					delivery.Ack(false) //nolint:errcheck
-					reports <- report
+					// skipped reports are dropped without restarting the consumer
+					if report != nil {
+						reports <- report
+					}
 				}
 			}
 		}()
@@ -191,8 +234,55 @@ func (mq *MQService) startConsumer(
 	return reports, errors, nil
 }
 
+// Parses the delivery into a report within a span continuing the trace context propagated in its headers.
+// The returned report carries the context of the span, so that the txs it leads to are traced as its children.
+// Returns no report and no error if the report is skipped.
+func (mq *MQService) receive(delivery amqp.Delivery) (*mqReport, error) {
+	ctx, span := mq.reporterService.tracer.Start(
+		extractAMQPTraceContext(context.Background(), delivery.Headers),
+		"mq.receive",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", mq.config.QueueConfig.QueueName),
+		),
+	)
+	defer span.End()
+
+	report, err := mq.parseDelivery(delivery)
+	if err != nil {
+		recordSpanError(span, err)
+
+		return nil, err
+	}
+
+	if report == nil {
+		span.AddEvent("skipped", trace.WithAttributes(attribute.String("reason", mqSkippedInvalidOutcome)))
+
+		return nil, nil
+	}
+
+	span.SetAttributes(
+		marketHashAttribute.String(report.MarketHash),
+		outcomeAttribute.Int64(int64(report.Outcome)),
+	)
+
+	return &mqReport{ctx: ctx, report: report}, nil
+}
+
+// Returns the delay before restarting a failed consumer.
+func (mq *MQService) reconnectDelay() time.Duration {
+	if delay := mq.reporterService.config.Load().MQConfig.ReconnectDelay; delay > 0 {
+		return delay
+	}
+
+	return defaultMQReconnectDelay
+}
+
 // Unmarshals the delivery body into a report or returns an error if parsing fails.
 // It checks for the presence of a message body and handles JSON unmarshaling errors.
+// Reports with a missing outcome, or an outcome not allowed by the contract, are skipped once recorded in the audit trail,
+// returning no report and no error, as the consumer is restarted on errors.
 func (mq *MQService) parseDelivery(delivery amqp.Delivery) (*proto.Report, error) {
 	if delivery.Body == nil {
 		return &proto.Report{}, fmt.Errorf("no message body")
@@ -203,7 +293,39 @@ func (mq *MQService) parseDelivery(delivery amqp.Delivery) (*proto.Report, error
 		return &proto.Report{}, fmt.Errorf("error during report outcome json unmarshaling, %w", err)
 	}
 
+	var outcome mqOutcome
+	if err := json.Unmarshal(delivery.Body, &outcome); err != nil {
+		return &proto.Report{}, fmt.Errorf("error during report outcome json unmarshaling, %w", err)
+	}
+
 	mq.logger.Debug("MQ message received", "marketHash", reportOutcome.MarketHash)
 
+	mq.reporterService.audit(&AuditEntry{
+		MarketHash: reportOutcome.MarketHash,
+		Event:      AuditMQMessage,
+		Function:   ProposeOutcome,
+		Outcome:    outcome.Outcome,
+		Message:    json.RawMessage(delivery.Body),
+	})
+
+	err := errMissingOutcome
+	if outcome.Outcome != nil {
+		_, err = ParseOutcome(int64(*outcome.Outcome))
+	}
+
+	if err != nil {
+		mq.logger.Warn("invalid outcome in MQ message, skipping", "marketHash", reportOutcome.MarketHash, "err", err)
+		mq.reporterService.audit(&AuditEntry{
+			MarketHash: reportOutcome.MarketHash,
+			Event:      AuditSkipped,
+			Function:   ProposeOutcome,
+			Outcome:    outcome.Outcome,
+			Reason:     mqSkippedInvalidOutcome,
+			Error:      err.Error(),
+		})
+
+		return nil, nil
+	}
+
 	return &reportOutcome, nil
 }
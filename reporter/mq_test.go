@@ -0,0 +1,83 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestMQParseDelivery(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		outcome int32
+		// error recorded in the audit trail for skipped reports
+		skipErr error
+	}{
+		{"outcome", `{"MarketHash":"` + testMarketA + `","Outcome":2}`, 2, nil},
+		{"void outcome", `{"marketHash":"` + testMarketA + `","outcome":0}`, 0, nil},
+		{"missing outcome", `{"MarketHash":"` + testMarketA + `"}`, 0, errMissingOutcome},
+		{"null outcome", `{"MarketHash":"` + testMarketA + `","Outcome":null}`, 0, errMissingOutcome},
+		{"outcome not allowed", `{"MarketHash":"` + testMarketA + `","Outcome":3}`, 0, errInvalidOutcome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestReporterService(t, nil, nil)
+			d.auditLog = newAuditLog(t.TempDir())
+
+			mq := &MQService{logger: hclog.NewNullLogger(), reporterService: d}
+
+			report, err := mq.parseDelivery(amqp.Delivery{Body: []byte(tt.body)})
+			if err != nil {
+				t.Fatalf("parseDelivery() error = %v", err)
+			}
+
+			entries, err := d.AuditTrail(testMarketA)
+			if err != nil {
+				t.Fatalf("AuditTrail() error = %v", err)
+			}
+
+			if tt.skipErr == nil {
+				if report == nil || report.Outcome != tt.outcome || len(entries) != 1 {
+					t.Errorf("parseDelivery() = %v with audit trail %+v, want outcome %d", report, entries, tt.outcome)
+				}
+
+				return
+			}
+
+			// skipped without an error, which would restart the consumer
+			if report != nil {
+				t.Errorf("parseDelivery() = %v, want the report skipped", report)
+			}
+
+			if len(entries) != 2 || entries[1].Event != AuditSkipped || entries[1].Reason != mqSkippedInvalidOutcome ||
+				!strings.HasPrefix(entries[1].Error, tt.skipErr.Error()) {
+				t.Errorf("audit trail = %+v, want the report skipped with %v", entries, tt.skipErr)
+			}
+
+			if tt.skipErr == errMissingOutcome && (entries[0].Outcome != nil || entries[1].Outcome != nil) {
+				t.Errorf("audit trail = %+v, want no outcome recorded", entries)
+			}
+		})
+	}
+}
+
+func TestMQParseDeliveryErrors(t *testing.T) {
+	mq := &MQService{logger: hclog.NewNullLogger(), reporterService: newTestReporterService(t, nil, nil)}
+
+	for name, body := range map[string][]byte{
+		"no body":             nil,
+		"invalid json":        []byte("{"),
+		"invalid outcome":     []byte(`{"MarketHash":"` + testMarketA + `","Outcome":"2"}`),
+		"invalid market hash": []byte(`{"MarketHash":1,"Outcome":2}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := mq.parseDelivery(amqp.Delivery{Body: body}); err == nil {
+				t.Error("parseDelivery() error = nil, want an error")
+			}
+		})
+	}
+}
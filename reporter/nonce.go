@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Number of times the transaction count of an account is fetched before its nonce is given up on.
+	nonceFetchAttempts = 4
+	// Delay before fetching the transaction count again, doubling with every failed attempt.
+	defaultNonceFetchBackoff = time.Second
+)
+
+// Hands out nonces for the account of a reporter key.
+// Each key sends its txs one at a time, so the manager only needs to make sure a mined nonce
+// is never reused while the node still reports a stale transaction count.
+type nonceManager struct {
+	address string                               // Account the nonces are managed for.
+	fetch   func(address string) (uint64, error) // Returns the node's transaction count of the account.
+	next    uint64                               // Nonce following the last mined tx.
+	gauge   prometheus.Gauge                     // Metric of the next nonce.
+	backoff time.Duration                        // Delay before the first refetch of a failed transaction count.
+	sync.Mutex
+}
+
+// Creates a nonce manager for the given account.
+func newNonceManager(address string, fetch func(address string) (uint64, error), gauge prometheus.Gauge) *nonceManager {
+	return &nonceManager{
+		address: address,
+		fetch:   fetch,
+		gauge:   gauge,
+		backoff: defaultNonceFetchBackoff,
+	}
+}
+
+// Returns the nonce of the next tx, the higher of the node's transaction count and the nonce following the last mined tx.
+// Retries after a nonce error or a failed receipt use it too, once the nonce they failed with is recorded as mined.
+// A failed fetch of the transaction count is retried with backoff, and no nonce is returned if every attempt fails,
+// as sending with an unverified nonce would replace or collide with the account's pending txs.
+func (n *nonceManager) current() (uint64, error) {
+	count, err := n.fetchCount()
+	if err != nil {
+		return 0, err
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	nonce := Max(count, n.next)
+	n.gauge.Set(float64(nonce))
+
+	return nonce, nil
+}
+
+// Fetches the node's transaction count of the account, retrying with backoff up to nonceFetchAttempts times.
+func (n *nonceManager) fetchCount() (uint64, error) {
+	backoff := n.backoff
+
+	for attempt := 1; ; attempt++ {
+		count, err := n.fetch(n.address)
+		if err == nil {
+			return count, nil
+		}
+
+		if attempt == nonceFetchAttempts {
+			return 0, fmt.Errorf("failed to fetch nonce of %s after %d attempts: %w", n.address, attempt, err)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// Records that the tx with the given nonce was mined, whether it succeeded or not.
+func (n *nonceManager) mined(nonce uint64) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.next = Max(n.next, nonce+1)
+	n.gauge.Set(float64(n.next))
+}
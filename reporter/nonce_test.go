@@ -0,0 +1,164 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sx-network/sx-reporter/reporter/proto"
+)
+
+// Transaction counts of accounts, failing the fetches of an account while it has failures left.
+type fakeTxCounts struct {
+	counts   map[string]uint64
+	failures map[string]int
+	fetches  map[string]int
+}
+
+func newFakeTxCounts() *fakeTxCounts {
+	return &fakeTxCounts{
+		counts:   make(map[string]uint64),
+		failures: make(map[string]int),
+		fetches:  make(map[string]int),
+	}
+}
+
+func (f *fakeTxCounts) fetch(address string) (uint64, error) {
+	f.fetches[address]++
+
+	if f.failures[address] > 0 {
+		f.failures[address]--
+
+		return 0, errors.New("connection refused")
+	}
+
+	return f.counts[address], nil
+}
+
+// Creates a nonce manager for the account, backing off for a millisecond between failed fetches.
+func newTestNonceManager(address string, counts *fakeTxCounts) *nonceManager {
+	nonces := newNonceManager(address, counts.fetch, prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_nonce"}))
+	nonces.backoff = time.Millisecond
+
+	return nonces
+}
+
+func TestNonceManagerCurrent(t *testing.T) {
+	counts := newFakeTxCounts()
+	counts.counts[testKeyAddress] = 5
+
+	nonces := newTestNonceManager(testKeyAddress, counts)
+
+	tests := []struct {
+		name  string
+		mined int64 // Nonce recorded as mined before the call, none if negative.
+		count uint64
+		want  uint64
+	}{
+		{"node's transaction count", -1, 5, 5},
+		{"stale transaction count after a mined tx", 5, 5, 6},
+		{"older mined tx", 2, 5, 6},
+		{"transaction count caught up", -1, 9, 9},
+	}
+
+	for _, tt := range tests {
+		if tt.mined >= 0 {
+			nonces.mined(uint64(tt.mined))
+		}
+
+		counts.counts[testKeyAddress] = tt.count
+
+		if nonce, err := nonces.current(); err != nil || nonce != tt.want {
+			t.Errorf("%s: current() = %d, %v, want %d", tt.name, nonce, err, tt.want)
+		}
+	}
+}
+
+func TestNonceManagerFetchRetry(t *testing.T) {
+	counts := newFakeTxCounts()
+	counts.counts[testKeyAddress] = 3
+	counts.failures[testKeyAddress] = nonceFetchAttempts - 1
+
+	nonces := newTestNonceManager(testKeyAddress, counts)
+
+	// a transient failure is retried
+	if nonce, err := nonces.current(); err != nil || nonce != 3 {
+		t.Fatalf("current() = %d, %v, want 3", nonce, err)
+	}
+
+	if fetches := counts.fetches[testKeyAddress]; fetches != nonceFetchAttempts {
+		t.Fatalf("fetched %d times, want %d", fetches, nonceFetchAttempts)
+	}
+
+	// no nonce is handed out once every attempt failed, even with a mined tx known
+	nonces.mined(3)
+	counts.failures[testKeyAddress] = nonceFetchAttempts
+
+	if nonce, err := nonces.current(); err == nil {
+		t.Errorf("current() = %d, want an error once every fetch failed", nonce)
+	}
+}
+
+func TestNonceManagersPerKey(t *testing.T) {
+	const otherAddress = "0x00000000000000000000000000000000000000b2"
+
+	counts := newFakeTxCounts()
+	counts.counts[testKeyAddress] = 10
+	counts.counts[otherAddress] = 2
+
+	first := newTestNonceManager(testKeyAddress, counts)
+	second := newTestNonceManager(otherAddress, counts)
+
+	first.mined(10)
+
+	if nonce, err := first.current(); err != nil || nonce != 11 {
+		t.Errorf("first key current() = %d, %v, want 11", nonce, err)
+	}
+
+	// a tx mined by one key leaves the nonces of the other untouched
+	if nonce, err := second.current(); err != nil || nonce != 2 {
+		t.Errorf("second key current() = %d, %v, want 2", nonce, err)
+	}
+
+	// a failing account does not fail the other
+	counts.failures[testKeyAddress] = nonceFetchAttempts
+
+	if _, err := first.current(); err == nil {
+		t.Error("first key current() error = nil, want an error once every fetch failed")
+	}
+
+	if nonce, err := second.current(); err != nil || nonce != 2 {
+		t.Errorf("second key current() = %d, %v, want 2", nonce, err)
+	}
+}
+
+func TestSendTxFailsWithoutNonce(t *testing.T) {
+	d := newTestReporterService(t, &ReporterConfig{SXNodeAddress: testOutcomeReporterAddress}, nil)
+	d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+	chain := newFakeChain(t, d)
+	chain.handle("eth_chainId", func([]json.RawMessage) (interface{}, error) {
+		return "0x1a0", nil
+	})
+
+	counts := newFakeTxCounts()
+	counts.failures[testKeyAddress] = nonceFetchAttempts
+
+	key := d.keys[0]
+	key.nonces = newTestNonceManager(testKeyAddress, counts)
+
+	receipt := d.sendTxWithRetry(&ReportingTx{functionType: ReportOutcome, key: key, report: &proto.Report{MarketHash: testMarketA}})
+	if receipt != nil {
+		t.Fatalf("sendTxWithRetry() = %+v, want no receipt without a nonce", receipt)
+	}
+
+	if sent := chain.count("eth_sendRawTransaction"); sent != 0 {
+		t.Errorf("sent %d txs with an unverified nonce, want none", sent)
+	}
+
+	if alerts := drainAlerts(d.alerter); len(alerts) != 1 || alerts[0].Rule != AlertTxFailed {
+		t.Errorf("queued %v, want a failed tx alert", alerts)
+	}
+}
@@ -0,0 +1,240 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Represents the type of backend alerts are sent to.
+type NotifierType string
+
+// Constants representing the supported notifier types.
+const (
+	// WebhookNotifier posts the alert as JSON to an HTTP endpoint
+	WebhookNotifier NotifierType = "webhook"
+	// SlackNotifier posts the alert as a message to a Slack incoming webhook
+	SlackNotifier NotifierType = "slack"
+	// PagerDutyNotifier triggers and resolves incidents with the PagerDuty Events API v2
+	PagerDutyNotifier NotifierType = "pagerduty"
+)
+
+const (
+	// Default timeout for notifier requests.
+	defaultNotifierTimeout = 10 * time.Second
+	// Default URL of the PagerDuty Events API v2.
+	defaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// Holds configuration options for an alert notifier.
+type NotifierConfig struct {
+	Type       NotifierType  // Type of the notifier.
+	URL        string        // Webhook URL, or Events API URL for PagerDuty, which defaults if empty.
+	RoutingKey string        // Integration routing key for PagerDuty.
+	Timeout    time.Duration // Timeout for notifier requests, defaults if zero.
+	Rules      []string      // Rules whose alerts are sent to the notifier, all enabled rules if nil.
+}
+
+// Sends alerts to an alerting backend.
+type Notifier interface {
+	// Type returns the type of the notifier.
+	Type() NotifierType
+	// Notify sends the alert, or its resolution, to the backend.
+	Notify(alert *Alert) error
+}
+
+// Creates the notifier described by the given configuration.
+func newNotifier(config *NotifierConfig) (Notifier, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultNotifierTimeout
+	}
+
+	switch config.Type {
+	case WebhookNotifier, SlackNotifier:
+		if config.URL == "" {
+			return nil, fmt.Errorf("no url specified for %s notifier", config.Type)
+		}
+
+		if config.Type == SlackNotifier {
+			return &slackNotifier{url: config.URL, timeout: timeout}, nil
+		}
+
+		return &webhookNotifier{url: config.URL, timeout: timeout}, nil
+	case PagerDutyNotifier:
+		if config.RoutingKey == "" {
+			return nil, fmt.Errorf("no routing key specified for %s notifier", config.Type)
+		}
+
+		url := config.URL
+		if url == "" {
+			url = defaultPagerDutyURL
+		}
+
+		return &pagerDutyNotifier{url: url, routingKey: config.RoutingKey, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("notifier type '%s' not found", config.Type)
+	}
+}
+
+// Posts alerts as JSON to a generic HTTP endpoint.
+type webhookNotifier struct {
+	url     string
+	timeout time.Duration
+}
+
+// Type returns the webhook notifier type.
+func (n *webhookNotifier) Type() NotifierType {
+	return WebhookNotifier
+}
+
+// Notify posts the alert as JSON.
+func (n *webhookNotifier) Notify(alert *Alert) error {
+	return postJSON(n.url, alert, n.timeout)
+}
+
+// Posts alerts as messages to a Slack incoming webhook.
+type slackNotifier struct {
+	url     string
+	timeout time.Duration
+}
+
+// Message posted to a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// Type returns the Slack notifier type.
+func (n *slackNotifier) Type() NotifierType {
+	return SlackNotifier
+}
+
+// Notify posts the alert as a Slack message, listing its details.
+func (n *slackNotifier) Notify(alert *Alert) error {
+	var text strings.Builder
+
+	status := strings.ToUpper(alert.Severity)
+	if alert.Resolved {
+		status = "RESOLVED"
+	}
+
+	fmt.Fprintf(&text, "*[%s] %s*\n%s", status, alert.Rule, alert.Summary)
+
+	for _, name := range alert.detailNames() {
+		fmt.Fprintf(&text, "\n• %s: `%s`", name, alert.Details[name])
+	}
+
+	fmt.Fprintf(&text, "\nsource: %s", alert.Source)
+
+	return postJSON(n.url, &slackMessage{Text: text.String()}, n.timeout)
+}
+
+// Triggers and resolves PagerDuty incidents, deduplicated by the alert's dedup key.
+type pagerDutyNotifier struct {
+	url        string
+	routingKey string
+	timeout    time.Duration
+}
+
+// Event sent to the PagerDuty Events API v2.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // trigger or resolve
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"` // Omitted on resolve.
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"` // critical, error, warning or info
+	Timestamp     time.Time         `json:"timestamp"`
+	Component     string            `json:"component"`
+	Class         string            `json:"class"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Type returns the PagerDuty notifier type.
+func (n *pagerDutyNotifier) Type() NotifierType {
+	return PagerDutyNotifier
+}
+
+// Notify triggers an incident for the alert, or resolves it.
+func (n *pagerDutyNotifier) Notify(alert *Alert) error {
+	event := &pagerDutyEvent{
+		RoutingKey:  n.routingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey,
+	}
+
+	if alert.Resolved {
+		event.EventAction = "resolve"
+	} else {
+		event.Payload = &pagerDutyPayload{
+			Summary:       alert.Summary,
+			Source:        alert.Source,
+			Severity:      alert.Severity,
+			Timestamp:     alert.Time,
+			Component:     "sx-reporter",
+			Class:         alert.Rule,
+			CustomDetails: alert.Details,
+		}
+	}
+
+	return postJSON(n.url, event, n.timeout)
+}
+
+// Returns the names of the alert's details, sorted.
+func (a *Alert) detailNames() []string {
+	names := make([]string, 0, len(a.Details))
+	for name := range a.Details {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Posts the payload as JSON to the URL, failing on non-2xx responses.
+func postJSON(rawURL string, payload interface{}, timeout time.Duration) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// the URL error holds the webhook URL, which may embed a token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Request received by a test notifier endpoint.
+type notifierRequest struct {
+	contentType string
+	body        []byte
+}
+
+// Starts an endpoint answering with the given status code and returns the requests it receives.
+func newNotifierServer(t *testing.T, status int) (*httptest.Server, <-chan notifierRequest) {
+	t.Helper()
+
+	requests := make(chan notifierRequest, alertQueueSize)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- notifierRequest{contentType: r.Header.Get("Content-Type"), body: body}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+// Returns the next request received by the endpoint, failing the test if none is received in time.
+func nextNotifierRequest(t *testing.T, requests <-chan notifierRequest) notifierRequest {
+	t.Helper()
+
+	select {
+	case request := <-requests:
+		if request.contentType != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", request.contentType)
+		}
+
+		return request
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifier request received")
+
+		return notifierRequest{}
+	}
+}
+
+func newTestAlert() *Alert {
+	return &Alert{
+		Rule:     AlertBalanceLow,
+		Severity: AlertCritical,
+		Summary:  "balance of key default is below the critical threshold",
+		DedupKey: "balance_low/default",
+		Details:  map[string]string{"key": "default", "balance": "0.5"},
+		Source:   "reporter-1",
+		Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	server, requests := newNotifierServer(t, http.StatusOK)
+
+	notifier, err := newNotifier(&NotifierConfig{Type: WebhookNotifier, URL: server.URL})
+	if err != nil {
+		t.Fatalf("newNotifier() error = %v", err)
+	}
+
+	alert := newTestAlert()
+	if err := notifier.Notify(alert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	var posted Alert
+	if err := json.Unmarshal(nextNotifierRequest(t, requests).body, &posted); err != nil {
+		t.Fatalf("invalid webhook payload, %v", err)
+	}
+
+	if posted.Rule != alert.Rule || posted.Severity != alert.Severity || posted.DedupKey != alert.DedupKey ||
+		posted.Details["balance"] != "0.5" || posted.Source != alert.Source || !posted.Time.Equal(alert.Time) {
+		t.Errorf("posted alert = %+v, want %+v", posted, alert)
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	server, requests := newNotifierServer(t, http.StatusOK)
+
+	notifier, err := newNotifier(&NotifierConfig{Type: SlackNotifier, URL: server.URL})
+	if err != nil {
+		t.Fatalf("newNotifier() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		resolved bool
+		text     string
+	}{
+		{
+			"alert",
+			false,
+			"*[CRITICAL] balance_low*\nbalance of key default is below the critical threshold" +
+				"\n• balance: `0.5`\n• key: `default`\nsource: reporter-1",
+		},
+		{
+			"resolution",
+			true,
+			"*[RESOLVED] balance_low*\nbalance of key default is below the critical threshold" +
+				"\n• balance: `0.5`\n• key: `default`\nsource: reporter-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := newTestAlert()
+			alert.Resolved = tt.resolved
+
+			if err := notifier.Notify(alert); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			var message slackMessage
+			if err := json.Unmarshal(nextNotifierRequest(t, requests).body, &message); err != nil {
+				t.Fatalf("invalid Slack payload, %v", err)
+			}
+
+			if message.Text != tt.text {
+				t.Errorf("Slack text = %q, want %q", message.Text, tt.text)
+			}
+		})
+	}
+}
+
+func TestPagerDutyNotifier(t *testing.T) {
+	server, requests := newNotifierServer(t, http.StatusAccepted)
+
+	notifier, err := newNotifier(&NotifierConfig{Type: PagerDutyNotifier, URL: server.URL, RoutingKey: "routing-key"})
+	if err != nil {
+		t.Fatalf("newNotifier() error = %v", err)
+	}
+
+	alert := newTestAlert()
+	if err := notifier.Notify(alert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	var trigger pagerDutyEvent
+	if err := json.Unmarshal(nextNotifierRequest(t, requests).body, &trigger); err != nil {
+		t.Fatalf("invalid PagerDuty payload, %v", err)
+	}
+
+	if trigger.RoutingKey != "routing-key" || trigger.EventAction != "trigger" || trigger.DedupKey != alert.DedupKey {
+		t.Errorf("trigger event = %+v, want a trigger of %s", trigger, alert.DedupKey)
+	}
+
+	if payload := trigger.Payload; payload == nil || payload.Summary != alert.Summary || payload.Severity != AlertCritical ||
+		payload.Source != alert.Source || payload.Class != alert.Rule || payload.CustomDetails["key"] != "default" {
+		t.Errorf("trigger payload = %+v, want the alert's", payload)
+	}
+
+	alert.Resolved = true
+	if err := notifier.Notify(alert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	// resolutions only carry the dedup key
+	var resolve map[string]interface{}
+	if err := json.Unmarshal(nextNotifierRequest(t, requests).body, &resolve); err != nil {
+		t.Fatalf("invalid PagerDuty payload, %v", err)
+	}
+
+	if resolve["event_action"] != "resolve" || resolve["dedup_key"] != alert.DedupKey || resolve["payload"] != nil {
+		t.Errorf("resolve event = %v, want a resolve of %s without payload", resolve, alert.DedupKey)
+	}
+}
+
+func TestNewNotifierDefaults(t *testing.T) {
+	notifier, err := newNotifier(&NotifierConfig{Type: PagerDutyNotifier, RoutingKey: "routing-key"})
+	if err != nil {
+		t.Fatalf("newNotifier() error = %v", err)
+	}
+
+	if pagerDuty := notifier.(*pagerDutyNotifier); pagerDuty.url != defaultPagerDutyURL || pagerDuty.timeout != defaultNotifierTimeout {
+		t.Errorf("PagerDuty notifier url = %s, timeout = %s, want the defaults", pagerDuty.url, pagerDuty.timeout)
+	}
+
+	for name, config := range map[string]*NotifierConfig{
+		"webhook without url":       {Type: WebhookNotifier},
+		"slack without url":         {Type: SlackNotifier},
+		"pagerduty without routing": {Type: PagerDutyNotifier},
+		"unknown type":              {Type: "email", URL: "https://example.com"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newNotifier(config); err == nil {
+				t.Error("newNotifier() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestPostJSONErrors(t *testing.T) {
+	server, _ := newNotifierServer(t, http.StatusInternalServerError)
+
+	if err := postJSON(server.URL, newTestAlert(), time.Second); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("postJSON() error = %v, want an unexpected status code error", err)
+	}
+
+	// the webhook URL may embed a token, which must not leak into the error
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	if err := postJSON(closed.URL+"/services/secret-token", newTestAlert(), time.Second); err == nil ||
+		strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("postJSON() error = %v, want a connection error without the URL", err)
+	}
+}
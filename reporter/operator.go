@@ -0,0 +1,160 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Response returned by the operator API voting period endpoint.
+type votingPeriodResponse struct {
+	VotingPeriodSeconds uint64    `json:"votingPeriodSeconds"`
+	LastRefresh         time.Time `json:"lastRefresh"`
+}
+
+// Response returned by the operator API health endpoint.
+type healthResponse struct {
+	Status               string                  `json:"status"`
+	LastEligibilityCheck time.Time               `json:"lastEligibilityCheck"`
+	Actions              map[string]ActionStatus `json:"actions"`
+	Keys                 map[string]*KeyStatus   `json:"keys"`
+	Balance              string                  `json:"balance"`            // Most severe balance level among the keys.
+	StakingEligibility   string                  `json:"stakingEligibility"` // Whether staking eligibility is checked.
+}
+
+// Response returned by the operator API audit trail endpoint.
+type auditTrailResponse struct {
+	MarketHash string        `json:"marketHash"`
+	Entries    []*AuditEntry `json:"entries"`
+}
+
+// Returns an HTTP handler serving the reporter operator API.
+func (d *ReporterService) OperatorHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", d.handleHealth)
+	mux.HandleFunc("GET /v1/voting-period", d.handleVotingPeriod)
+	mux.HandleFunc("GET /v1/rewards", d.handleRewards)
+	mux.HandleFunc("POST /v1/emergency-report", d.handleEmergencyReport)
+	mux.HandleFunc("GET /v1/audit", d.handleAuditTrail)
+	mux.HandleFunc("GET /v1/audit/export", d.handleAuditExport)
+
+	return mux
+}
+
+// Returns the node health, reported as degraded when no key selected by the routing rules can perform a reporting action
+// or when the balance of any key is below the critical threshold.
+func (d *ReporterService) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	actions, keys, lastCheck := d.EligibilityStatus()
+	balance := d.worstBalanceLevel()
+
+	status := "ok"
+	if balance == BalanceCritical {
+		status = "degraded"
+	}
+
+	for functionType, action := range actions {
+		// the emergency role is optional, so lacking it does not affect health
+		if !action.Allowed && functionType != EmergencyReportOutcome {
+			status = "degraded"
+		}
+	}
+
+	writeJSON(w, http.StatusOK, &healthResponse{
+		Status:               status,
+		LastEligibilityCheck: lastCheck,
+		Actions:              actions,
+		Keys:                 keys,
+		Balance:              balance,
+		StakingEligibility:   d.StakingEligibility(),
+	})
+}
+
+// Returns the cached on-chain voting period and the time it was last refreshed.
+func (d *ReporterService) handleVotingPeriod(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &votingPeriodResponse{
+		VotingPeriodSeconds: d.VotingPeriod(),
+		LastRefresh:         d.VotingPeriodLastRefresh(),
+	})
+}
+
+// Returns the juiced reporting rewards earned and withdrawn by the reporter keys.
+func (d *ReporterService) handleRewards(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, d.Rewards())
+}
+
+// Sends an emergency outcome report for the market and outcome in the request body.
+// The body must confirm the request and provide a reason, see EmergencyReportRequest.
+// The response is sent once the tx is sent, with its hash and nonce, the final result is recorded in the audit trail.
+func (d *ReporterService) handleEmergencyReport(w http.ResponseWriter, r *http.Request) {
+	request := &EmergencyReportRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	request.Requester = r.RemoteAddr
+
+	result, err := d.EmergencyReport(request)
+
+	switch {
+	case errors.Is(err, errEmergencyNotConfirmed),
+		errors.Is(err, errEmergencyNoReason),
+		errors.Is(err, errEmergencyNoMarketHash),
+		errors.Is(err, errInvalidMarketHash),
+		errors.Is(err, errInvalidOutcome):
+		writeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, errEmergencyNotPermitted):
+		writeError(w, http.StatusForbidden, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// Returns the audit trail recorded for the market given by the marketHash query parameter.
+func (d *ReporterService) handleAuditTrail(w http.ResponseWriter, r *http.Request) {
+	marketHash := r.URL.Query().Get("marketHash")
+
+	entries, err := d.AuditTrail(marketHash)
+
+	switch {
+	case errors.Is(err, errAuditNoMarketHash):
+		writeError(w, http.StatusBadRequest, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, &auditTrailResponse{
+			MarketHash: marketHash,
+			Entries:    entries,
+		})
+	}
+}
+
+// Streams the audit trail as JSON lines, restricted to the market given by the optional marketHash query parameter.
+func (d *ReporterService) handleAuditExport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	if err := d.ExportAuditTrail(w, r.URL.Query().Get("marketHash")); err != nil {
+		d.logger.Error("failed to export audit trail", "err", err)
+	}
+}
+
+// Writes the given error as a JSON response with the provided status code.
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, struct {
+		Error string `json:"error"`
+	}{
+		Error: err.Error(),
+	})
+}
+
+// Writes the given value as a JSON response with the provided status code.
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(value)
+}
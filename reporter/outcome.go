@@ -0,0 +1,51 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Represents a market outcome, mirroring the OutcomeReporter contract's LibOutcome.Outcome enum.
+type Outcome uint8
+
+// Constants representing the outcomes allowed by the contract.
+const (
+	// OutcomeVoid pertains to a voided market, refunding its bets
+	OutcomeVoid Outcome = 0
+	// OutcomeOne pertains to the first outcome of the market winning
+	OutcomeOne Outcome = 1
+	// OutcomeTwo pertains to the second outcome of the market winning
+	OutcomeTwo Outcome = 2
+)
+
+var (
+	errInvalidOutcome = errors.New("invalid outcome")
+	errMissingOutcome = errors.New("missing outcome")
+)
+
+// ParseOutcome returns the outcome of the raw value, or an error if the contract does not allow it.
+func ParseOutcome(value int64) (Outcome, error) {
+	if value < int64(OutcomeVoid) || value > int64(OutcomeTwo) {
+		return 0, fmt.Errorf(
+			"%w %d, expected %d (%s), %d (%s) or %d (%s)",
+			errInvalidOutcome, value,
+			OutcomeVoid, OutcomeVoid, OutcomeOne, OutcomeOne, OutcomeTwo, OutcomeTwo,
+		)
+	}
+
+	return Outcome(value), nil
+}
+
+// String returns the name of the outcome.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeVoid:
+		return "void"
+	case OutcomeOne:
+		return "outcome one"
+	case OutcomeTwo:
+		return "outcome two"
+	default:
+		return fmt.Sprintf("unknown outcome %d", uint8(o))
+	}
+}
@@ -0,0 +1,36 @@
+package reporter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseOutcome(t *testing.T) {
+	tests := []struct {
+		value   int64
+		outcome Outcome
+		wantErr bool
+	}{
+		{0, OutcomeVoid, false},
+		{1, OutcomeOne, false},
+		{2, OutcomeTwo, false},
+		{-1, 0, true},
+		{3, 0, true},
+		{256, 0, true},
+	}
+
+	for _, tt := range tests {
+		outcome, err := ParseOutcome(tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, errInvalidOutcome) {
+				t.Errorf("ParseOutcome(%d) error = %v, want %v", tt.value, err, errInvalidOutcome)
+			}
+
+			continue
+		}
+
+		if err != nil || outcome != tt.outcome {
+			t.Errorf("ParseOutcome(%d) = %s, %v, want %s", tt.value, outcome, err, tt.outcome)
+		}
+	}
+}
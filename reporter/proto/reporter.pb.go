@@ -13,5 +13,19 @@ type Report struct {
 	Outcome int32 `protobuf:"varint,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
 }
 
+func (x *Report) GetMarketHash() string {
+	if x != nil {
+		return x.MarketHash
+	}
+	return ""
+}
+
+func (x *Report) GetOutcome() int32 {
+	if x != nil {
+		return x.Outcome
+	}
+	return 0
+}
+
 type UnimplementedDataFeedOperatorServer struct {
 }
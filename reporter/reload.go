@@ -0,0 +1,43 @@
+package reporter
+
+import (
+	"fmt"
+)
+
+// Reload replaces the reporter config with the provided one at runtime.
+// Only options read on use take effect, such as the verify outcome API, timeouts, gas policy, AMQP reconnect delay,
+// reward withdrawal, roles, balance thresholds and vote policy, along with the AMQP prefetch count, which is re-applied
+// to the consumer's channel. Keys, routing, endpoints and contract addresses
+// are expected to be unchanged, the caller rejects changes to them.
+func (d *ReporterService) Reload(config *ReporterConfig) error {
+	if err := validateBalanceThresholds(config.BalanceWarningThreshold, config.BalanceCriticalThreshold); err != nil {
+		return err
+	}
+
+	if err := config.Gas.validate(); err != nil {
+		return err
+	}
+
+	if err := config.VotePolicy.validate(); err != nil {
+		return err
+	}
+
+	current := d.config.Load()
+	if (current.VerifyOutcomeURI == "") != (config.VerifyOutcomeURI == "") {
+		return fmt.Errorf("reporter 'verify_outcome_api_url' cannot be enabled or disabled without a restart")
+	}
+
+	d.config.Store(config)
+
+	if d.mqService != nil && mqPrefetchCount(current) != mqPrefetchCount(config) {
+		if err := d.mqService.applyPrefetchCount(mqConsumerConcurrency); err != nil {
+			d.logger.Error("failed to apply the AMQP prefetch count, it applies once the consumer restarts", "err", err)
+		}
+	}
+
+	// re-check the roles and balances against the new config
+	d.checkEligibility()
+	d.checkBalances()
+
+	return nil
+}
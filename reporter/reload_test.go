@@ -0,0 +1,76 @@
+package reporter
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	current := &ReporterConfig{
+		OutcomeReporterAddress:  testOutcomeReporterAddress,
+		VerifyOutcomeURI:        "http://127.0.0.1:8080/verify",
+		BalanceWarningThreshold: big.NewInt(100),
+	}
+
+	d := newTestReporterService(t, current, nil)
+	d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+	newFakeChain(t, d)
+
+	tests := []struct {
+		name    string
+		config  ReporterConfig
+		wantErr string
+	}{
+		{
+			"balance thresholds",
+			ReporterConfig{VerifyOutcomeURI: current.VerifyOutcomeURI, BalanceWarningThreshold: big.NewInt(10), BalanceCriticalThreshold: big.NewInt(20)},
+			"reporter balance warning threshold 10 is below the critical threshold 20",
+		},
+		{
+			"gas policy",
+			ReporterConfig{VerifyOutcomeURI: current.VerifyOutcomeURI, Gas: &GasConfig{MaxTxTries: -1}},
+			"reporter gas max tx tries -1 is negative",
+		},
+		{
+			"vote policy",
+			ReporterConfig{VerifyOutcomeURI: current.VerifyOutcomeURI, VotePolicy: &VotePolicyConfig{Policy: "guess"}},
+			"",
+		},
+		{
+			"disabling verification",
+			ReporterConfig{},
+			"reporter 'verify_outcome_api_url' cannot be enabled or disabled without a restart",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+
+			err := d.Reload(&config)
+			if err == nil || (tt.wantErr != "" && err.Error() != tt.wantErr) {
+				t.Fatalf("Reload() error = %v, want %s", err, tt.wantErr)
+			}
+
+			// a rejected config is not applied
+			if d.config.Load() != current {
+				t.Error("rejected config applied")
+			}
+		})
+	}
+
+	reloaded := &ReporterConfig{
+		OutcomeReporterAddress:  testOutcomeReporterAddress,
+		VerifyOutcomeURI:        "http://127.0.0.1:8081/verify",
+		BalanceWarningThreshold: big.NewInt(200),
+		VotePolicy:              &VotePolicyConfig{Policy: AbstainVotePolicy},
+	}
+
+	if err := d.Reload(reloaded); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if d.config.Load() != reloaded {
+		t.Error("reloaded config not applied")
+	}
+}
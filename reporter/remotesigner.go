@@ -0,0 +1,127 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/umbracle/ethgo"
+)
+
+// Signs transactions with an external signer over HTTP JSON-RPC, so the reporter key never enters this process.
+// Web3Signer is called with eth_signTransaction and Clef with account_signTransaction.
+type remoteSigner struct {
+	config  *SignerConfig
+	client  *rpc.Client
+	address ethgo.Address
+}
+
+// Transaction arguments sent to a remote signer, following the eth_signTransaction object format.
+type remoteSignerTxArgs struct {
+	From     common.MixedcaseAddress  `json:"from"`
+	To       *common.MixedcaseAddress `json:"to,omitempty"`
+	Gas      hexutil.Uint64           `json:"gas"`
+	GasPrice *hexutil.Big             `json:"gasPrice"`
+	Value    *hexutil.Big             `json:"value"`
+	Nonce    hexutil.Uint64           `json:"nonce"`
+	Data     hexutil.Bytes            `json:"data"`
+	ChainID  *hexutil.Big             `json:"chainId"`
+}
+
+// Result returned by Clef's account_signTransaction.
+type clefSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Creates a signer for the account held by the remote signer at the configured URL.
+func newRemoteSigner(config *SignerConfig) (*remoteSigner, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("no url specified for %s signer", config.Type)
+	}
+
+	if !common.IsHexAddress(config.Address) {
+		return nil, fmt.Errorf("invalid reporter address '%s' for %s signer", config.Address, config.Type)
+	}
+
+	client, err := rpc.DialOptions(context.Background(), config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s signer, %w", config.Type, err)
+	}
+
+	return &remoteSigner{
+		config:  config,
+		client:  client,
+		address: ethgo.HexToAddress(config.Address),
+	}, nil
+}
+
+// Type returns the configured remote signer type.
+func (s *remoteSigner) Type() SignerType {
+	return s.config.Type
+}
+
+// Address returns the configured reporter address.
+func (s *remoteSigner) Address() ethgo.Address {
+	return s.address
+}
+
+// SignTx sends the transaction to the remote signer and returns the signed RLP encoding it produces.
+// The signed transaction is decoded and rejected unless it is the requested transaction signed by the configured address.
+func (s *remoteSigner) SignTx(tx *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	timeout := s.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultRemoteSignerTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	args := &remoteSignerTxArgs{
+		From:     common.NewMixedcaseAddress(common.Address(s.address)),
+		Gas:      hexutil.Uint64(tx.Gas),
+		GasPrice: (*hexutil.Big)(new(big.Int).SetUint64(tx.GasPrice)),
+		Value:    (*hexutil.Big)(new(big.Int)),
+		Nonce:    hexutil.Uint64(tx.Nonce),
+		Data:     tx.Input,
+		ChainID:  (*hexutil.Big)(chainID),
+	}
+
+	if tx.To != nil {
+		to := common.NewMixedcaseAddress(common.Address(*tx.To))
+		args.To = &to
+	}
+
+	if tx.Value != nil {
+		args.Value = (*hexutil.Big)(tx.Value)
+	}
+
+	var raw hexutil.Bytes
+
+	switch s.config.Type {
+	case ClefSigner:
+		var result clefSignTxResult
+		if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+			return nil, fmt.Errorf("clef signing request failed, %w", err)
+		}
+
+		raw = result.Raw
+	default:
+		if err := s.client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+			return nil, fmt.Errorf("web3signer signing request failed, %w", err)
+		}
+	}
+
+	if len(raw) == 0 {
+		return nil, errors.New("remote signer returned an empty transaction")
+	}
+
+	if err := verifySignedTx(raw, s.address, tx, chainID); err != nil {
+		return nil, fmt.Errorf("remote signer returned an unexpected transaction, %w", err)
+	}
+
+	return raw, nil
+}
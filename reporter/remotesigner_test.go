@@ -0,0 +1,359 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/umbracle/ethgo"
+)
+
+const (
+	testSignerAddress = testKeyAddress
+	testSignerTo      = "0x00000000000000000000000000000000000000aa"
+)
+
+// JSON-RPC request received by the stub signer.
+type stubSignerRequest struct {
+	ID     json.RawMessage      `json:"id"`
+	Method string               `json:"method"`
+	Params []remoteSignerTxArgs `json:"params"`
+}
+
+// Starts a JSON-RPC server standing in for a remote signer, answering every request with respond.
+// The requests received are sent to the returned channel.
+func newStubSigner(
+	t *testing.T,
+	respond func(request *stubSignerRequest) (result interface{}, errMessage string),
+) (*httptest.Server, <-chan *stubSignerRequest) {
+	t.Helper()
+
+	requests := make(chan *stubSignerRequest, 8)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &stubSignerRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		requests <- request
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		result, errMessage := respond(request)
+		if errMessage != "" {
+			response["error"] = map[string]interface{}{"code": -32000, "message": errMessage}
+		} else {
+			response["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+// Signs the transaction described by the signer request with the given key, as a remote signer holding it would,
+// applying modify to the transaction first. The requested chain ID is used unless chainID is set.
+func signStubTx(
+	t *testing.T,
+	args remoteSignerTxArgs,
+	keyHex string,
+	chainID *big.Int,
+	modify func(tx *types.LegacyTx),
+) hexutil.Bytes {
+	t.Helper()
+
+	key, err := crypto.HexToECDSA(keyHex)
+	if err != nil {
+		t.Fatalf("HexToECDSA() error = %v", err)
+	}
+
+	tx := &types.LegacyTx{
+		Nonce:    uint64(args.Nonce),
+		GasPrice: args.GasPrice.ToInt(),
+		Gas:      uint64(args.Gas),
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	}
+
+	if args.To != nil {
+		to := args.To.Address()
+		tx.To = &to
+	}
+
+	if chainID == nil {
+		chainID = args.ChainID.ToInt()
+	}
+
+	if modify != nil {
+		modify(tx)
+	}
+
+	signed, err := types.SignNewTx(key, types.NewEIP155Signer(chainID), tx)
+	if err != nil {
+		t.Fatalf("SignNewTx() error = %v", err)
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	return raw
+}
+
+// Returns the transaction signed in the tests.
+func testSignerTx() *ethgo.Transaction {
+	to := ethgo.HexToAddress(testSignerTo)
+
+	return &ethgo.Transaction{
+		From:     ethgo.HexToAddress(testSignerAddress),
+		To:       &to,
+		Input:    []byte{0xca, 0xfe},
+		GasPrice: 1000000000,
+		Gas:      21000,
+		Nonce:    7,
+		Value:    big.NewInt(5),
+	}
+}
+
+// Checks the transaction arguments sent to the signer match the signed transaction.
+func checkSignerTxArgs(t *testing.T, args remoteSignerTxArgs) {
+	t.Helper()
+
+	if !strings.EqualFold(args.From.Address().Hex(), testSignerAddress) {
+		t.Errorf("from = %s, want %s", args.From.Address().Hex(), testSignerAddress)
+	}
+
+	if args.To == nil || !strings.EqualFold(args.To.Address().Hex(), testSignerTo) {
+		t.Errorf("to = %v, want %s", args.To, testSignerTo)
+	}
+
+	if args.Gas != 21000 || args.Nonce != 7 {
+		t.Errorf("gas, nonce = %d, %d, want 21000, 7", args.Gas, args.Nonce)
+	}
+
+	if args.GasPrice.ToInt().Int64() != 1000000000 || args.Value.ToInt().Int64() != 5 || args.ChainID.ToInt().Int64() != 416 {
+		t.Errorf("gasPrice, value, chainId = %s, %s, %s, want 1000000000, 5, 416", args.GasPrice, args.Value, args.ChainID)
+	}
+
+	if !bytes.Equal(args.Data, []byte{0xca, 0xfe}) {
+		t.Errorf("data = %s, want 0xcafe", args.Data)
+	}
+}
+
+func TestRemoteSignerWeb3Signer(t *testing.T) {
+	var signed hexutil.Bytes
+
+	server, requests := newStubSigner(t, func(request *stubSignerRequest) (interface{}, string) {
+		signed = signStubTx(t, request.Params[0], testKeyHex, nil, nil)
+
+		return signed, ""
+	})
+
+	signer, err := newSigner(&SignerConfig{Type: Web3Signer, URL: server.URL, Address: testSignerAddress}, nil, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	if signer.Type() != Web3Signer || signer.Address() != ethgo.HexToAddress(testSignerAddress) {
+		t.Fatalf("signer = %s %s, want %s %s", signer.Type(), signer.Address(), Web3Signer, testSignerAddress)
+	}
+
+	raw, err := signer.SignTx(testSignerTx(), big.NewInt(416))
+	if err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+
+	if !bytes.Equal(raw, signed) {
+		t.Errorf("SignTx() = %x, want %x", raw, []byte(signed))
+	}
+
+	request := <-requests
+	if request.Method != "eth_signTransaction" || len(request.Params) != 1 {
+		t.Fatalf("request = %s with %d params, want eth_signTransaction with 1", request.Method, len(request.Params))
+	}
+
+	checkSignerTxArgs(t, request.Params[0])
+}
+
+func TestRemoteSignerClef(t *testing.T) {
+	var signed hexutil.Bytes
+
+	server, requests := newStubSigner(t, func(request *stubSignerRequest) (interface{}, string) {
+		signed = signStubTx(t, request.Params[0], testKeyHex, nil, nil)
+
+		return map[string]interface{}{"raw": signed, "tx": map[string]string{}}, ""
+	})
+
+	signer, err := newSigner(&SignerConfig{Type: ClefSigner, URL: server.URL, Address: testSignerAddress}, nil, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	raw, err := signer.SignTx(testSignerTx(), big.NewInt(416))
+	if err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+
+	if !bytes.Equal(raw, signed) {
+		t.Errorf("SignTx() = %x, want %x", raw, []byte(signed))
+	}
+
+	request := <-requests
+	if request.Method != "account_signTransaction" || len(request.Params) != 1 {
+		t.Fatalf("request = %s with %d params, want account_signTransaction with 1", request.Method, len(request.Params))
+	}
+
+	checkSignerTxArgs(t, request.Params[0])
+}
+
+func TestRemoteSignerFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		signerType SignerType
+		result     interface{}
+		errMessage string
+		wantErr    string
+	}{
+		{"web3signer rejection", Web3Signer, nil, "account locked", "web3signer signing request failed, account locked"},
+		{"clef rejection", ClefSigner, nil, "Request denied", "clef signing request failed, Request denied"},
+		{"empty transaction", Web3Signer, hexutil.Bytes{}, "", "remote signer returned an empty transaction"},
+		{"empty clef transaction", ClefSigner, map[string]interface{}{}, "", "remote signer returned an empty transaction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newStubSigner(t, func(*stubSignerRequest) (interface{}, string) {
+				return tt.result, tt.errMessage
+			})
+
+			signer, err := newSigner(&SignerConfig{Type: tt.signerType, URL: server.URL, Address: testSignerAddress}, nil, "")
+			if err != nil {
+				t.Fatalf("newSigner() error = %v", err)
+			}
+
+			if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("SignTx() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerRejectsUnexpectedTx(t *testing.T) {
+	otherKeyHex := "8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63"
+
+	tests := []struct {
+		name    string
+		keyHex  string
+		chainID *big.Int
+		modify  func(tx *types.LegacyTx)
+		wantErr string
+	}{
+		{"other sender", otherKeyHex, nil, nil, "sender"},
+		{"chain id", testKeyHex, big.NewInt(1), nil, "chainId 1, expected 416"},
+		{"nonce", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Nonce++ }, "nonce 8, expected 7"},
+		{"to", testKeyHex, nil, func(tx *types.LegacyTx) { tx.To = &common.Address{0xbb} }, "to"},
+		{"contract creation", testKeyHex, nil, func(tx *types.LegacyTx) { tx.To = nil }, "to <nil>"},
+		{"data", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Data = []byte{0xbe, 0xef} }, "data beef, expected cafe"},
+		{"value", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Value = big.NewInt(6) }, "value 6, expected 5"},
+		{"gas", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Gas = 50000 }, "gas 50000, expected 21000"},
+		{"gas price", testKeyHex, nil, func(tx *types.LegacyTx) { tx.GasPrice = big.NewInt(1) }, "gasPrice 1, expected 1000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newStubSigner(t, func(request *stubSignerRequest) (interface{}, string) {
+				return signStubTx(t, request.Params[0], tt.keyHex, tt.chainID, tt.modify), ""
+			})
+
+			signer, err := newSigner(&SignerConfig{Type: Web3Signer, URL: server.URL, Address: testSignerAddress}, nil, "")
+			if err != nil {
+				t.Fatalf("newSigner() error = %v", err)
+			}
+
+			wantErr := "remote signer returned an unexpected transaction, " + tt.wantErr
+			if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil || !strings.HasPrefix(err.Error(), wantErr) {
+				t.Errorf("SignTx() error = %v, want %s", err, wantErr)
+			}
+		})
+	}
+
+	// a response that is not a signed transaction is rejected too
+	server, _ := newStubSigner(t, func(*stubSignerRequest) (interface{}, string) {
+		return hexutil.Bytes{0xf8, 0x6b, 0x01}, ""
+	})
+
+	signer, err := newSigner(&SignerConfig{Type: Web3Signer, URL: server.URL, Address: testSignerAddress}, nil, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil ||
+		!strings.HasPrefix(err.Error(), "remote signer returned an unexpected transaction, failed to decode it") {
+		t.Errorf("SignTx() error = %v, want a decoding error", err)
+	}
+}
+
+func TestRemoteSignerTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	signer, err := newSigner(&SignerConfig{
+		Type:    Web3Signer,
+		URL:     server.URL,
+		Address: testSignerAddress,
+		Timeout: 50 * time.Millisecond,
+	}, nil, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil {
+		t.Error("SignTx() error = nil, want a timeout")
+	}
+}
+
+func TestNewRemoteSignerConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SignerConfig
+		wantErr string
+	}{
+		{"missing url", &SignerConfig{Type: ClefSigner, Address: testSignerAddress}, "no url specified for clef signer"},
+		{
+			"invalid address",
+			&SignerConfig{Type: Web3Signer, URL: "http://127.0.0.1:9000", Address: "0x1234"},
+			"invalid reporter address '0x1234' for web3signer signer",
+		},
+		{"unknown type", &SignerConfig{Type: "hsm"}, "signer type 'hsm' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newSigner(tt.config, nil, ""); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("newSigner() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
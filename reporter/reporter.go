@@ -1,40 +1,103 @@
 package reporter
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/reporter/proto"
+	"github.com/umbracle/ethgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Holds configuration options for the reporter service.
 type ReporterConfig struct {
-	MQConfig                   *MQConfig // Configuration for message queue.
-	VerifyOutcomeURI           string    // URI for verifying outcomes.
-	OutcomeVotingPeriodSeconds uint64    // Duration of the outcome voting period in seconds.
-	OutcomeReporterAddress     string    // Address of the outcome reporter.
-	SXNodeAddress              string    // Address of the SX node.
+	MQConfig                    *MQConfig            // Configuration for message queue.
+	VerifyOutcomeURI            string               // URI for verifying outcomes.
+	VotingPeriodRefreshInterval time.Duration        // Interval between scheduled refreshes of the on-chain voting period.
+	OutcomeReporterAddress      string               // Address of the outcome reporter.
+	SXNodeAddress               string               // Address of the SX node.
+	ProposeRole                 string               // Role required to propose outcomes, unchecked if empty or UncheckedRole.
+	VoteRole                    string               // Role required to vote on outcomes, unchecked if empty or UncheckedRole.
+	ReportRole                  string               // Role required to report outcomes, unchecked if empty or UncheckedRole.
+	StakingAddress              string               // Address of the staking contract checked for voting and reporting, unchecked if empty.
+	StakingEligibilityFunction  string               // Signature of the staking contract view function checking an account's eligibility.
+	EligibilityRefreshInterval  time.Duration        // Interval between scheduled role eligibility checks.
+	DataDir                     string               // Directory for persisting reporter state.
+	AutoWithdrawRewards         bool                 // Whether juiced reporting rewards are withdrawn automatically.
+	RewardWithdrawThreshold     *big.Int             // Minimum available rewards in wei before withdrawing.
+	RewardPayoutAddress         string               // Address withdrawn rewards are forwarded to, if not the reporter address.
+	WSXAddress                  string               // Address of the WSX token the rewards are paid in.
+	Signer                      *SignerConfig        // Signer for reporting transactions, the secrets manager key is used if nil.
+	Keys                        []*ReporterKeyConfig // Named reporter keys, a single default key using Signer is used if empty.
+	Routing                     map[string]string    // Routing rule by function type, a key name, RoundRobin or AllKeys.
+	BalanceCheckInterval        time.Duration        // Interval between scheduled balance checks of the reporter keys.
+	BalanceWarningThreshold     *big.Int             // Balance in wei below which a warning is raised, unchecked if nil.
+	BalanceCriticalThreshold    *big.Int             // Balance in wei below which low funds functions are paused, unchecked if nil.
+	LowFundsPausedFunctions     []string             // Functions paused while a key's balance is critical, defaults if nil.
+	JSONRPCURL                  string               // URL of the JSON-RPC HTTP endpoint.
+	JSONRPCWsURL                string               // URL of the JSON-RPC WebSocket endpoint used for event subscriptions.
+	RPCTimeout                  time.Duration        // Timeout for JSON-RPC requests made outside the ethgo client.
+	VerifyOutcomeTimeout        time.Duration        // Timeout for verify outcome API requests.
+	TxConfirmationTimeout       time.Duration        // Time to wait for a sent tx to be mined before giving up.
+	Gas                         *GasConfig           // Gas policy for reporting txs, defaults are used if nil.
+	TracerProvider              trace.TracerProvider // Provider of the spans tracing markets, the global provider is used if nil.
+	Alerting                    *AlertingConfig      // Alerting rules and notifiers, alerting is disabled if nil.
+	VotePolicy                  *VotePolicyConfig    // Policy for votes on markets that could not be verified, defaults are used if nil.
 }
 
 // Represents a transaction for reporting.
 type ReportingTx struct {
-	functionType string        // Type of the function for reporting.
-	report       *proto.Report // Report data.
+	functionType string                // Type of the function for reporting.
+	report       *proto.Report         // Report data.
+	amount       *big.Int              // Token amount for reward withdrawals and transfers.
+	recipient    string                // Recipient address for reward transfers.
+	result       chan<- *ethgo.Receipt // Receives the success receipt, or nil on failure, if set.
+	sent         chan<- *sentTx        // Receives the hash and nonce of the first attempt sent to the node, if set.
+	key          *reporterKey          // Key sending the tx, set when queued.
+	ctx          context.Context       // Trace context of the request queueing the tx, if any.
+}
+
+// Describes a reporting tx sent to the node, which may not be mined yet.
+type sentTx struct {
+	hash  ethgo.Hash // Hash of the sent tx.
+	nonce uint64     // Nonce of the sent tx.
+}
+
+// Returns the trace context of the request queueing the tx, or an empty context if none.
+func (r *ReportingTx) context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
+	return r.ctx
 }
 
 // Orchestrates various components of the reporter service.
 type ReporterService struct {
 	logger                                    hclog.Logger // Logger for reporting.
 	secretsManager                            secrets.SecretsManager
-	config                                    *ReporterConfig   // Configuration for the reporter.
-	mqService                                 *MQService        // AMQP message consumer.
-	txService                                 *TxService        // JSON-RPC transaction sender.
-	eventListener                             *EventListener    // Listener for blockchain events.
-	storeProcessor                            *StoreProcessor   // Processor for market items.
-	reportingTxChan                           chan *ReportingTx // Channel for queuing reporting transactions.
-	proto.UnimplementedDataFeedOperatorServer                   // DataFeed operator commands implementation.
+	keys                                      []*reporterKey                 // Reporter keys operated by the node.
+	router                                    *keyRouter                     // Selects the keys sending reporting txs.
+	config                                    atomic.Pointer[ReporterConfig] // Configuration for the reporter, replaced on reload.
+	mqService                                 *MQService                     // AMQP message consumer.
+	txService                                 *TxService                     // JSON-RPC transaction sender.
+	eventListener                             *EventListener                 // Listener for blockchain events.
+	scheduler                                 *ReportScheduler               // Scheduler for reportOutcome txs.
+	eligibilityChecks                         chan struct{}                  // Requests an eligibility check from the refresh loop.
+	voteScheduler                             *VoteScheduler                 // Scheduler verifying markets and queueing voteOutcome txs.
+	votingPeriod                              votingPeriodCache              // Cached on-chain outcome voting period.
+	auditLog                                  *auditLog                      // Audit trail of the handling of each market.
+	outcomes                                  *outcomeTracker                // Outcomes of the markets being voted on.
+	alerter                                   *alerter                       // Sends alerts to the notifiers, nil if alerting is disabled.
+	metrics                                   *Metrics                       // Prometheus metrics.
+	tracer                                    trace.Tracer                   // Tracer of the spans following markets.
+	proto.UnimplementedDataFeedOperatorServer                                // DataFeed operator commands implementation.
 	// lock                                      sync.Mutex        // Mutex for synchronization.
 }
 
@@ -46,10 +109,61 @@ func NewReporterService(
 	secretsManager *secrets.SecretsManager,
 ) (*ReporterService, error) {
 	reporterService := &ReporterService{
-		logger:          logger.Named("reporter"),
-		config:          config,
-		reportingTxChan: make(chan *ReportingTx, 100),
-		secretsManager:  *secretsManager,
+		logger:            logger.Named("reporter"),
+		secretsManager:    *secretsManager,
+		metrics:           newMetrics(),
+		auditLog:          newAuditLog(config.DataDir),
+		outcomes:          newOutcomeTracker(),
+		eligibilityChecks: make(chan struct{}, 1),
+		tracer:            newTracer(config.TracerProvider),
+	}
+
+	if config.JSONRPCURL == "" {
+		return nil, fmt.Errorf("reporter JSON-RPC URL is missing")
+	}
+
+	if err := validateBalanceThresholds(config.BalanceWarningThreshold, config.BalanceCriticalThreshold); err != nil {
+		return nil, err
+	}
+
+	if err := config.Gas.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := config.VotePolicy.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateStaking(config.StakingAddress, config.StakingEligibilityFunction); err != nil {
+		return nil, err
+	}
+
+	reporterService.config.Store(config)
+
+	alerter, err := newAlerter(reporterService.logger, reporterService.metrics, config.Alerting)
+	if err != nil {
+		return nil, err
+	}
+	reporterService.alerter = alerter
+
+	keys, err := reporterService.setupKeys()
+	if err != nil {
+		return nil, err
+	}
+	reporterService.keys = keys
+
+	router, err := newKeyRouter(keys, config.Routing)
+	if err != nil {
+		return nil, err
+	}
+	reporterService.router = router
+
+	for _, key := range keys {
+		reporterService.updateRewardMetrics(key)
+
+		if reporterService.hasExternalPayoutAddress(key) && config.WSXAddress == "" {
+			return nil, fmt.Errorf("reporter 'reward_payout_address' provided but missing a valid 'wsx_address'")
+		}
 	}
 
 	if config.MQConfig.AMQPURI != "" {
@@ -69,13 +183,25 @@ func NewReporterService(
 		reporterService.mqService = mqService
 	}
 
-	txService, err := newTxService(reporterService.logger)
+	txService, err := newTxService(reporterService.logger, config.JSONRPCURL)
 	if err != nil {
 		return nil, err
 	}
 	reporterService.txService = txService
 
-	go reporterService.processTxsFromQueue()
+	if !reporterService.isStakingChecked() {
+		reporterService.logger.Warn("staking eligibility unchecked, 'staking_address' and 'staking_eligibility_function' are not configured")
+	}
+
+	reporterService.checkEligibility()
+	reporterService.checkBalances()
+
+	go reporterService.startEligibilityRefreshLoop()
+	go reporterService.startBalanceCheckLoop()
+
+	for _, key := range keys {
+		go reporterService.processTxsFromQueue(key)
+	}
 
 	if config.VerifyOutcomeURI == "" {
 		reporterService.logger.Warn("Reporter 'verify_outcome_api_url' is missing but required for outcome voting and reporting.. we will avoid participating in outcome voting and reporting...") //nolint:lll
@@ -83,84 +209,136 @@ func NewReporterService(
 		return reporterService, nil
 	}
 
-	eventListener, err := newEventListener(reporterService.logger, reporterService)
+	if err := reporterService.syncVotingPeriod(); err != nil {
+		return nil, fmt.Errorf("unable to load on-chain voting period: %w", err)
+	}
+
+	scheduler, err := newReportScheduler(reporterService.logger, reporterService)
 	if err != nil {
 		return nil, err
 	}
-	reporterService.eventListener = eventListener
+	reporterService.scheduler = scheduler
+	reporterService.voteScheduler = newVoteScheduler(reporterService.logger, reporterService)
+
+	go reporterService.startVotingPeriodRefreshLoop()
 
-	storeProcessor, err := newStoreProcessor(reporterService.logger, reporterService)
+	eventListener, err := newEventListener(reporterService.logger, reporterService)
 	if err != nil {
 		return nil, err
 	}
-	reporterService.storeProcessor = storeProcessor
+	reporterService.eventListener = eventListener
 
 	return reporterService, nil
 }
 
 // Queues a reporting transaction for processing with the specified function type, market hash, and outcome.
-// It creates a ReportingTx instance with the provided parameters and sets the outcome based on the function type.
-// If the function type is "ProposeOutcome", it sets the outcome directly.
-// If the function type is "VoteOutcome", it verifies the market and sets the outcome accordingly.
-// Finally, it logs a debug message and queues the reporting transaction for processing.
-func (d *ReporterService) queueReportingTx(functionType string, marketHash string, outcome int32) {
+// It selects the keys sending the transaction according to the routing rules and
+// creates a ReportingTx instance with the provided parameters, setting the outcome based on the function type.
+// If the function type is "ProposeOutcome" or "VoteOutcome", it sets the outcome directly, the outcome is ignored for other function types.
+// Votes are queued by the VoteScheduler once the market is verified.
+// Finally, it logs a debug message and queues the reporting transaction on the pipeline of each selected key.
+// The queued transactions are traced as children of the span started from ctx.
+// Returns whether the transaction was queued, false if no key is permitted to send it or the function type is unknown.
+func (d *ReporterService) queueReportingTx(ctx context.Context, functionType string, marketHash string, outcome int32) bool {
+	ctx, span := d.tracer.Start(ctx, "queueReportingTx", trace.WithAttributes(
+		functionAttribute.String(functionType),
+		marketHashAttribute.String(marketHash),
+	))
+	defer span.End()
 
-	reportingTx := &ReportingTx{
-		functionType: functionType,
-		report: &proto.Report{
-			MarketHash: marketHash,
-		},
+	keys, reason := d.router.route(functionType, true)
+	if len(keys) == 0 {
+		d.logger.Warn("no reporter key is permitted to perform action, skipping tx", "function", functionType, "marketHash", marketHash, "reason", reason)
+		span.AddEvent("skipped", trace.WithAttributes(attribute.String("reason", reason)))
+		d.audit(&AuditEntry{MarketHash: marketHash, Event: AuditSkipped, Function: functionType, Reason: reason})
+
+		return false
+	}
+
+	report := &proto.Report{
+		MarketHash: marketHash,
 	}
 
 	switch functionType {
-	case ProposeOutcome:
-		reportingTx.report.Outcome = outcome
-	case VoteOutcome:
-		verifyOutcome, err := d.verifyMarket(marketHash)
-		reportingTx.report.Outcome = verifyOutcome
-		if err != nil {
-			d.logger.Error("Error encountered in verifying market, skipping vote tx", "err", err)
-			return
-		}
+	case ProposeOutcome, VoteOutcome:
+		report.Outcome = outcome
 	default:
 		if functionType != ReportOutcome {
-			d.logger.Error("Unrecognized function type, skipping tx..", "functionType", functionType)
-			return
+			d.logger.Error("Unrecognized function type, skipping tx..", "function", functionType, "marketHash", marketHash)
+			recordSpanError(span, fmt.Errorf("unrecognized function type %s", functionType))
+
+			return false
 		}
 	}
 
-	d.logger.Debug("queueing reporting tx for processing", "function", functionType, "marketHash", marketHash)
-	d.reportingTxChan <- reportingTx
-}
+	span.SetAttributes(outcomeAttribute.Int64(int64(report.Outcome)))
 
-// Processes transactions from the reporting transaction channel.
-// It continuously listens for reporting transactions from the channel and processes them one by one.
-// For each reporting transaction received, it invokes the sendTxWithRetry method to attempt sending
-// the transaction with retries in case of failures.
-func (d *ReporterService) processTxsFromQueue() {
-	for reportingTx := range d.reportingTxChan {
-		d.logger.Debug("processing reporting tx", "function", reportingTx.functionType, "marketHash", reportingTx.report.MarketHash)
-		d.sendTxWithRetry(reportingTx.functionType, reportingTx.report)
+	for _, key := range keys {
+		d.logger.Debug("queueing reporting tx for processing", "key", key.name, "function", functionType, "marketHash", marketHash)
+
+		decision := &AuditEntry{MarketHash: marketHash, Event: AuditDecision, Function: functionType, Key: key.name}
+		if functionType != ReportOutcome {
+			decision.Outcome = &report.Outcome
+		}
+
+		d.audit(decision)
+
+		d.enqueueTx(key, &ReportingTx{
+			functionType: functionType,
+			report: &proto.Report{
+				MarketHash: report.MarketHash,
+				Outcome:    report.Outcome,
+			},
+			ctx: ctx,
+		})
 	}
+
+	return true
 }
 
-// Retrieves the voting period from the chain and updates the configuration accordingly.
-// It sends a call to the "_votingPeriod" function on the chain to fetch the voting period value.
-// If the result is nil or cannot be converted to *big.Int, it logs an error and returns.
-// Otherwise, it updates the OutcomeVotingPeriodSeconds field in the configuration with the retrieved value.
-func (d *ReporterService) syncVotingPeriod() {
-	result := d.sendCall("_votingPeriod")
-	if result == nil {
-		d.logger.Error("voting period returned nil")
-		return
-	}
-
-	votingPeriodOnchain, ok := result.(*big.Int)
-	if !ok {
-		d.logger.Error("failed to convert result to *big.Int")
-		return
-	}
-	d.logger.Debug("retrieved onchain voting period", votingPeriodOnchain)
-	d.logger.Debug("update voting period", votingPeriodOnchain)
-	d.config.OutcomeVotingPeriodSeconds = votingPeriodOnchain.Uint64()
+// Processes transactions from the reporting transaction channels of the given key, the priority channel first.
+// It continuously listens for reporting transactions from the channels and processes them one by one.
+// For each reporting transaction received, it invokes the sendTxWithRetry method to attempt sending
+// the transaction with retries in case of failures, then updates the key's reward ledger from the outcome.
+func (d *ReporterService) processTxsFromQueue(key *reporterKey) {
+	for {
+		reportingTx, ok := key.nextTx()
+		if !ok {
+			return
+		}
+
+		d.metrics.QueuedTxs.WithLabelValues(key.name).Set(float64(len(key.txChan)))
+
+		d.logger.Debug(
+			"processing reporting tx",
+			"key", key.name,
+			"function", reportingTx.functionType,
+			"marketHash", reportingTx.report.GetMarketHash(),
+		)
+		receipt := d.sendTxWithRetry(reportingTx)
+
+		if receipt != nil {
+			d.metrics.TxsSent.WithLabelValues(key.name, reportingTx.functionType, "success").Inc()
+		} else {
+			d.metrics.TxsSent.WithLabelValues(key.name, reportingTx.functionType, "failed").Inc()
+		}
+
+		if reportingTx.result != nil {
+			reportingTx.result <- receipt
+		}
+
+		switch {
+		case reportingTx.functionType == VoteOutcome && receipt != nil:
+			d.recordVotedOutcome(reportingTx.report.MarketHash, reportingTx.report.Outcome)
+		case reportingTx.functionType == ReportOutcome && receipt != nil:
+			d.scheduler.cancel(reportingTx.report.MarketHash)
+			d.recordReportingReward(key, reportingTx.report.MarketHash, receipt)
+		case reportingTx.functionType == ReportOutcome:
+			d.scheduler.retry(reportingTx.report.MarketHash)
+		case reportingTx.functionType == WithdrawRewards && receipt != nil:
+			d.recordRewardWithdrawal(key, reportingTx.amount, receipt)
+		case reportingTx.functionType == WithdrawRewards:
+			d.finishRewardWithdrawal(key)
+		}
+	}
 }
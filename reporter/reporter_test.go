@@ -0,0 +1,260 @@
+package reporter
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/wallet"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	testMetricsOnce sync.Once
+	testMetrics     *Metrics
+)
+
+// Returns the reporter metrics shared by the tests, as they can only be registered once per process.
+func sharedTestMetrics() *Metrics {
+	testMetricsOnce.Do(func() {
+		testMetrics = newMetrics()
+	})
+
+	return testMetrics
+}
+
+// Private key of the default key of the test reporter service, whose address is testKeyAddress.
+const (
+	testKeyHex     = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testKeyAddress = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+)
+
+// Creates an in-process signer for the hex encoded private key.
+func newTestSigner(t *testing.T, keyHex string) *secretsManagerSigner {
+	t.Helper()
+
+	privKey, err := crypto.BytesToECDSAPrivateKey([]byte(keyHex))
+	if err != nil {
+		t.Fatalf("BytesToECDSAPrivateKey() error = %v", err)
+	}
+
+	return &secretsManagerSigner{
+		key:     wallet.NewKey(privKey),
+		privKey: privKey,
+		address: ethgo.Address(crypto.PubKeyToAddress(&privKey.PublicKey)),
+	}
+}
+
+// Creates a reporter service with a single default key whose txs are left queued,
+// without connecting to the chain. The tracer provider is the global one if nil.
+func newTestReporterService(t *testing.T, config *ReporterConfig, provider trace.TracerProvider) *ReporterService {
+	t.Helper()
+
+	if config == nil {
+		config = &ReporterConfig{}
+	}
+
+	d := &ReporterService{
+		logger:            hclog.NewNullLogger(),
+		metrics:           sharedTestMetrics(),
+		auditLog:          newAuditLog(""),
+		outcomes:          newOutcomeTracker(),
+		eligibilityChecks: make(chan struct{}, 1),
+		tracer:            newTracer(provider),
+	}
+	d.config.Store(config)
+
+	d.keys = []*reporterKey{{
+		name:        "default",
+		signer:      newTestSigner(t, testKeyHex),
+		txChan:      make(chan *ReportingTx, keyTxQueueSize),
+		priorityCh:  make(chan *ReportingTx, keyPriorityTxQueueSize),
+		eligibility: eligibility{actions: make(map[string]*ActionStatus)},
+		balance:     keyBalance{level: BalanceUnknown},
+	}}
+
+	router, err := newKeyRouter(d.keys, config.Routing)
+	if err != nil {
+		t.Fatalf("newKeyRouter() error = %v", err)
+	}
+
+	d.router = router
+
+	return d
+}
+
+// Contract call answered by the fake chain with the outputs of fn for the decoded inputs.
+// A nil result reverts the call.
+type fakeContractCall struct {
+	method *abi.Method
+	fn     func(inputs map[string]interface{}) interface{}
+}
+
+// JSON-RPC request received by the fake chain.
+type fakeRPCRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// Fake JSON-RPC node answering contract calls and the methods registered on it.
+type fakeChain struct {
+	calls   map[string]*fakeContractCall                                   // Contract calls by selector.
+	methods map[string]func(params []json.RawMessage) (interface{}, error) // Handlers by JSON-RPC method.
+	counts  map[string]int                                                 // Requests by JSON-RPC method or contract function.
+	sync.Mutex
+}
+
+// Starts a fake chain and points the reporter service's tx service at it.
+func newFakeChain(t *testing.T, d *ReporterService) *fakeChain {
+	t.Helper()
+
+	chain := &fakeChain{
+		calls:   make(map[string]*fakeContractCall),
+		methods: make(map[string]func(params []json.RawMessage) (interface{}, error)),
+		counts:  make(map[string]int),
+	}
+
+	server := httptest.NewServer(chain)
+	t.Cleanup(server.Close)
+
+	client, err := jsonrpc.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("jsonrpc.NewClient() error = %v", err)
+	}
+
+	d.txService = &TxService{logger: hclog.NewNullLogger(), client: client}
+
+	return chain
+}
+
+// Answers calls of the contract function with the given signature.
+func (c *fakeChain) handleCall(t *testing.T, signature string, fn func(inputs map[string]interface{}) interface{}) {
+	t.Helper()
+
+	method, err := abi.NewMethod(signature)
+	if err != nil {
+		t.Fatalf("abi.NewMethod(%q) error = %v", signature, err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.calls[hex.EncodeToString(method.ID())] = &fakeContractCall{method: method, fn: fn}
+}
+
+// Answers requests of the JSON-RPC method.
+func (c *fakeChain) handle(method string, fn func(params []json.RawMessage) (interface{}, error)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.methods[method] = fn
+}
+
+// Returns the number of requests of the JSON-RPC method or contract function.
+func (c *fakeChain) count(name string) int {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.counts[name]
+}
+
+func (c *fakeChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request fakeRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	result, err := c.serve(&request)
+
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result}
+	if err != nil {
+		response = map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -32000, "message": err.Error()},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (c *fakeChain) serve(request *fakeRPCRequest) (interface{}, error) {
+	c.Lock()
+	c.counts[request.Method]++
+	handler := c.methods[request.Method]
+	c.Unlock()
+
+	if handler != nil {
+		return handler(request.Params)
+	}
+
+	if request.Method != "eth_call" || len(request.Params) == 0 {
+		return nil, errors.New("method not found")
+	}
+
+	var msg struct {
+		Data  string `json:"data"`
+		Input string `json:"input"`
+	}
+
+	if err := json.Unmarshal(request.Params[0], &msg); err != nil {
+		return nil, err
+	}
+
+	if msg.Data == "" {
+		msg.Data = msg.Input
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(msg.Data, "0x"))
+	if err != nil || len(data) < 4 {
+		return nil, errors.New("invalid call data")
+	}
+
+	c.Lock()
+	call := c.calls[hex.EncodeToString(data[:4])]
+	if call != nil {
+		c.counts[call.method.Name]++
+	}
+	c.Unlock()
+
+	if call == nil {
+		return nil, errors.New("execution reverted")
+	}
+
+	inputs := make(map[string]interface{})
+
+	if len(data) > 4 {
+		decoded, err := abi.Decode(call.method.Inputs, data[4:])
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = decoded.(map[string]interface{})
+	}
+
+	output := call.fn(inputs)
+	if output == nil {
+		return nil, errors.New("execution reverted")
+	}
+
+	encoded, err := abi.Encode([]interface{}{output}, call.method.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return "0x" + hex.EncodeToString(encoded), nil
+}
@@ -0,0 +1,332 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"github.com/umbracle/ethgo"
+	ethgoabi "github.com/umbracle/ethgo/abi"
+)
+
+const (
+	// File name of the default key's rewards ledger within the data directory.
+	rewardsLedgerFile = "rewards.json"
+)
+
+// ERC20 Transfer event emitted by the WSX token when the OutcomeReporter contract pays out juiced reporting rewards.
+var rewardTransferEvent = ethgoabi.MustNewEvent("event Transfer(address indexed from, address indexed to, uint256 value)")
+
+// Persisted record of the juiced reporting rewards earned and withdrawn by a reporter key.
+// Both the rewards earned and the rewards withdrawn are read from the WSX transfers in the tx receipts,
+// so the ledger only records what the key was actually paid.
+type rewardsLedger struct {
+	Markets   map[string]*marketReward `json:"markets"`   // Rewards earned by market hash.
+	Accrued   *big.Int                 `json:"accrued"`   // Total rewards earned, in wei.
+	Withdrawn *big.Int                 `json:"withdrawn"` // Total rewards withdrawn, in wei.
+
+	path              string // Path the ledger is persisted to, not persisted if empty.
+	withdrawalPending bool   // Whether a withdrawal tx is queued or in flight.
+	sync.Mutex
+}
+
+// Reward earned for reporting the outcome of a single market.
+type marketReward struct {
+	Amount     *big.Int  `json:"amount"`     // Reward amount in wei, as paid by the reportOutcome tx.
+	ReportedAt time.Time `json:"reportedAt"` // Time the reportOutcome tx was confirmed.
+}
+
+// Summary of the reward ledger returned by the operator API.
+type RewardsSummary struct {
+	Accrued         *big.Int                   `json:"accrued"`         // Total rewards earned, in wei.
+	Withdrawn       *big.Int                   `json:"withdrawn"`       // Total rewards withdrawn, in wei.
+	Available       *big.Int                   `json:"available"`       // Rewards earned but not yet withdrawn, in wei.
+	ReportedMarkets int                        `json:"reportedMarkets"` // Number of markets that earned a reward.
+	Keys            map[string]*RewardsSummary `json:"keys,omitempty"`  // Summary of each key, if summing over keys.
+}
+
+// Returns the file name of the rewards ledger of the named key within the data directory.
+func rewardsLedgerFileName(keyName string) string {
+	if keyName == "" || keyName == secrets.DefaultReporterKeyName {
+		return rewardsLedgerFile
+	}
+
+	return fmt.Sprintf("rewards-%s.json", keyName)
+}
+
+// Loads the rewards ledger of the named key from the given data directory, starting an empty ledger if none exists.
+// An empty data directory yields an in-memory ledger.
+func loadRewardsLedger(dataDir string, keyName string) (*rewardsLedger, error) {
+	ledger := &rewardsLedger{
+		Markets:   make(map[string]*marketReward),
+		Accrued:   new(big.Int),
+		Withdrawn: new(big.Int),
+	}
+
+	if dataDir == "" {
+		return ledger, nil
+	}
+
+	ledger.path = filepath.Join(dataDir, rewardsLedgerFileName(keyName))
+
+	data, err := os.ReadFile(ledger.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read rewards ledger (%s), %w", ledger.path, err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("unable to parse rewards ledger (%s), %w", ledger.path, err)
+	}
+
+	return ledger, nil
+}
+
+// Writes the ledger to disk. Must be called with the lock held.
+func (l *rewardsLedger) save() error {
+	if l.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(l, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(l.path, data, 0600)
+}
+
+// Returns a summary of the ledger totals.
+func (l *rewardsLedger) summary() *RewardsSummary {
+	l.Lock()
+	defer l.Unlock()
+
+	return &RewardsSummary{
+		Accrued:         new(big.Int).Set(l.Accrued),
+		Withdrawn:       new(big.Int).Set(l.Withdrawn),
+		Available:       new(big.Int).Sub(l.Accrued, l.Withdrawn),
+		ReportedMarkets: len(l.Markets),
+	}
+}
+
+// Returns the reporting rewards summary summed over the reporter keys, along with the summary of each key.
+func (d *ReporterService) Rewards() *RewardsSummary {
+	total := &RewardsSummary{
+		Accrued:   new(big.Int),
+		Withdrawn: new(big.Int),
+		Available: new(big.Int),
+		Keys:      make(map[string]*RewardsSummary, len(d.keys)),
+	}
+
+	for _, key := range d.keys {
+		summary := key.rewards.summary()
+
+		total.Accrued.Add(total.Accrued, summary.Accrued)
+		total.Withdrawn.Add(total.Withdrawn, summary.Withdrawn)
+		total.Available.Add(total.Available, summary.Available)
+		total.ReportedMarkets += summary.ReportedMarkets
+		total.Keys[key.name] = summary
+	}
+
+	return total
+}
+
+// Records the reward paid for a market reported by the key, read from the reportOutcome receipt.
+// Reports that were not paid a juiced reward are not recorded.
+// It then withdraws the key's available rewards if auto-withdrawal is enabled and the threshold is reached.
+func (d *ReporterService) recordReportingReward(key *reporterKey, marketHash string, receipt *ethgo.Receipt) {
+	amount := d.rewardsPaid(key, receipt)
+	if amount.Sign() == 0 {
+		d.logger.Debug("no juiced reward paid for report", "key", key.name, "marketHash", marketHash)
+
+		return
+	}
+
+	key.rewards.Lock()
+
+	if _, ok := key.rewards.Markets[marketHash]; ok {
+		key.rewards.Unlock()
+
+		return
+	}
+
+	key.rewards.Markets[marketHash] = &marketReward{
+		Amount:     amount,
+		ReportedAt: time.Now(),
+	}
+	key.rewards.Accrued.Add(key.rewards.Accrued, amount)
+
+	if err := key.rewards.save(); err != nil {
+		d.logger.Error("failed to persist rewards ledger", "key", key.name, "err", err)
+	}
+
+	key.rewards.Unlock()
+
+	d.logger.Info("recorded reporting reward", "key", key.name, "marketHash", marketHash, "amount", amount)
+	d.updateRewardMetrics(key)
+	d.maybeWithdrawRewards(key)
+}
+
+// Records a confirmed withdrawal by the key, booking the rewards transferred to the key in the withdrawJuicedRewards receipt
+// rather than the amount requested, and forwards them to the payout address if one is configured.
+func (d *ReporterService) recordRewardWithdrawal(key *reporterKey, requested *big.Int, receipt *ethgo.Receipt) {
+	defer d.finishRewardWithdrawal(key)
+
+	amount := d.rewardsPaid(key, receipt)
+	if amount.Sign() == 0 {
+		d.logger.Warn("no rewards transferred by withdrawal", "key", key.name, "requested", requested, "txHash", receipt.TransactionHash)
+
+		return
+	}
+
+	if amount.Cmp(requested) != 0 {
+		d.logger.Warn("withdrawal transferred a different amount than requested", "key", key.name, "requested", requested, "amount", amount)
+	}
+
+	key.rewards.Lock()
+	key.rewards.Withdrawn.Add(key.rewards.Withdrawn, amount)
+
+	if err := key.rewards.save(); err != nil {
+		d.logger.Error("failed to persist rewards ledger", "key", key.name, "err", err)
+	}
+
+	key.rewards.Unlock()
+
+	d.logger.Info("withdrew reporting rewards", "key", key.name, "amount", amount)
+	d.updateRewardMetrics(key)
+
+	if !d.hasExternalPayoutAddress(key) {
+		return
+	}
+
+	if allowed, reason := key.isActionAllowed(TransferRewards); !allowed {
+		d.logger.Warn("skipping rewards transfer to payout address", "key", key.name, "amount", amount, "reason", reason)
+
+		return
+	}
+
+	d.logger.Debug(
+		"queueing rewards transfer to payout address",
+		"key", key.name,
+		"amount", amount,
+		"payoutAddress", d.config.Load().RewardPayoutAddress,
+	)
+
+	d.enqueueRewardTx(key, &ReportingTx{
+		functionType: TransferRewards,
+		amount:       amount,
+		recipient:    d.config.Load().RewardPayoutAddress,
+	})
+}
+
+// Clears the pending withdrawal flag of the key once a withdrawal tx has completed or failed.
+func (d *ReporterService) finishRewardWithdrawal(key *reporterKey) {
+	key.rewards.Lock()
+	key.rewards.withdrawalPending = false
+	key.rewards.Unlock()
+}
+
+// Queues a withdrawal of all rewards available to the key if auto-withdrawal is enabled and the threshold is reached.
+func (d *ReporterService) maybeWithdrawRewards(key *reporterKey) {
+	if !d.config.Load().AutoWithdrawRewards {
+		return
+	}
+
+	if allowed, reason := key.isActionAllowed(WithdrawRewards); !allowed {
+		d.logger.Debug("skipping rewards withdrawal", "key", key.name, "reason", reason)
+
+		return
+	}
+
+	key.rewards.Lock()
+	defer key.rewards.Unlock()
+
+	available := new(big.Int).Sub(key.rewards.Accrued, key.rewards.Withdrawn)
+	if key.rewards.withdrawalPending || available.Sign() <= 0 {
+		return
+	}
+
+	if threshold := d.config.Load().RewardWithdrawThreshold; threshold != nil && available.Cmp(threshold) < 0 {
+		return
+	}
+
+	key.rewards.withdrawalPending = true
+
+	d.logger.Debug("queueing rewards withdrawal", "key", key.name, "amount", available)
+
+	d.enqueueRewardTx(key, &ReportingTx{
+		functionType: WithdrawRewards,
+		amount:       available,
+	})
+}
+
+// Returns the juiced rewards paid to the key by the tx of the receipt, summing the WSX transfers
+// from the OutcomeReporter contract to the key. Transfers of other tokens are ignored if the WSX address is configured.
+func (d *ReporterService) rewardsPaid(key *reporterKey, receipt *ethgo.Receipt) *big.Int {
+	config := d.config.Load()
+	outcomeReporterAddress := ethgo.HexToAddress(config.OutcomeReporterAddress)
+	amount := new(big.Int)
+
+	for _, vLog := range receipt.Logs {
+		if !rewardTransferEvent.Match(vLog) {
+			continue
+		}
+
+		if config.WSXAddress != "" && vLog.Address != ethgo.HexToAddress(config.WSXAddress) {
+			continue
+		}
+
+		transfer, err := rewardTransferEvent.ParseLog(vLog)
+		if err != nil {
+			d.logger.Error("failed to parse transfer log", "key", key.name, "txHash", receipt.TransactionHash, "err", err)
+
+			continue
+		}
+
+		from, _ := transfer["from"].(ethgo.Address)
+		to, _ := transfer["to"].(ethgo.Address)
+		value, ok := transfer["value"].(*big.Int)
+
+		if ok && from == outcomeReporterAddress && to == key.address() {
+			amount.Add(amount, value)
+		}
+	}
+
+	return amount
+}
+
+// Queues a rewards tx on the key's pipeline from a separate goroutine,
+// as rewards are recorded on the key's tx queue consumer, which would block on its own full queue.
+func (d *ReporterService) enqueueRewardTx(key *reporterKey, reportingTx *ReportingTx) {
+	go d.enqueueTx(key, reportingTx)
+}
+
+// Checks whether rewards withdrawn by the key should be forwarded to a payout address other than its reporter address.
+func (d *ReporterService) hasExternalPayoutAddress(key *reporterKey) bool {
+	payoutAddress := d.config.Load().RewardPayoutAddress
+	if payoutAddress == "" {
+		return false
+	}
+
+	return !strings.EqualFold(key.address().String(), payoutAddress)
+}
+
+// Updates the reward metrics of the key from its ledger totals.
+func (d *ReporterService) updateRewardMetrics(key *reporterKey) {
+	summary := key.rewards.summary()
+
+	accrued, _ := new(big.Float).SetInt(summary.Accrued).Float64()
+	withdrawn, _ := new(big.Float).SetInt(summary.Withdrawn).Float64()
+
+	d.metrics.RewardsAccrued.WithLabelValues(key.name).Set(accrued)
+	d.metrics.RewardsWithdrawn.WithLabelValues(key.name).Set(withdrawn)
+	d.metrics.RewardedMarkets.WithLabelValues(key.name).Set(float64(summary.ReportedMarkets))
+}
@@ -0,0 +1,228 @@
+package reporter
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/umbracle/ethgo"
+)
+
+const testPayoutAddress = "0x00000000000000000000000000000000000000d1"
+
+const testWSXAddress = "0x00000000000000000000000000000000000000d2"
+
+// Creates a reporter service whose default key keeps its rewards ledger in the given data directory.
+func newRewardsTestService(t *testing.T, config *ReporterConfig) (*ReporterService, *reporterKey) {
+	t.Helper()
+
+	config.OutcomeReporterAddress = testOutcomeReporterAddress
+	config.WSXAddress = testWSXAddress
+
+	d := newTestReporterService(t, config, nil)
+	key := d.keys[0]
+
+	ledger, err := loadRewardsLedger(config.DataDir, key.name)
+	if err != nil {
+		t.Fatalf("loadRewardsLedger() error = %v", err)
+	}
+
+	key.rewards = ledger
+
+	return d, key
+}
+
+// Returns a WSX Transfer log of the given token moving value between the addresses.
+func transferLog(token, from, to ethgo.Address, value int64) *ethgo.Log {
+	var data [32]byte
+	big.NewInt(value).FillBytes(data[:])
+
+	return &ethgo.Log{
+		Address: token,
+		Topics: []ethgo.Hash{
+			rewardTransferEvent.ID(),
+			ethgo.BytesToHash(from[:]),
+			ethgo.BytesToHash(to[:]),
+		},
+		Data: data[:],
+	}
+}
+
+// Returns a receipt paying the key the given WSX amounts from the OutcomeReporter contract.
+func rewardReceipt(key *reporterKey, amounts ...int64) *ethgo.Receipt {
+	receipt := &ethgo.Receipt{BlockNumber: 100}
+
+	for _, amount := range amounts {
+		receipt.Logs = append(receipt.Logs, transferLog(
+			ethgo.HexToAddress(testWSXAddress),
+			ethgo.HexToAddress(testOutcomeReporterAddress),
+			key.address(),
+			amount,
+		))
+	}
+
+	return receipt
+}
+
+// Returns the next tx queued on the key within the timeout, or nil if none is queued.
+// Reward txs are queued from a separate goroutine, so the timeout also bounds how long an unexpected tx is waited for.
+func nextQueuedTx(key *reporterKey, timeout time.Duration) *ReportingTx {
+	select {
+	case tx := <-key.txChan:
+		return tx
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func checkRewards(t *testing.T, key *reporterKey, accrued, withdrawn int64, markets int) {
+	t.Helper()
+
+	summary := key.rewards.summary()
+	if summary.Accrued.Int64() != accrued || summary.Withdrawn.Int64() != withdrawn || summary.ReportedMarkets != markets {
+		t.Fatalf("rewards = %+v, want %d accrued and %d withdrawn over %d markets", summary, accrued, withdrawn, markets)
+	}
+}
+
+func TestRecordReportingReward(t *testing.T) {
+	dataDir := t.TempDir()
+
+	d, key := newRewardsTestService(t, &ReporterConfig{DataDir: dataDir})
+
+	d.recordReportingReward(key, testMarketA, rewardReceipt(key, 10))
+	d.recordReportingReward(key, testMarketB, rewardReceipt(key, 4, 6))
+
+	// a market is only rewarded once
+	d.recordReportingReward(key, testMarketA, rewardReceipt(key, 10))
+	checkRewards(t, key, 20, 0, 2)
+
+	// reports paying the key no WSX are not recorded
+	other := ethgo.HexToAddress("0x00000000000000000000000000000000000000e1")
+	outcomeReporter := ethgo.HexToAddress(testOutcomeReporterAddress)
+	wsx := ethgo.HexToAddress(testWSXAddress)
+
+	tests := []struct {
+		name    string
+		receipt *ethgo.Receipt
+	}{
+		{"no logs", &ethgo.Receipt{}},
+		{"transfer to another address", &ethgo.Receipt{Logs: []*ethgo.Log{transferLog(wsx, outcomeReporter, other, 10)}}},
+		{"transfer from another address", &ethgo.Receipt{Logs: []*ethgo.Log{transferLog(wsx, other, key.address(), 10)}}},
+		{"transfer of another token", &ethgo.Receipt{Logs: []*ethgo.Log{transferLog(other, outcomeReporter, key.address(), 10)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d.recordReportingReward(key, testMarketC, tt.receipt)
+			checkRewards(t, key, 20, 0, 2)
+		})
+	}
+
+	// the ledger is persisted
+	ledger, err := loadRewardsLedger(dataDir, key.name)
+	if err != nil {
+		t.Fatalf("loadRewardsLedger() error = %v", err)
+	}
+
+	if reward := ledger.Markets[testMarketA]; reward == nil || reward.Amount.Int64() != 10 || ledger.Accrued.Int64() != 20 {
+		data, _ := json.Marshal(ledger)
+		t.Errorf("persisted ledger = %s, want 10 earned on %s and 20 accrued", data, testMarketA)
+	}
+
+	if tx := nextQueuedTx(key, 100*time.Millisecond); tx != nil {
+		t.Errorf("queued %s tx with auto-withdrawal disabled", tx.functionType)
+	}
+}
+
+func TestAutoWithdrawRewards(t *testing.T) {
+	d, key := newRewardsTestService(t, &ReporterConfig{
+		AutoWithdrawRewards:     true,
+		RewardWithdrawThreshold: big.NewInt(25),
+		RewardPayoutAddress:     testPayoutAddress,
+	})
+
+	d.recordReportingReward(key, testMarketA, rewardReceipt(key, 10))
+	d.recordReportingReward(key, testMarketB, rewardReceipt(key, 10))
+
+	if tx := nextQueuedTx(key, 100*time.Millisecond); tx != nil {
+		t.Fatalf("queued %s tx for 20 available rewards, below the threshold of 25", tx.functionType)
+	}
+
+	d.recordReportingReward(key, testMarketC, rewardReceipt(key, 10))
+
+	withdrawal := nextQueuedTx(key, time.Second)
+	if withdrawal == nil || withdrawal.functionType != WithdrawRewards || withdrawal.amount.Int64() != 30 {
+		t.Fatalf("queued %+v once the threshold was reached, want a withdrawal of the 30 available", withdrawal)
+	}
+
+	// no other withdrawal is queued while one is pending
+	d.recordReportingReward(key, "0x000000000000000000000000000000000000000000000000000000000000000d", rewardReceipt(key, 10))
+
+	if tx := nextQueuedTx(key, 100*time.Millisecond); tx != nil {
+		t.Fatalf("queued %s tx while a withdrawal is pending", tx.functionType)
+	}
+
+	// the amount transferred by the withdrawal is booked and forwarded to the payout address, not the amount requested
+	d.recordRewardWithdrawal(key, withdrawal.amount, rewardReceipt(key, 25))
+	checkRewards(t, key, 40, 25, 4)
+
+	transfer := nextQueuedTx(key, time.Second)
+	if transfer == nil || transfer.functionType != TransferRewards || transfer.amount.Int64() != 25 || transfer.recipient != testPayoutAddress {
+		t.Fatalf("queued %+v after the withdrawal, want a transfer of 25 to %s", transfer, testPayoutAddress)
+	}
+
+	key.rewards.Lock()
+	pending := key.rewards.withdrawalPending
+	key.rewards.Unlock()
+
+	if pending {
+		t.Error("withdrawal still pending once confirmed")
+	}
+}
+
+func TestRewardWithdrawalWithoutTransfer(t *testing.T) {
+	d, key := newRewardsTestService(t, &ReporterConfig{RewardPayoutAddress: testPayoutAddress})
+
+	d.recordReportingReward(key, testMarketA, rewardReceipt(key, 10))
+
+	key.rewards.Lock()
+	key.rewards.withdrawalPending = true
+	key.rewards.Unlock()
+
+	// a withdrawal transferring nothing to the key books nothing and forwards nothing
+	d.recordRewardWithdrawal(key, big.NewInt(10), &ethgo.Receipt{})
+	checkRewards(t, key, 10, 0, 1)
+
+	if tx := nextQueuedTx(key, 100*time.Millisecond); tx != nil {
+		t.Errorf("queued %s tx after a withdrawal transferring nothing", tx.functionType)
+	}
+
+	key.rewards.Lock()
+	pending := key.rewards.withdrawalPending
+	key.rewards.Unlock()
+
+	if pending {
+		t.Error("withdrawal still pending once confirmed")
+	}
+}
+
+func TestAutoWithdrawRewardsFailed(t *testing.T) {
+	d, key := newRewardsTestService(t, &ReporterConfig{AutoWithdrawRewards: true})
+
+	d.recordReportingReward(key, testMarketA, rewardReceipt(key, 10))
+
+	if withdrawal := nextQueuedTx(key, time.Second); withdrawal == nil || withdrawal.functionType != WithdrawRewards {
+		t.Fatalf("queued %+v without a threshold, want a withdrawal", withdrawal)
+	}
+
+	// a failed withdrawal leaves the rewards available, and they are withdrawn again on the next reward
+	d.finishRewardWithdrawal(key)
+	d.recordReportingReward(key, testMarketB, rewardReceipt(key, 10))
+
+	if withdrawal := nextQueuedTx(key, time.Second); withdrawal == nil || withdrawal.amount.Int64() != 20 {
+		t.Fatalf("queued %+v after a failed withdrawal, want a withdrawal of the 20 available", withdrawal)
+	}
+
+	checkRewards(t, key, 20, 0, 2)
+}
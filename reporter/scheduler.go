@@ -0,0 +1,311 @@
+package reporter
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+)
+
+// Schedules reportOutcome txs for proposed markets once their voting period has elapsed.
+// Deadlines are keyed by the ProposeOutcome block timestamp plus the on-chain voting period,
+// and are compared against chain time (the latest block timestamp) rather than the local wall clock.
+type ReportScheduler struct {
+	logger          hclog.Logger
+	reporterService *ReporterService
+	queue           scheduledMarketQueue        // Min-heap of scheduled markets ordered by deadline.
+	markets         map[string]*scheduledMarket // Scheduled markets by market hash.
+	chainTime       uint64                      // Latest known block timestamp.
+	wakeCh          chan struct{}               // Signals the scheduling loop that its state changed.
+	closeCh         chan struct{}               // Stops the scheduling loop once closed.
+	sync.Mutex
+}
+
+// Represents a market waiting for its voting period to elapse.
+// A market whose reportOutcome tx is queued stays scheduled outside the heap until the tx succeeds,
+// so it can be queued again if the tx fails.
+type scheduledMarket struct {
+	marketHash     string // Hash of the proposed market.
+	blockTimestamp uint64 // Timestamp of the block the outcome was proposed in.
+	deadline       uint64 // Chain time at which the market becomes reportable.
+	attempts       int    // Number of reportOutcome txs queued for the market.
+	index          int    // Position of the market within the heap, -1 while its reportOutcome tx is queued.
+}
+
+const (
+	// Chain time in seconds to wait before queueing a reportOutcome tx again after it failed.
+	reportRetryDelay = 60
+	// Maximum number of reportOutcome txs queued for a market.
+	maxReportAttempts = 5
+)
+
+// Creates and initializes a new ReportScheduler instance with the provided logger and reporter service.
+// It seeds chain time from the latest block and starts the scheduling loop.
+func newReportScheduler(logger hclog.Logger, reporterService *ReporterService) (*ReportScheduler, error) {
+	scheduler := &ReportScheduler{
+		logger:          logger.Named("scheduler"),
+		reporterService: reporterService,
+		markets:         make(map[string]*scheduledMarket),
+		wakeCh:          make(chan struct{}, 1),
+		closeCh:         make(chan struct{}),
+	}
+
+	scheduler.refreshChainTime()
+
+	go scheduler.startSchedulingLoop()
+
+	return scheduler, nil
+}
+
+// Schedules a reportOutcome tx for the given market once the voting period has elapsed since blockTimestamp.
+// Scheduling an already scheduled market replaces its previous deadline and resets its attempts.
+func (s *ReportScheduler) schedule(marketHash string, blockTimestamp uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	deadline := blockTimestamp + s.reporterService.VotingPeriod()
+
+	if market, ok := s.markets[marketHash]; ok {
+		market.blockTimestamp = blockTimestamp
+		market.deadline = deadline
+		market.attempts = 0
+
+		if market.index < 0 {
+			heap.Push(&s.queue, market)
+		} else {
+			heap.Fix(&s.queue, market.index)
+		}
+	} else {
+		market := &scheduledMarket{
+			marketHash:     marketHash,
+			blockTimestamp: blockTimestamp,
+			deadline:       deadline,
+		}
+		heap.Push(&s.queue, market)
+		s.markets[marketHash] = market
+	}
+
+	s.logger.Debug("scheduled market", "marketHash", marketHash, "blockTimestamp", blockTimestamp, "deadline", deadline)
+	s.wake()
+}
+
+// Cancels the scheduled reportOutcome tx for the given market, if any.
+// It is called once the outcome is reported, or once the market's reportOutcome tx succeeded.
+func (s *ReportScheduler) cancel(marketHash string) {
+	s.Lock()
+	defer s.Unlock()
+
+	market, ok := s.markets[marketHash]
+	if !ok {
+		return
+	}
+
+	if market.index >= 0 {
+		heap.Remove(&s.queue, market.index)
+	}
+
+	delete(s.markets, marketHash)
+
+	s.logger.Debug("cancelled market", "marketHash", marketHash)
+	s.wake()
+}
+
+// Recomputes the deadline of every scheduled market using the given voting period.
+// Markets waiting to retry a failed reportOutcome tx keep their retry deadline.
+func (s *ReportScheduler) reschedule(votingPeriod uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, market := range s.queue {
+		if market.attempts == 0 {
+			market.deadline = market.blockTimestamp + votingPeriod
+		}
+	}
+
+	heap.Init(&s.queue)
+
+	s.logger.Debug("rescheduled markets", "votingPeriod", votingPeriod, "markets", len(s.queue))
+	s.wake()
+}
+
+// Schedules the reportOutcome tx of the given market again after it failed, reportRetryDelay seconds from chain time.
+// Markets cancelled meanwhile, already scheduled again or out of attempts are not scheduled.
+func (s *ReportScheduler) retry(marketHash string) {
+	s.Lock()
+	defer s.Unlock()
+
+	market, ok := s.markets[marketHash]
+	if !ok || market.index >= 0 {
+		return
+	}
+
+	if market.attempts >= maxReportAttempts {
+		delete(s.markets, marketHash)
+		s.logger.Error("giving up reporting market after failed reportOutcome txs", "marketHash", marketHash, "attempts", market.attempts)
+
+		return
+	}
+
+	market.deadline = s.chainTime + reportRetryDelay
+	heap.Push(&s.queue, market)
+
+	s.logger.Warn("rescheduled market for another reportOutcome tx", "marketHash", marketHash, "attempts", market.attempts, "deadline", market.deadline)
+	s.wake()
+}
+
+// Schedules the market again after its reportOutcome tx was skipped because no key was permitted to send it,
+// e.g. while keys are ineligible or paused by low funds protection. Skipped txs do not use up the market's attempts.
+func (s *ReportScheduler) skipped(marketHash string) {
+	s.Lock()
+	if market, ok := s.markets[marketHash]; ok && market.index < 0 && market.attempts > 0 {
+		market.attempts--
+	}
+	s.Unlock()
+
+	s.retry(marketHash)
+}
+
+// Advances chain time to the given block timestamp.
+// Timestamps older than the current chain time are ignored.
+func (s *ReportScheduler) updateChainTime(blockTimestamp uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	if blockTimestamp <= s.chainTime {
+		return
+	}
+
+	s.chainTime = blockTimestamp
+	s.wake()
+}
+
+// Returns the latest known block timestamp.
+func (s *ReportScheduler) currentChainTime() uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.chainTime
+}
+
+// Reads the latest block timestamp from the chain and advances chain time accordingly.
+func (s *ReportScheduler) refreshChainTime() {
+	block, err := s.reporterService.txService.client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	if err != nil {
+		s.logger.Error("failed to fetch latest block", "err", err)
+
+		return
+	}
+
+	s.updateChainTime(block.Timestamp)
+}
+
+// Notifies the scheduling loop without blocking. Must be called with the lock held.
+func (s *ReportScheduler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Starts the scheduling loop for the ReportScheduler.
+// Each iteration pops every market whose deadline has been reached in chain time and queues its reportOutcome tx.
+// Popped markets stay scheduled outside the heap until the tx succeeds or is retried,
+// and are scheduled again right away if no key may send their tx.
+// The loop then sleeps until it is woken by a state change or until the next deadline is estimated to be reached,
+// at which point chain time is re-read from the chain. It returns once the scheduler is closed.
+func (s *ReportScheduler) startSchedulingLoop() {
+	for {
+		s.Lock()
+
+		var due []scheduledMarket
+
+		for len(s.queue) > 0 && s.queue[0].deadline <= s.chainTime {
+			market, _ := heap.Pop(&s.queue).(*scheduledMarket)
+			market.attempts++
+
+			due = append(due, *market)
+		}
+
+		var (
+			timer   *time.Timer
+			timerCh <-chan time.Time
+		)
+
+		if len(s.queue) > 0 {
+			timer = time.NewTimer(time.Duration(s.queue[0].deadline-s.chainTime) * time.Second)
+			timerCh = timer.C
+		}
+
+		chainTime := s.chainTime
+
+		s.Unlock()
+
+		for _, market := range due {
+			s.logger.Debug(
+				"processing market item",
+				"marketHash", market.marketHash,
+				"blockTimestamp", market.blockTimestamp,
+				"deadline", market.deadline,
+				"attempts", market.attempts,
+				"chainTime", chainTime,
+			)
+			if !s.reporterService.queueReportingTx(context.Background(), ReportOutcome, market.marketHash, -1) {
+				s.skipped(market.marketHash)
+			}
+		}
+
+		select {
+		case <-s.wakeCh:
+		case <-timerCh:
+			s.refreshChainTime()
+		case <-s.closeCh:
+			if timer != nil {
+				timer.Stop()
+			}
+
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Stops the scheduling loop. Scheduled markets are no longer reported afterwards.
+func (s *ReportScheduler) close() {
+	close(s.closeCh)
+}
+
+// Min-heap of scheduled markets ordered by deadline, implementing heap.Interface.
+type scheduledMarketQueue []*scheduledMarket
+
+func (q scheduledMarketQueue) Len() int { return len(q) }
+
+func (q scheduledMarketQueue) Less(i, j int) bool { return q[i].deadline < q[j].deadline }
+
+func (q scheduledMarketQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduledMarketQueue) Push(x interface{}) {
+	market, _ := x.(*scheduledMarket)
+	market.index = len(*q)
+	*q = append(*q, market)
+}
+
+func (q *scheduledMarketQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	market := old[n-1]
+	old[n-1] = nil
+	market.index = -1
+	*q = old[:n-1]
+
+	return market
+}
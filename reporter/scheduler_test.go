@@ -0,0 +1,295 @@
+package reporter
+
+import (
+	"container/heap"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/reporter/proto"
+)
+
+const (
+	testMarketA = "0x000000000000000000000000000000000000000000000000000000000000000a"
+	testMarketB = "0x000000000000000000000000000000000000000000000000000000000000000b"
+	testMarketC = "0x000000000000000000000000000000000000000000000000000000000000000c"
+)
+
+// Creates a report scheduler at the given chain time with the given voting period, without starting its loop.
+func newTestReportScheduler(t *testing.T, chainTime uint64, votingPeriod uint64) *ReportScheduler {
+	t.Helper()
+
+	d := newTestReporterService(t, nil, nil)
+	d.votingPeriod.seconds.Store(votingPeriod)
+
+	scheduler := &ReportScheduler{
+		logger:          hclog.NewNullLogger(),
+		reporterService: d,
+		markets:         make(map[string]*scheduledMarket),
+		chainTime:       chainTime,
+		wakeCh:          make(chan struct{}, 1),
+		closeCh:         make(chan struct{}),
+	}
+	d.scheduler = scheduler
+
+	return scheduler
+}
+
+// Returns the hashes of the scheduled markets in deadline order, emptying the queue.
+func popScheduledMarkets(s *ReportScheduler) []string {
+	var hashes []string
+
+	for s.queue.Len() > 0 {
+		market := s.queue[0]
+		s.cancel(market.marketHash)
+		hashes = append(hashes, market.marketHash)
+	}
+
+	return hashes
+}
+
+func checkMarketOrder(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("markets = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("markets = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReportSchedulerOrdering(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+
+	s.schedule(testMarketA, 300)
+	s.schedule(testMarketB, 100)
+	s.schedule(testMarketC, 200)
+
+	if market := s.markets[testMarketB]; market.deadline != 200 {
+		t.Errorf("deadline = %d, want the block timestamp plus the voting period 200", market.deadline)
+	}
+
+	// scheduling an already scheduled market replaces its deadline
+	s.schedule(testMarketB, 400)
+
+	if len(s.markets) != 3 || s.queue.Len() != 3 {
+		t.Fatalf("scheduled %d markets in a queue of %d, want 3", len(s.markets), s.queue.Len())
+	}
+
+	checkMarketOrder(t, popScheduledMarkets(s), testMarketC, testMarketA, testMarketB)
+}
+
+func TestReportSchedulerReschedule(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+
+	s.schedule(testMarketA, 100)
+	s.schedule(testMarketB, 200)
+
+	s.reschedule(500)
+
+	for hash, deadline := range map[string]uint64{testMarketA: 600, testMarketB: 700} {
+		if market := s.markets[hash]; market.deadline != deadline {
+			t.Errorf("deadline of %s = %d after reschedule, want %d", hash, market.deadline, deadline)
+		}
+	}
+
+	checkMarketOrder(t, popScheduledMarkets(s), testMarketA, testMarketB)
+}
+
+func TestReportSchedulerCancel(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+
+	s.schedule(testMarketA, 100)
+	s.schedule(testMarketB, 200)
+	s.schedule(testMarketC, 300)
+
+	s.cancel(testMarketB)
+	s.cancel(testMarketB)
+	s.cancel("0xunknown")
+
+	if _, ok := s.markets[testMarketB]; ok {
+		t.Error("cancelled market is still scheduled")
+	}
+
+	checkMarketOrder(t, popScheduledMarkets(s), testMarketA, testMarketC)
+}
+
+func TestReportSchedulerChainTime(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+
+	s.updateChainTime(900)
+
+	if s.chainTime != 1000 {
+		t.Errorf("chain time = %d after an older block, want 1000", s.chainTime)
+	}
+
+	s.updateChainTime(1010)
+
+	if s.chainTime != 1010 {
+		t.Errorf("chain time = %d, want 1010", s.chainTime)
+	}
+}
+
+func TestReportSchedulerFiresOnChainTime(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+	txChan := s.reporterService.keys[0].txChan
+
+	// the deadlines are long past in wall time, but not yet reached in chain time
+	s.schedule(testMarketA, 950)
+	s.schedule(testMarketB, 5000)
+
+	go s.startSchedulingLoop()
+	t.Cleanup(s.close)
+
+	select {
+	case tx := <-txChan:
+		t.Fatalf("queued %s tx on %s before its deadline in chain time", tx.functionType, tx.report.MarketHash)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.updateChainTime(1050)
+
+	select {
+	case tx := <-txChan:
+		if tx.functionType != ReportOutcome || tx.report.MarketHash != testMarketA {
+			t.Errorf("queued %s tx on %s, want %s on %s", tx.functionType, tx.report.MarketHash, ReportOutcome, testMarketA)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reportOutcome tx queued once the deadline was reached in chain time")
+	}
+
+	select {
+	case tx := <-txChan:
+		t.Fatalf("queued %s tx on %s before its deadline in chain time", tx.functionType, tx.report.MarketHash)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// the fired market stays scheduled outside the queue until its tx succeeds
+	if market, ok := s.markets[testMarketA]; !ok || market.index >= 0 || market.attempts != 1 || s.queue.Len() != 1 {
+		t.Errorf("scheduled markets = %v in a queue of %d, want %s out of the queue after 1 attempt", s.markets, s.queue.Len(), testMarketA)
+	}
+}
+
+// Pops the due markets as the scheduling loop does, returning their hashes.
+func popDueMarkets(s *ReportScheduler) []string {
+	var hashes []string
+
+	for s.queue.Len() > 0 && s.queue[0].deadline <= s.chainTime {
+		market, _ := heap.Pop(&s.queue).(*scheduledMarket)
+		market.attempts++
+		hashes = append(hashes, market.marketHash)
+	}
+
+	return hashes
+}
+
+func TestReportSchedulerRetry(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+
+	s.schedule(testMarketA, 900)
+	s.schedule(testMarketB, 900)
+	checkMarketOrder(t, popDueMarkets(s), testMarketA, testMarketB)
+
+	// a failed tx queues the market again after the retry delay in chain time
+	s.retry(testMarketA)
+	s.retry(testMarketA)
+
+	if market := s.markets[testMarketA]; market.index < 0 || market.deadline != 1000+reportRetryDelay {
+		t.Fatalf("market = %+v after a failed tx, want it queued at %d", market, 1000+reportRetryDelay)
+	}
+
+	if got := popDueMarkets(s); len(got) != 0 {
+		t.Fatalf("retried %v before the retry delay", got)
+	}
+
+	// a succeeded tx unschedules the market, so a later failure of another key's tx does not retry it
+	s.cancel(testMarketB)
+	s.retry(testMarketB)
+
+	if _, ok := s.markets[testMarketB]; ok {
+		t.Error("market reported by a succeeded tx was retried")
+	}
+
+	// the market is given up once out of attempts
+	for attempt := 1; attempt < maxReportAttempts; attempt++ {
+		s.updateChainTime(s.chainTime + reportRetryDelay)
+		checkMarketOrder(t, popDueMarkets(s), testMarketA)
+		s.retry(testMarketA)
+	}
+
+	if market, ok := s.markets[testMarketA]; ok {
+		t.Errorf("market = %+v after %d failed txs, want it given up", market, maxReportAttempts)
+	}
+}
+
+func TestReportSchedulerRetriedOnFailedTx(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+	d := s.reporterService
+	key := d.keys[0]
+
+	s.schedule(testMarketA, 900)
+	checkMarketOrder(t, popDueMarkets(s), testMarketA)
+
+	// no chain is reachable, so the reportOutcome tx fails
+	d.config.Store(&ReporterConfig{TxConfirmationTimeout: time.Millisecond})
+	newFakeChain(t, d)
+
+	key.txChan <- &ReportingTx{functionType: ReportOutcome, report: &proto.Report{MarketHash: testMarketA}, key: key}
+	close(key.txChan)
+	d.processTxsFromQueue(key)
+
+	if market := s.markets[testMarketA]; market == nil || market.index < 0 {
+		t.Errorf("market = %+v after a failed reportOutcome tx, want it queued again", market)
+	}
+}
+
+func TestReportSchedulerRetriedOnSkippedTx(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+	key := s.reporterService.keys[0]
+
+	// no key is permitted to report, so the reportOutcome tx is skipped
+	key.eligibility.actions[ReportOutcome] = &ActionStatus{Allowed: false, Reason: "missing role"}
+
+	s.schedule(testMarketA, 900)
+
+	go s.startSchedulingLoop()
+	t.Cleanup(s.close)
+
+	// the skipped market is queued again without using up an attempt
+	queued := func() bool {
+		s.Lock()
+		defer s.Unlock()
+
+		market := s.markets[testMarketA]
+
+		return market != nil && market.index >= 0 && market.deadline == 1000+reportRetryDelay && market.attempts == 0
+	}
+
+	for start := time.Now(); !queued(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("skipped market was not queued again")
+		}
+	}
+
+	key.eligibility.Lock()
+	key.eligibility.actions[ReportOutcome] = &ActionStatus{Allowed: true}
+	key.eligibility.Unlock()
+
+	s.updateChainTime(1000 + reportRetryDelay)
+
+	select {
+	case tx := <-key.txChan:
+		if tx.functionType != ReportOutcome || tx.report.MarketHash != testMarketA {
+			t.Errorf("queued %s tx on %s, want %s on %s", tx.functionType, tx.report.MarketHash, ReportOutcome, testMarketA)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reportOutcome tx queued once the key was permitted to report")
+	}
+}
@@ -0,0 +1,266 @@
+package reporter
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sx-network/sx-reporter/helper/crypto"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+// Represents the type of signer used for reporting transactions.
+type SignerType string
+
+// Constants representing the supported signer types.
+const (
+	// LocalSigner signs in-process with the reporter key from the secrets manager [Default]
+	LocalSigner SignerType = "local"
+	// Web3Signer signs remotely with eth_signTransaction
+	Web3Signer SignerType = "web3signer"
+	// ClefSigner signs remotely with account_signTransaction
+	ClefSigner SignerType = "clef"
+	// BackendSigner signs within the secrets manager backend, such as a Vault signing plugin
+	BackendSigner SignerType = "secrets-manager"
+)
+
+var errSignerClosed = errors.New("signer is closed")
+
+const (
+	// Default timeout for remote signing requests.
+	defaultRemoteSignerTimeout = 30 * time.Second
+)
+
+// Holds configuration options for the transaction signer.
+type SignerConfig struct {
+	Type    SignerType    // Type of the signer.
+	URL     string        // JSON-RPC URL of a remote signer.
+	Address string        // Address of the reporter account held by a remote signer.
+	Timeout time.Duration // Timeout for remote signing requests.
+}
+
+// Signs reporting transactions on behalf of the reporter account.
+type Signer interface {
+	// Type returns the type of the signer.
+	Type() SignerType
+	// Address returns the reporter address transactions are signed for.
+	Address() ethgo.Address
+	// SignTx signs the transaction for the given chain ID and returns its RLP encoding.
+	SignTx(tx *ethgo.Transaction, chainID *big.Int) ([]byte, error)
+}
+
+// Creates the signer described by the given configuration.
+// The in-process signer backed by the key held under the secret name is used if no configuration is provided.
+func newSigner(config *SignerConfig, secretsManager secrets.SecretsManager, secretName string) (Signer, error) {
+	if config == nil || config.Type == "" || config.Type == LocalSigner {
+		return newSecretsManagerSigner(secretsManager, secretName)
+	}
+
+	switch config.Type {
+	case Web3Signer, ClefSigner:
+		return newRemoteSigner(config)
+	case BackendSigner:
+		return newBackendSigner(secretsManager)
+	default:
+		return nil, fmt.Errorf("signer type '%s' not found", config.Type)
+	}
+}
+
+// Signs transactions in-process with a reporter key held by the secrets manager.
+// The key is read, and decrypted for encrypted keystores, once when the signer is created and kept in memory
+// until the signer is closed, which zeroes it, so a slow keystore decryption is not paid on every tx.
+type secretsManagerSigner struct {
+	key     *wallet.Key
+	privKey *ecdsa.PrivateKey // Private key of the reporter key, nil once closed.
+	address ethgo.Address
+	sync.RWMutex
+}
+
+// Creates a signer for the reporter key held under the secret name by the given secrets manager.
+func newSecretsManagerSigner(secretsManager secrets.SecretsManager, secretName string) (*secretsManagerSigner, error) {
+	if !secretsManager.HasSecret(secretName) {
+		return nil, ErrECDSAKeyNotFound
+	}
+
+	privKeyBytes, err := secretsManager.GetSecret(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve private key from SecretsManager: %w", err)
+	}
+
+	defer crypto.ZeroBytes(privKeyBytes)
+
+	privKey, err := crypto.BytesToECDSAPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretsManagerSigner{
+		key:     wallet.NewKey(privKey),
+		privKey: privKey,
+		address: ethgo.Address(crypto.PubKeyToAddress(&privKey.PublicKey)),
+	}, nil
+}
+
+// Type returns LocalSigner.
+func (s *secretsManagerSigner) Type() SignerType {
+	return LocalSigner
+}
+
+// Address returns the address of the reporter key.
+func (s *secretsManagerSigner) Address() ethgo.Address {
+	return s.address
+}
+
+// SignTx signs the transaction with the reporter key using EIP-155.
+func (s *secretsManagerSigner) SignTx(tx *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.privKey == nil {
+		return nil, errSignerClosed
+	}
+
+	signedTx, err := wallet.NewEIP155Signer(chainID.Uint64()).SignTx(tx, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return signedTx.MarshalRLPTo(nil)
+}
+
+// Close zeroes the reporter key held in memory. Transactions can no longer be signed afterwards.
+func (s *secretsManagerSigner) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.privKey != nil {
+		crypto.ZeroKey(s.privKey)
+		s.privKey = nil
+		s.key = nil
+	}
+
+	return nil
+}
+
+// Signs transactions within the secrets manager backend, so the reporter key never leaves it.
+type backendSigner struct {
+	txSigner secrets.TxSigner
+	address  ethgo.Address
+}
+
+// Creates a signer for the signing key held by the given secrets manager.
+func newBackendSigner(secretsManager secrets.SecretsManager) (*backendSigner, error) {
+	txSigner, ok := secretsManager.(secrets.TxSigner)
+	if !ok {
+		return nil, fmt.Errorf("secrets manager does not support %s signer", BackendSigner)
+	}
+
+	address, err := txSigner.SignerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return &backendSigner{
+		txSigner: txSigner,
+		address:  ethgo.HexToAddress(address),
+	}, nil
+}
+
+// Type returns BackendSigner.
+func (s *backendSigner) Type() SignerType {
+	return BackendSigner
+}
+
+// Address returns the address of the signing key.
+func (s *backendSigner) Address() ethgo.Address {
+	return s.address
+}
+
+// SignTx signs the transaction within the secrets manager backend.
+// The signed transaction is decoded and rejected unless it is the requested transaction signed by the signing key.
+func (s *backendSigner) SignTx(tx *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	request := &secrets.SignTxRequest{
+		Data:     tx.Input,
+		Value:    tx.Value,
+		Gas:      tx.Gas,
+		GasPrice: tx.GasPrice,
+		Nonce:    tx.Nonce,
+		ChainID:  chainID,
+	}
+
+	if tx.To != nil {
+		request.To = tx.To.String()
+	}
+
+	raw, err := s.txSigner.SignTx(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignedTx(raw, s.address, tx, chainID); err != nil {
+		return nil, fmt.Errorf("secrets manager returned an unexpected transaction, %w", err)
+	}
+
+	return raw, nil
+}
+
+// Checks that a signed transaction returned by a signer outside this process is the requested transaction
+// for the chain ID, signed by the given address.
+func verifySignedTx(raw []byte, address ethgo.Address, tx *ethgo.Transaction, chainID *big.Int) error {
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return fmt.Errorf("failed to decode it, %w", err)
+	}
+
+	if signedTx.ChainId().Cmp(chainID) != 0 {
+		return fmt.Errorf("chainId %s, expected %s", signedTx.ChainId(), chainID)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return fmt.Errorf("failed to recover its sender, %w", err)
+	}
+
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	gasPrice := new(big.Int).SetUint64(tx.GasPrice)
+
+	switch {
+	case sender != common.Address(address):
+		return fmt.Errorf("sender %s, expected %s", sender, address)
+	case signedTx.Nonce() != tx.Nonce:
+		return fmt.Errorf("nonce %d, expected %d", signedTx.Nonce(), tx.Nonce)
+	case !sameRecipient(signedTx.To(), tx.To):
+		return fmt.Errorf("to %v, expected %v", signedTx.To(), tx.To)
+	case !bytes.Equal(signedTx.Data(), tx.Input):
+		return fmt.Errorf("data %x, expected %x", signedTx.Data(), tx.Input)
+	case signedTx.Value().Cmp(value) != 0:
+		return fmt.Errorf("value %s, expected %s", signedTx.Value(), value)
+	case signedTx.Gas() != tx.Gas:
+		return fmt.Errorf("gas %d, expected %d", signedTx.Gas(), tx.Gas)
+	case signedTx.GasPrice().Cmp(gasPrice) != 0:
+		return fmt.Errorf("gasPrice %s, expected %s", signedTx.GasPrice(), gasPrice)
+	}
+
+	return nil
+}
+
+// Reports whether the recipient of a signed transaction is the requested one, both being nil for contract creations.
+func sameRecipient(to *common.Address, expected *ethgo.Address) bool {
+	if to == nil || expected == nil {
+		return to == nil && expected == nil
+	}
+
+	return *to == common.Address(*expected)
+}
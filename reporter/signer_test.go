@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sx-network/sx-reporter/infra/secrets"
+)
+
+// Secrets manager signing transactions within the backend, as a Vault signing plugin would,
+// signing each requested transaction with the key after applying modify to it.
+// The requested chain ID is used unless chainID is set.
+type fakeBackendSigner struct {
+	secrets.SecretsManager
+	t       *testing.T
+	keyHex  string
+	chainID *big.Int
+	modify  func(tx *types.LegacyTx)
+}
+
+func (f *fakeBackendSigner) SignerAddress() (string, error) {
+	return testSignerAddress, nil
+}
+
+func (f *fakeBackendSigner) SignTx(request *secrets.SignTxRequest) ([]byte, error) {
+	if f.keyHex == "" {
+		return nil, errors.New("signing plugin unavailable")
+	}
+
+	args := remoteSignerTxArgs{
+		Gas:      hexutil.Uint64(request.Gas),
+		GasPrice: (*hexutil.Big)(new(big.Int).SetUint64(request.GasPrice)),
+		Value:    (*hexutil.Big)(request.Value),
+		Nonce:    hexutil.Uint64(request.Nonce),
+		Data:     request.Data,
+		ChainID:  (*hexutil.Big)(request.ChainID),
+	}
+
+	if request.To != "" {
+		to := common.NewMixedcaseAddress(common.HexToAddress(request.To))
+		args.To = &to
+	}
+
+	return signStubTx(f.t, args, f.keyHex, f.chainID, f.modify), nil
+}
+
+func TestBackendSigner(t *testing.T) {
+	signer, err := newSigner(&SignerConfig{Type: BackendSigner}, &fakeBackendSigner{t: t, keyHex: testKeyHex}, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	if !strings.EqualFold(signer.Address().String(), testSignerAddress) {
+		t.Errorf("Address() = %s, want %s", signer.Address(), testSignerAddress)
+	}
+
+	raw, err := signer.SignTx(testSignerTx(), big.NewInt(416))
+	if err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	if signedTx.Nonce() != 7 || !bytes.Equal(signedTx.Data(), []byte{0xca, 0xfe}) {
+		t.Errorf("signed nonce, data = %d, %x, want 7, cafe", signedTx.Nonce(), signedTx.Data())
+	}
+
+	// signing errors from the backend are returned as is
+	signer, err = newSigner(&SignerConfig{Type: BackendSigner}, &fakeBackendSigner{t: t}, "")
+	if err != nil {
+		t.Fatalf("newSigner() error = %v", err)
+	}
+
+	if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil || err.Error() != "signing plugin unavailable" {
+		t.Errorf("SignTx() error = %v, want signing plugin unavailable", err)
+	}
+}
+
+func TestBackendSignerRejectsUnexpectedTx(t *testing.T) {
+	otherKeyHex := "8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63"
+
+	tests := []struct {
+		name    string
+		keyHex  string
+		chainID *big.Int
+		modify  func(tx *types.LegacyTx)
+		wantErr string
+	}{
+		{"other sender", otherKeyHex, nil, nil, "sender"},
+		{"chain id", testKeyHex, big.NewInt(1), nil, "chainId 1, expected 416"},
+		{"nonce", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Nonce++ }, "nonce 8, expected 7"},
+		{"to", testKeyHex, nil, func(tx *types.LegacyTx) { tx.To = &common.Address{0xbb} }, "to"},
+		{"contract creation", testKeyHex, nil, func(tx *types.LegacyTx) { tx.To = nil }, "to <nil>"},
+		{"data", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Data = []byte{0xbe, 0xef} }, "data beef, expected cafe"},
+		{"value", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Value = big.NewInt(6) }, "value 6, expected 5"},
+		{"gas", testKeyHex, nil, func(tx *types.LegacyTx) { tx.Gas = 50000 }, "gas 50000, expected 21000"},
+		{"gas price", testKeyHex, nil, func(tx *types.LegacyTx) { tx.GasPrice = big.NewInt(1) }, "gasPrice 1, expected 1000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &fakeBackendSigner{t: t, keyHex: tt.keyHex, chainID: tt.chainID, modify: tt.modify}
+
+			signer, err := newSigner(&SignerConfig{Type: BackendSigner}, backend, "")
+			if err != nil {
+				t.Fatalf("newSigner() error = %v", err)
+			}
+
+			wantErr := "secrets manager returned an unexpected transaction, " + tt.wantErr
+			if _, err := signer.SignTx(testSignerTx(), big.NewInt(416)); err == nil || !strings.HasPrefix(err.Error(), wantErr) {
+				t.Errorf("SignTx() error = %v, want %s", err, wantErr)
+			}
+		})
+	}
+}
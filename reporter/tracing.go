@@ -0,0 +1,78 @@
+package reporter
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer instrumenting the reporter.
+const tracerName = "github.com/sx-network/sx-reporter/reporter"
+
+// Attribute keys of the reporter's spans.
+const (
+	marketHashAttribute = attribute.Key("market.hash")
+	outcomeAttribute    = attribute.Key("market.outcome")
+	functionAttribute   = attribute.Key("reporter.function")
+	keyAttribute        = attribute.Key("reporter.key")
+	nonceAttribute      = attribute.Key("tx.nonce")
+	txHashAttribute     = attribute.Key("tx.hash")
+	txTryAttribute      = attribute.Key("tx.try")
+)
+
+// Propagates W3C trace context between the reporter and the AMQP publishers and verify outcome API.
+var tracePropagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Returns the tracer of the configured tracer provider, or of the global provider if none is configured.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return provider.Tracer(tracerName)
+}
+
+// Records the error on the span and marks the span as failed.
+func recordSpanError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Adapts AMQP message headers to carry trace context.
+type amqpHeaderCarrier amqp.Table
+
+// Get returns the string value of the header, empty if missing or not a string.
+func (c amqpHeaderCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+
+	return value
+}
+
+// Set sets the header to the value.
+func (c amqpHeaderCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys returns the names of the headers.
+func (c amqpHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Returns the context carrying the trace context propagated in the AMQP message headers, if any.
+func extractAMQPTraceContext(ctx context.Context, headers amqp.Table) context.Context {
+	if headers == nil {
+		return ctx
+	}
+
+	return tracePropagator.Extract(ctx, amqpHeaderCarrier(headers))
+}
@@ -0,0 +1,264 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testMarketHash = "0x0e1c5b4c8b3a2f6f0d0a3e7f2d1c4b5a69788796a5b4c3d2e1f0011223344556"
+
+// Returns a tracer provider exporting ended spans synchronously to an in-memory exporter.
+func newTestTracerProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return provider, exporter
+}
+
+// Returns the ended span of the given name, failing the test if there is not exactly one.
+func endedSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+
+	var found []tracetest.SpanStub
+
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			found = append(found, span)
+		}
+	}
+
+	if len(found) != 1 {
+		t.Fatalf("found %d ended %s spans, want 1", len(found), name)
+	}
+
+	return found[0]
+}
+
+// Checks that the span is a child of the parent span.
+func checkParent(t *testing.T, span tracetest.SpanStub, parent trace.SpanContext) {
+	t.Helper()
+
+	if span.Parent.TraceID() != parent.TraceID() || span.Parent.SpanID() != parent.SpanID() {
+		t.Errorf("%s span parent = %s/%s, want %s/%s",
+			span.Name, span.Parent.TraceID(), span.Parent.SpanID(), parent.TraceID(), parent.SpanID())
+	}
+}
+
+// Returns AMQP headers carrying the trace context of a publisher span, along with the span's context.
+func publishedHeaders(t *testing.T, provider trace.TracerProvider) (amqp.Table, trace.SpanContext) {
+	t.Helper()
+
+	ctx, span := provider.Tracer("publisher").Start(context.Background(), "publish")
+	defer span.End()
+
+	headers := amqp.Table{"content-encoding": "json"}
+	tracePropagator.Inject(ctx, amqpHeaderCarrier(headers))
+
+	if _, ok := headers["traceparent"].(string); !ok {
+		t.Fatalf("headers = %v, want a traceparent header", headers)
+	}
+
+	return headers, span.SpanContext()
+}
+
+func TestExtractAMQPTraceContext(t *testing.T) {
+	provider, _ := newTestTracerProvider(t)
+	headers, published := publishedHeaders(t, provider)
+
+	extracted := trace.SpanContextFromContext(extractAMQPTraceContext(context.Background(), headers))
+	if !extracted.IsRemote() || extracted.TraceID() != published.TraceID() || extracted.SpanID() != published.SpanID() {
+		t.Errorf("extracted span context = %v, want the remote publisher span %v", extracted, published)
+	}
+
+	for name, headers := range map[string]amqp.Table{
+		"nil headers":           nil,
+		"no trace context":      {"content-encoding": "json"},
+		"non-string header":     {"traceparent": int32(1)},
+		"malformed traceparent": {"traceparent": "00-zz"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if extracted := trace.SpanContextFromContext(extractAMQPTraceContext(context.Background(), headers)); extracted.IsValid() {
+				t.Errorf("extracted span context = %v, want none", extracted)
+			}
+		})
+	}
+}
+
+func TestMQReceiveSpanTree(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+	d := newTestReporterService(t, nil, provider)
+
+	mq := &MQService{
+		logger:          hclog.NewNullLogger(),
+		config:          &MQConfig{QueueConfig: &QueueConfig{QueueName: "reporter"}},
+		reporterService: d,
+	}
+
+	headers, published := publishedHeaders(t, provider)
+	body, _ := json.Marshal(map[string]interface{}{"MarketHash": testMarketHash, "Outcome": 1})
+
+	report, err := mq.receive(amqp.Delivery{Headers: headers, Body: body})
+	if err != nil {
+		t.Fatalf("receive() error = %v", err)
+	}
+
+	d.queueReportingTx(report.ctx, ProposeOutcome, report.report.MarketHash, report.report.Outcome)
+
+	receive := endedSpan(t, exporter, "mq.receive")
+	queue := endedSpan(t, exporter, "queueReportingTx")
+
+	// publisher -> mq.receive -> queueReportingTx -> queued tx
+	checkParent(t, receive, published)
+	checkParent(t, queue, receive.SpanContext)
+
+	if receive.SpanKind != trace.SpanKindConsumer {
+		t.Errorf("mq.receive span kind = %s, want %s", receive.SpanKind, trace.SpanKindConsumer)
+	}
+
+	select {
+	case tx := <-d.keys[0].txChan:
+		if txSpan := trace.SpanContextFromContext(tx.context()); txSpan.SpanID() != queue.SpanContext.SpanID() {
+			t.Errorf("queued tx span = %s, want the queueReportingTx span %s", txSpan.SpanID(), queue.SpanContext.SpanID())
+		}
+	default:
+		t.Fatal("no reporting tx queued")
+	}
+}
+
+func TestMQReceiveInvalidMessageSpan(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+
+	mq := &MQService{
+		logger:          hclog.NewNullLogger(),
+		config:          &MQConfig{QueueConfig: &QueueConfig{QueueName: "reporter"}},
+		reporterService: newTestReporterService(t, nil, provider),
+	}
+
+	if _, err := mq.receive(amqp.Delivery{Body: []byte("{")}); err == nil {
+		t.Fatal("receive() error = nil, want a parse error")
+	}
+
+	receive := endedSpan(t, exporter, "mq.receive")
+	if receive.Status.Code != codes.Error || len(receive.Events) == 0 {
+		t.Errorf("mq.receive span status = %v with %d events, want an error recorded", receive.Status, len(receive.Events))
+	}
+
+	if receive.Parent.IsValid() {
+		t.Errorf("mq.receive span parent = %v, want a root span", receive.Parent)
+	}
+}
+
+func TestMQReceiveInvalidOutcomeSkipped(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+	d := newTestReporterService(t, nil, provider)
+	d.auditLog = newAuditLog(t.TempDir())
+
+	mq := &MQService{
+		logger:          hclog.NewNullLogger(),
+		config:          &MQConfig{QueueConfig: &QueueConfig{QueueName: "reporter"}},
+		reporterService: d,
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"MarketHash": testMarketHash, "Outcome": 7})
+
+	// an error would restart the consumer
+	if report, err := mq.receive(amqp.Delivery{Body: body}); report != nil || err != nil {
+		t.Fatalf("receive() = %v, %v, want the report skipped", report, err)
+	}
+
+	receive := endedSpan(t, exporter, "mq.receive")
+	if receive.Status.Code == codes.Error || len(receive.Events) != 1 || receive.Events[0].Name != "skipped" {
+		t.Errorf("mq.receive span status = %v with events %v, want a skipped event", receive.Status, receive.Events)
+	}
+
+	entries, err := d.AuditTrail(testMarketHash)
+	if err != nil {
+		t.Fatalf("AuditTrail() error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Event != AuditMQMessage || entries[1].Event != AuditSkipped ||
+		entries[1].Reason != mqSkippedInvalidOutcome || entries[1].Error == "" {
+		t.Errorf("audit trail = %+v, want the message and its skip", entries)
+	}
+}
+
+func TestVerifyMarketPropagatesTraceContext(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+
+	traceparents := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents <- r.Header.Get("traceparent")
+
+		_, _ = w.Write([]byte(`{"Outcome":2,"Timestamp":1700000000}`))
+	}))
+	defer server.Close()
+
+	d := newTestReporterService(t, &ReporterConfig{VerifyOutcomeURI: server.URL}, provider)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "vote")
+
+	outcome, err := d.verifyMarket(ctx, testMarketHash)
+	parent.End()
+
+	if err != nil || outcome != 2 {
+		t.Fatalf("verifyMarket() = %d, %v, want 2", outcome, err)
+	}
+
+	verify := endedSpan(t, exporter, "verifyMarket")
+	checkParent(t, verify, parent.SpanContext())
+
+	if verify.SpanKind != trace.SpanKindClient {
+		t.Errorf("verifyMarket span kind = %s, want %s", verify.SpanKind, trace.SpanKindClient)
+	}
+
+	// the API continues the trace as a child of the client span
+	want := "00-" + verify.SpanContext.TraceID().String() + "-" + verify.SpanContext.SpanID().String() + "-01"
+	if traceparent := <-traceparents; traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+
+	attributes := make(map[string]int64)
+	for _, kv := range verify.Attributes {
+		attributes[string(kv.Key)] = kv.Value.AsInt64()
+	}
+
+	if attributes["http.status_code"] != http.StatusOK || attributes[string(outcomeAttribute)] != 2 {
+		t.Errorf("verifyMarket span attributes = %v, want status code 200 and outcome 2", verify.Attributes)
+	}
+}
+
+func TestVerifyMarketErrorSpan(t *testing.T) {
+	provider, exporter := newTestTracerProvider(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	d := newTestReporterService(t, &ReporterConfig{VerifyOutcomeURI: server.URL}, provider)
+
+	if _, err := d.verifyMarket(context.Background(), testMarketHash); err == nil {
+		t.Fatal("verifyMarket() error = nil, want a non-200 error")
+	}
+
+	if verify := endedSpan(t, exporter, "verifyMarket"); verify.Status.Code != codes.Error {
+		t.Errorf("verifyMarket span status = %v, want an error", verify.Status)
+	}
+}
@@ -2,37 +2,43 @@ package reporter
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 	"time"
 
-	"github.com/btcsuite/btcd/btcec"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/helper/crypto"
 	"github.com/sx-network/sx-reporter/helper/types"
 	"github.com/sx-network/sx-reporter/infra/secrets"
 	"github.com/sx-network/sx-reporter/reporter/proto"
 	"github.com/umbracle/ethgo"
 	ethgoabi "github.com/umbracle/ethgo/abi"
-	"github.com/umbracle/ethgo/contract"
 	"github.com/umbracle/ethgo/jsonrpc"
-	"github.com/umbracle/ethgo/wallet"
-	"golang.org/x/crypto/sha3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Constants defining the JSON-RPC host address and smart contract function signatures.
 const (
-	JSONRPCHost              = "https://rpc.sx-rollup-testnet.t.raas.gelato.cloud"
-	proposeOutcomeSCFunction = "function proposeOutcome(bytes32 marketHash, uint8 outcome)"
-	voteOutcomeSCFunction    = "function voteOutcome(bytes32 marketHash, uint8 outcome)"
-	reportOutcomeSCFunction  = "function reportOutcome(bytes32 marketHash)"
+	// Default time to wait for a sent tx to be mined.
+	defaultTxConfirmationTimeout = 5 * time.Minute
+	// Default timeout for JSON-RPC requests made outside the ethgo client.
+	defaultRPCTimeout = 30 * time.Second
+)
+
+// Constants defining the smart contract function signatures.
+const (
+	proposeOutcomeSCFunction  = "function proposeOutcome(bytes32 marketHash, uint8 outcome)"
+	voteOutcomeSCFunction     = "function voteOutcome(bytes32 marketHash, uint8 outcome)"
+	reportOutcomeSCFunction   = "function reportOutcome(bytes32 marketHash)"
+	emergencyReportSCFunction = "function emergencyReportOutcome(bytes32 marketHash, uint8 outcome)"
+	withdrawRewardsSCFunction = "function withdrawJuicedRewards(uint256 amount)"
+	transferSCFunction        = "function transfer(address to, uint256 amount) returns (bool)"
 )
 
 // Represents a service for interacting with transactions.
@@ -43,18 +49,20 @@ type TxService struct {
 
 // Constants representing transaction types.
 const (
-	ProposeOutcome string = "proposeOutcome"
-	VoteOutcome    string = "voteOutcome"
-	ReportOutcome  string = "reportOutcome"
+	ProposeOutcome         string = "proposeOutcome"
+	VoteOutcome            string = "voteOutcome"
+	ReportOutcome          string = "reportOutcome"
+	EmergencyReportOutcome string = "emergencyReportOutcome"
+	WithdrawRewards        string = "withdrawJuicedRewards"
+	TransferRewards        string = "transfer"
 )
 
-// Initializes a new TxService instance with the provided logger
+// Initializes a new TxService instance with the provided logger and JSON-RPC URL
 // and returns it along with any error encountered during initialization.
-func newTxService(logger hclog.Logger) (*TxService, error) {
-
-	client, err := jsonrpc.NewClient(JSONRPCHost)
+func newTxService(logger hclog.Logger, jsonRPCURL string) (*TxService, error) {
+	client, err := jsonrpc.NewClient(jsonRPCURL)
 	if err != nil {
-		logger.Error("failed to initialize new ethgo client")
+		logger.Error("failed to initialize new ethgo client", "err", err)
 
 		return nil, err
 	}
@@ -67,48 +75,52 @@ func newTxService(logger hclog.Logger) (*TxService, error) {
 	return txService, nil
 }
 
-func (d *ReporterService) GetPrivateKeyFromSecretsManager(keyName string) (*ecdsa.PrivateKey, error) {
-	// // Convert byte slice to hex-encoded string
-	// privateKeyString := hex.EncodeToString(privKeyBytes)
-
-	// Retrieve the private key bytes from the SecretsManager
-	privKeyBytes, err := d.secretsManager.GetSecret(keyName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve private key from SecretsManager: %v", err)
-	}
-
-	privKey, err := BytesToECDSAPrivateKey(privKeyBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	return privKey, nil
-}
-
 // Sends a transaction to the blockchain with retry logic
 // in case of failures. It constructs the transaction based on the provided
-// function type and report data. The transaction is attempted multiple times
-// with increasing gas price and nonce until it succeeds or reaches the maximum
-// number of tries. If the transaction fails due to a low nonce error, it retries
-// with a higher nonce.
-func (d *ReporterService) sendTxWithRetry(
-	functionType string,
-	report *proto.Report,
-) {
-
-	d.logger.Debug("functionType", functionType)
-	d.logger.Debug("validator key", secrets.ReporterKey)
-
-	privateKey, err := d.GetPrivateKeyFromSecretsManager(secrets.ReporterKey)
-	if err != nil {
-		d.txService.logger.Error("private key error")
+// function type and report data. The transaction is attempted multiple times,
+// re-estimating its gas limit and reading its gas price again on each try, until
+// it succeeds or reaches the maximum number of tries. The gas price is not bumped
+// between tries. If the transaction fails due to a low nonce error, it retries
+// with a higher nonce. It returns the success receipt, or nil if the transaction
+// could not be sent or mined successfully. Attempts and receipt confirmations are traced
+// as children of the trace context of the reporting tx.
+func (d *ReporterService) sendTxWithRetry(reportingTx *ReportingTx) *ethgo.Receipt {
+	functionType := reportingTx.functionType
+
+	report := reportingTx.report
+	if report == nil {
+		report = &proto.Report{}
 	}
 
-	const (
-		maxTxTries    = 4
-		txGasPriceWei = 1000000000
-		txGasLimitWei = 1000000
-	)
+	key := reportingTx.key
+	logger := d.txService.logger.With("key", key.name, "function", functionType, "marketHash", report.MarketHash)
+
+	ctx, span := d.tracer.Start(reportingTx.context(), "sendTxWithRetry", trace.WithAttributes(
+		functionAttribute.String(functionType),
+		marketHashAttribute.String(report.MarketHash),
+		keyAttribute.String(key.name),
+	))
+	defer span.End()
+
+	// records the failure to send or mine the tx on its span and in the audit trail, and alerts on it
+	fail := func(err error) {
+		recordSpanError(span, err)
+		d.auditTxFailure(reportingTx, err)
+		d.alerter.raise(&Alert{
+			Rule:     AlertTxFailed,
+			Severity: AlertCritical,
+			Summary:  fmt.Sprintf("%s tx of key %s permanently failed", functionType, key.name),
+			DedupKey: fmt.Sprintf("%s/%s/%s/%s", AlertTxFailed, key.name, functionType, report.MarketHash),
+			Details: map[string]string{
+				"key":        key.name,
+				"function":   functionType,
+				"marketHash": report.MarketHash,
+				"err":        err.Error(),
+			},
+		})
+	}
+
+	maxTxTries := d.config.Load().Gas.maxTxTries()
 
 	var functionSig string
 
@@ -116,209 +128,358 @@ func (d *ReporterService) sendTxWithRetry(
 
 	var functionArgs []interface{}
 
+	contractAddress := d.config.Load().SXNodeAddress
+
+	// the outcome is checked again right before encoding, as the uint8 ABI argument would silently wrap it
+	var outcome Outcome
+
+	switch functionType {
+	case ProposeOutcome, VoteOutcome, EmergencyReportOutcome:
+		var err error
+
+		if outcome, err = ParseOutcome(int64(report.Outcome)); err != nil {
+			logger.Error("refusing to send tx with invalid outcome", "outcome", report.Outcome, "err", err)
+			fail(err)
+
+			return nil
+		}
+	}
+
 	switch functionType {
 	case ProposeOutcome:
 		functionSig = proposeOutcomeSCFunction
 		functionName = ProposeOutcome
 
-		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash), report.Outcome)
+		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash), uint8(outcome))
 	case VoteOutcome:
 		functionSig = voteOutcomeSCFunction
 		functionName = VoteOutcome
 
-		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash), report.Outcome)
+		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash), uint8(outcome))
 	case ReportOutcome:
 		functionSig = reportOutcomeSCFunction
 		functionName = ReportOutcome
 
 		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash))
+	case EmergencyReportOutcome:
+		functionSig = emergencyReportSCFunction
+		functionName = EmergencyReportOutcome
+		contractAddress = d.config.Load().OutcomeReporterAddress
+
+		functionArgs = append(make([]interface{}, 0), types.StringToHash(report.MarketHash), uint8(outcome))
+	case WithdrawRewards:
+		functionSig = withdrawRewardsSCFunction
+		functionName = WithdrawRewards
+		contractAddress = d.config.Load().OutcomeReporterAddress
+
+		functionArgs = append(make([]interface{}, 0), reportingTx.amount)
+	case TransferRewards:
+		functionSig = transferSCFunction
+		functionName = TransferRewards
+		contractAddress = d.config.Load().WSXAddress
+
+		functionArgs = append(make([]interface{}, 0), ethgo.HexToAddress(reportingTx.recipient), reportingTx.amount)
 	}
 
 	abiContract, err := ethgoabi.NewABIFromList([]string{functionSig})
 	if err != nil {
-		d.txService.logger.Error(
+		logger.Error(
 			"failed to retrieve ethgo ABI",
-			"function", functionName,
 			"err", err,
 		)
+		fail(err)
 
-		return
+		return nil
 	}
 
-	validatorAddress, err := GetValidatorAddressFromSecretManager(d.secretsManager)
+	input, err := abiContract.GetMethod(functionName).Encode(functionArgs)
 	if err != nil {
-		return
-	}
-
-	d.logger.Debug("validatorAddress", validatorAddress.String())
-
-	c := contract.NewContract(
-		ethgo.Address(ethgo.HexToAddress(d.config.SXNodeAddress)),
-		abiContract,
-		contract.WithSender(wallet.NewKey(privateKey)),
-		contract.WithJsonRPC(d.txService.client.Eth()),
-	)
-
-	txn, err := c.Txn(
-		functionName,
-		functionArgs...,
-	)
-
-	if err != nil {
-		d.txService.logger.Error(
-			"failed to create txn via ethgo",
-			"function", functionName,
+		logger.Error(
+			"failed to encode txn input via ethgo",
 			"functionArgs", functionArgs,
 			"functionSig", abiContract,
 			"err", err,
 		)
+		fail(err)
+
+		return nil
+	}
 
-		return
+	chainID, err := d.txService.client.Eth().ChainID()
+	if err != nil {
+		logger.Error("failed to get chain id", "err", err)
+		fail(err)
+
+		return nil
 	}
 
+	from := key.address()
+	to := ethgo.HexToAddress(contractAddress)
+
 	txTry := uint64(0)
-	// currNonce := d.consensusInfo().Nonce
+	txHash := ethgo.ZeroHash
 
-	currNonce, err := d.getCurrentNonce(validatorAddress.String())
+	currNonce, err := key.nonces.current()
 	if err != nil {
-		d.txService.logger.Error("nonce error")
+		logger.Error("nonce error", "err", err)
+		fail(err)
+
+		return nil
 	}
 
 	for txTry < maxTxTries {
 
-		d.txService.logger.Debug(
-			"attempting tx with nonce",
-			"function", functionName,
-			"nonce", currNonce,
-			"try #", txTry,
-			"marketHash", report.MarketHash)
-
-		//TODO: derive these gas params better, have it dynamic?
-		txn.WithOpts(
-			&contract.TxnOpts{
-				// GasPrice: txGasPriceWei + (txTry * txGasPriceWei),
-				// GasLimit: txGasLimitWei,
-				Nonce:    currNonce,
-			},
-		)
+		logger.Debug("attempting tx with nonce", "nonce", currNonce, "try", txTry)
+		span.AddEvent("attempt", trace.WithAttributes(
+			nonceAttribute.Int64(int64(currNonce)),
+			txTryAttribute.Int64(int64(txTry)),
+		))
+
+		txn, err := d.buildTxn(from, to, input, currNonce)
+		if err != nil {
+			logger.Error(
+				"failed to build txn via ethgo",
+				"err", err,
+				"try", txTry,
+				"nonce", currNonce,
+			)
+			fail(err)
+
+			return nil
+		}
+
+		rawTxn, err := key.signer.SignTx(txn, chainID)
+		if err != nil {
+			logger.Error(
+				"failed to sign txn",
+				"err", err,
+				"try", txTry,
+				"nonce", currNonce,
+			)
+			fail(err)
+
+			return nil
+		}
 
 		// TODO: consider adding directly to txpool txpool.AddTx() instead of over local jsonrpc
 		// can use TxnPoolOperatorClient.AddTx()
-		err = txn.Do()
+		txHash, err = d.txService.client.Eth().SendRawTransaction(rawTxn)
 		if err != nil {
 			if strings.Contains(err.Error(), "nonce too low") {
 				// if nonce too low, retry with higher nonce
-				d.txService.logger.Debug(
+				logger.Debug(
 					"encountered nonce too low error trying to send raw txn via ethgo, retrying...",
-					"function", functionName,
-					"try #", txTry,
+					"try", txTry,
 					"nonce", currNonce,
-					"marketHash", report.MarketHash,
 				)
+				span.AddEvent("nonce too low")
+
+				// a tx with this nonce was already mined
+				key.nonces.mined(currNonce)
 
-				nonce, err := d.getCurrentNonce(validatorAddress.String())
+				currNonce, err = key.nonces.current()
 				if err != nil {
-					d.txService.logger.Error("nonce error")
+					logger.Error("nonce error", "err", err)
+					fail(err)
+
+					return nil
 				}
 
-				currNonce = Max(nonce, nonce+1)
 				txTry++
 
 				continue
 			} else {
 				// if any other error, just log and return for now
-				d.txService.logger.Error(
+				logger.Error(
 					"failed to send raw txn via ethgo due to non-recoverable error",
-					"function", functionName,
 					"err", err,
-					"try #", txTry,
+					"try", txTry,
 					"nonce", currNonce,
-					"marketHash", report.MarketHash,
 				)
+				fail(err)
 
-				return
+				return nil
+			}
+		}
+
+		sent := &AuditEntry{
+			MarketHash: report.MarketHash,
+			Event:      AuditTxSent,
+			Function:   functionType,
+			Key:        key.name,
+			TxHash:     txHash.String(),
+			Nonce:      &currNonce,
+		}
+		if functionType != ReportOutcome {
+			sent.Outcome = &report.Outcome
+		}
+
+		d.audit(sent)
+
+		if reportingTx.sent != nil {
+			// only the first attempt is reported, retries are followed through the result
+			select {
+			case reportingTx.sent <- &sentTx{hash: txHash, nonce: currNonce}:
+			default:
 			}
 		}
 
-		d.txService.logger.Debug(
+		logger.Debug(
 			"sent tx",
-			"function", functionName,
-			"hash", txn.Hash(),
-			"from", ethgo.Address(validatorAddress),
+			"txHash", txHash,
+			"from", from,
 			"nonce", currNonce,
-			"market", report.MarketHash,
 			"outcome", report.Outcome,
 		)
 
 		// wait for tx to mine
-		receipt := <-d.txService.waitTxConfirmed(txn.Hash())
+		_, confirmSpan := d.tracer.Start(ctx, "waitTxConfirmed", trace.WithAttributes(
+			txHashAttribute.String(txHash.String()),
+			nonceAttribute.Int64(int64(currNonce)),
+		))
+
+		receipt := <-d.txService.waitTxConfirmed(txHash, d.txConfirmationTimeout())
+		if receipt == nil {
+			logger.Error(
+				"timed out waiting for tx receipt",
+				"try", txTry,
+				"nonce", currNonce,
+				"txHash", txHash,
+			)
+
+			err := fmt.Errorf("timed out waiting for receipt of tx %s", txHash)
+			recordSpanError(confirmSpan, err)
+			confirmSpan.End()
+			fail(err)
+
+			return nil
+		}
+
+		confirmSpan.SetAttributes(
+			attribute.Int64("tx.status", int64(receipt.Status)),
+			attribute.Int64("tx.block_number", int64(receipt.BlockNumber)),
+		)
+		d.auditReceipt(reportingTx, currNonce, receipt)
 
 		if receipt.Status == 1 {
-			d.txService.logger.Debug(
+			logger.Debug(
 				"got success receipt",
-				"function", functionName,
 				"nonce", currNonce,
-				"txHash", txn.Hash(),
-				"marketHash", report.MarketHash,
+				"txHash", txHash,
 			)
+			confirmSpan.End()
+			span.SetAttributes(txHashAttribute.String(txHash.String()), nonceAttribute.Int64(int64(currNonce)))
 
-			if functionName == ReportOutcome {
-				d.storeProcessor.store.remove(report.MarketHash)
-			}
+			key.nonces.mined(currNonce)
 
-			return
+			return receipt
 		} else {
+			confirmSpan.SetStatus(codes.Error, "tx failed")
+			confirmSpan.End()
 
-			nonce, err := d.getCurrentNonce(validatorAddress.String())
+			// the failed tx still consumed its nonce
+			key.nonces.mined(currNonce)
+
+			currNonce, err = key.nonces.current()
 			if err != nil {
-				d.txService.logger.Error("nonce error")
+				logger.Error("nonce error", "err", err)
+				fail(err)
+
+				return nil
 			}
 
-			currNonce = Max(nonce, nonce+1)
-			d.txService.logger.Debug(
-				"got failed receipt, retrying with nextNonce and more gas",
-				"function", functionName,
-				"try #", txTry,
+			logger.Debug(
+				"got failed receipt, retrying with nextNonce",
+				"try", txTry,
 				"nonce", currNonce,
-				"txHash", txn.Hash(),
-				"marketHash", report.MarketHash,
+				"txHash", txHash,
 			)
 			txTry++
 		}
 	}
-	d.txService.logger.Debug("could not get success tx receipt even after max tx retries",
-		"function", functionName,
-		"try #", txTry,
+	logger.Debug("could not get success tx receipt even after max tx retries",
+		"try", txTry,
 		"nonce", currNonce,
-		"txHash", txn.Hash(),
-		"marketHash", report.MarketHash)
+		"txHash", txHash)
+	fail(fmt.Errorf("no success receipt after %d tries", txTry))
+
+	return nil
+}
+
+// Builds an unsigned legacy transaction calling the given contract, using the gas price
+// and a gas limit estimated for the call according to the gas policy.
+func (d *ReporterService) buildTxn(from, to ethgo.Address, input []byte, nonce uint64) (*ethgo.Transaction, error) {
+	gasPrice, err := d.gasPrice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price, %w", err)
+	}
 
-	if functionName == ReportOutcome {
-		d.storeProcessor.store.remove(report.MarketHash)
+	gas, err := d.txService.client.Eth().EstimateGas(&ethgo.CallMsg{
+		From: from,
+		To:   &to,
+		Data: input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas, %w", err)
 	}
+
+	return &ethgo.Transaction{
+		From:     from,
+		To:       &to,
+		Input:    input,
+		GasPrice: gasPrice,
+		Gas:      d.config.Load().Gas.gasLimit(gas),
+		Nonce:    nonce,
+		Value:    big.NewInt(0),
+	}, nil
 }
 
 // Returns a channel that receives the receipt of a transaction
 // once it has been confirmed on the blockchain. It continuously polls the
 // blockchain for the transaction receipt using its hash until the receipt is
 // available. Once the receipt is received, it is sent over the channel.
-func (t *TxService) waitTxConfirmed(hash ethgo.Hash) <-chan *ethgo.Receipt {
-	ch := make(chan *ethgo.Receipt)
+// If no receipt is available before the timeout, nil is sent instead.
+func (t *TxService) waitTxConfirmed(hash ethgo.Hash, timeout time.Duration) <-chan *ethgo.Receipt {
+	ch := make(chan *ethgo.Receipt, 1)
 	go func() {
-		for {
+		deadline := time.Now().Add(timeout)
+
+		for time.Now().Before(deadline) {
 			var receipt *ethgo.Receipt
-			t.client.Call("eth_getTransactionReceipt", &receipt, hash)
+			t.client.Call("eth_getTransactionReceipt", &receipt, hash) //nolint:errcheck
 			if receipt != nil {
 				ch <- receipt
+
+				return
 			}
 
 			time.Sleep(time.Millisecond * 500)
 		}
+
+		ch <- nil
 	}()
 
 	return ch
 }
 
+// Returns the time to wait for a sent tx to be mined.
+func (d *ReporterService) txConfirmationTimeout() time.Duration {
+	if timeout := d.config.Load().TxConfirmationTimeout; timeout > 0 {
+		return timeout
+	}
+
+	return defaultTxConfirmationTimeout
+}
+
+// Returns the timeout for JSON-RPC requests made outside the ethgo client.
+func (d *ReporterService) rpcTimeout() time.Duration {
+	if timeout := d.config.Load().RPCTimeout; timeout > 0 {
+		return timeout
+	}
+
+	return defaultRPCTimeout
+}
+
 func (d *ReporterService) getCurrentNonce(address string) (uint64, error) {
 	// Get the transaction count for the given address
 	txCount, err := d.GetTransactionCount(address)
@@ -333,8 +494,11 @@ func (d *ReporterService) getCurrentNonce(address string) (uint64, error) {
 }
 
 func (d *ReporterService) GetTransactionCount(address string) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.rpcTimeout())
+	defer cancel()
+
 	// Initialize JSON-RPC client
-	client, err := rpc.DialContext(context.Background(), JSONRPCHost)
+	client, err := rpc.DialContext(ctx, d.config.Load().JSONRPCURL)
 	if err != nil {
 		d.txService.logger.Error("failed to connect to Ethereum node", "err", err)
 		return nil, err
@@ -346,7 +510,7 @@ func (d *ReporterService) GetTransactionCount(address string) (*big.Int, error)
 
 	// Call eth_getTransactionCount method
 	var count hexutil.Uint64
-	err = client.CallContext(context.Background(), &count, "eth_getTransactionCount", addr, "latest")
+	err = client.CallContext(ctx, &count, "eth_getTransactionCount", addr, "latest")
 	if err != nil {
 		d.txService.logger.Error("failed to call eth_getTransactionCount via JSON-RPC", "err", err)
 		return nil, err
@@ -355,7 +519,7 @@ func (d *ReporterService) GetTransactionCount(address string) (*big.Int, error)
 	// Convert the result to big.Int
 	txCount := new(big.Int).SetUint64(uint64(count))
 
-	d.logger.Debug("txCount", txCount)
+	d.txService.logger.Debug("retrieved transaction count", "address", address, "nonce", txCount)
 
 	return txCount, nil
 }
@@ -368,76 +532,26 @@ func Max(a, b uint64) uint64 {
 	return b
 }
 
-func BytesToECDSAPrivateKey(input []byte) (*ecdsa.PrivateKey, error) {
-	// The key file on disk should be encoded in Base64,
-	// so it must be decoded before it can be parsed by ParsePrivateKey
-	decoded, err := hex.DecodeString(string(input))
-	if err != nil {
-		return nil, err
-	}
-
-	// Make sure the key is properly formatted
-	if len(decoded) != 32 {
-		// Key must be exactly 64 chars (32B) long
-		return nil, fmt.Errorf("invalid key length (%dB), should be 32B", len(decoded))
-	}
-
-	// Convert decoded bytes to a private key
-	key, err := ParseECDSAPrivateKey(decoded)
-	if err != nil {
-		return nil, err
-	}
-
-	return key, nil
-}
-
-// S256 is the secp256k1 elliptic curve
-var S256 = btcec.S256()
-
-func ParseECDSAPrivateKey(buf []byte) (*ecdsa.PrivateKey, error) {
-	prv, _ := btcec.PrivKeyFromBytes(S256, buf)
-
-	return prv.ToECDSA(), nil
-}
-
 var ErrECDSAKeyNotFound = errors.New("ECDSA key not found in given path")
 
-func GetValidatorAddressFromSecretManager(manager secrets.SecretsManager) (types.Address, error) {
-	if !manager.HasSecret(secrets.ReporterKey) {
+func GetValidatorAddressFromSecretManager(manager secrets.SecretsManager, secretName string) (types.Address, error) {
+	if !manager.HasSecret(secretName) {
 		return types.ZeroAddress, ErrECDSAKeyNotFound
 	}
 
-	keyBytes, err := manager.GetSecret(secrets.ReporterKey)
+	keyBytes, err := manager.GetSecret(secretName)
 	if err != nil {
 		return types.ZeroAddress, err
 	}
 
-	privKey, err := BytesToECDSAPrivateKey(keyBytes)
+	defer crypto.ZeroBytes(keyBytes)
+
+	privKey, err := crypto.BytesToECDSAPrivateKey(keyBytes)
 	if err != nil {
 		return types.ZeroAddress, err
 	}
 
-	return PubKeyToAddress(&privKey.PublicKey), nil
-}
-
-// PubKeyToAddress returns the Ethereum address of a public key
-func PubKeyToAddress(pub *ecdsa.PublicKey) types.Address {
-	buf := Keccak256(MarshalPublicKey(pub)[1:])[12:]
-
-	return types.BytesToAddress(buf)
-}
-
-// Keccak256 calculates the Keccak256
-func Keccak256(v ...[]byte) []byte {
-	h := sha3.NewLegacyKeccak256()
-	for _, i := range v {
-		h.Write(i)
-	}
-
-	return h.Sum(nil)
-}
+	defer crypto.ZeroKey(privKey)
 
-// MarshalPublicKey marshals a public key on the secp256k1 elliptic curve.
-func MarshalPublicKey(pub *ecdsa.PublicKey) []byte {
-	return elliptic.Marshal(S256, pub.X, pub.Y)
+	return crypto.PubKeyToAddress(&privKey.PublicKey), nil
 }
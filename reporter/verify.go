@@ -1,46 +1,116 @@
 package reporter
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Default timeout for verify outcome API requests.
+	defaultVerifyOutcomeTimeout = 30 * time.Second
 )
 
+var errMarketUnverified = errors.New("market is not verified yet")
+
 type verifyAPIResponse struct {
-	Outcome   int32
+	Outcome   *int32 // Rejected if omitted, rather than voting void.
 	Timestamp int64
+	Verified  *bool // Whether the outcome is verified, assumed if omitted.
 }
 
-// verifyMarket uses the verify market API to derive an outcome for the specified marketHash to vote on
-func (d *ReporterService) verifyMarket(marketHash string) (int32, error) {
-	requestURL := fmt.Sprintf("%s/%s", d.config.VerifyOutcomeURI, marketHash)
-	response, err := http.Get(requestURL) //nolint:gosec
+// verifyMarket uses the verify market API to derive an outcome for the specified marketHash to vote on.
+// Missing outcomes, outcomes not allowed by the contract, and outcomes the API answers as not verified yet, are rejected.
+// The trace context of ctx is propagated to the API in the request headers.
+// The request and its response are recorded in the audit trail of the market, and API errors are counted for alerting.
+func (d *ReporterService) verifyMarket(ctx context.Context, marketHash string) (outcome Outcome, err error) {
+	ctx, span := d.tracer.Start(ctx, "verifyMarket", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		marketHashAttribute.String(marketHash),
+	))
+
+	auditEntry := &AuditEntry{MarketHash: marketHash, Event: AuditVerifyResponse, Function: VoteOutcome}
+
+	defer func() {
+		if err != nil {
+			recordSpanError(span, err)
+
+			// markets not verified yet are expected while they are being settled, not an error of the API
+			if !errors.Is(err, errMarketUnverified) {
+				d.alerter.recordVerifyError(err)
+			}
+
+			auditEntry.Error = err.Error()
+		} else {
+			span.SetAttributes(outcomeAttribute.Int64(int64(outcome)))
+			d.alerter.recordVerifySuccess()
+
+			value := int32(outcome)
+			auditEntry.Outcome = &value
+		}
 
+		span.End()
+		d.audit(auditEntry)
+	}()
+
+	config := d.config.Load()
+
+	timeout := config.VerifyOutcomeTimeout
+	if timeout <= 0 {
+		timeout = defaultVerifyOutcomeTimeout
+	}
+
+	client := &http.Client{Timeout: timeout}
+
+	requestURL := fmt.Sprintf("%s/%s", config.VerifyOutcomeURI, marketHash)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
-		d.logger.Error("failed to verify market with server error", "error", err)
+		return 0, err
+	}
+
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
 
-		return -1, err
+	d.audit(&AuditEntry{MarketHash: marketHash, Event: AuditVerifyRequest, Function: VoteOutcome, Request: auditURL(requestURL)})
+
+	response, err := client.Do(request)
+	if err != nil {
+		d.logger.Error("failed to verify market with server error", "marketHash", marketHash, "err", err)
+
+		return 0, err
 	}
 
 	defer response.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.status_code", response.StatusCode))
+
 	body, parseErr := ioutil.ReadAll(response.Body)
 
+	auditEntry.StatusCode = response.StatusCode
+	auditEntry.Response = auditResponse(body)
+
 	if parseErr != nil {
-		d.logger.Error("failed to parse response for verify market call", "parseError", parseErr)
+		d.logger.Error("failed to parse response for verify market call", "marketHash", marketHash, "err", parseErr)
 
-		return -1, parseErr
+		return 0, parseErr
 	}
 
 	if response.StatusCode != 200 {
 		d.logger.Error(
 			"got non-200 response for verify market call",
-			"market", marketHash,
-			"parsedBody", body,
+			"marketHash", marketHash,
+			"body", string(body),
 			"statusCode", response.StatusCode,
 		)
 
-		return -1, fmt.Errorf("got non-200 response from verify market response with statusCode %d", response.StatusCode)
+		return 0, fmt.Errorf("got non-200 response from verify market response with statusCode %d", response.StatusCode)
 	}
 
 	var data verifyAPIResponse
@@ -49,12 +119,32 @@ func (d *ReporterService) verifyMarket(marketHash string) (int32, error) {
 	if marshalErr != nil {
 		d.logger.Error(
 			"failed to unmarshal outcome for verify market response",
-			"body", body,
-			"parseError", marshalErr,
+			"marketHash", marketHash,
+			"body", string(body),
+			"err", marshalErr,
 		)
 
-		return -1, marshalErr
+		return 0, marshalErr
+	}
+
+	if data.Verified != nil && !*data.Verified {
+		d.logger.Warn("got unverified result for verify market response", "marketHash", marketHash, "body", string(body))
+
+		return 0, errMarketUnverified
+	}
+
+	if data.Outcome == nil {
+		d.logger.Error("got no outcome for verify market response", "marketHash", marketHash, "body", string(body))
+
+		return 0, errMissingOutcome
+	}
+
+	outcome, err = ParseOutcome(int64(*data.Outcome))
+	if err != nil {
+		d.logger.Error("got invalid outcome for verify market response", "marketHash", marketHash, "body", string(body), "err", err)
+
+		return 0, err
 	}
 
-	return data.Outcome, nil
+	return outcome, nil
 }
@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyMarket(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		outcome    Outcome
+		wantErr    bool
+		errIs      error // Error the returned error is expected to wrap, if any.
+	}{
+		{"outcome", http.StatusOK, `{"Outcome":1,"Timestamp":1700000000}`, OutcomeOne, false, nil},
+		{"void outcome", http.StatusOK, `{"Outcome":0,"Timestamp":1700000000}`, OutcomeVoid, false, nil},
+		{"verified outcome", http.StatusOK, `{"Outcome":2,"Timestamp":1700000000,"Verified":true}`, OutcomeTwo, false, nil},
+		{"missing outcome", http.StatusOK, `{"Timestamp":1700000000}`, 0, true, errMissingOutcome},
+		{"null outcome", http.StatusOK, `{"Outcome":null,"Timestamp":1700000000}`, 0, true, errMissingOutcome},
+		{"outcome not allowed", http.StatusOK, `{"Outcome":3,"Timestamp":1700000000}`, 0, true, errInvalidOutcome},
+		{"unverified outcome", http.StatusOK, `{"Outcome":1,"Timestamp":1700000000,"Verified":false}`, 0, true, errMarketUnverified},
+		{"invalid json", http.StatusOK, `{"Outcome":`, 0, true, nil},
+		{"non-200 response", http.StatusServiceUnavailable, `{"Outcome":1}`, 0, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			d := newTestReporterService(t, &ReporterConfig{VerifyOutcomeURI: server.URL}, nil)
+			d.alerter = newQueuedAlerter(&AlertingConfig{}, alertQueueSize)
+
+			outcome, err := d.verifyMarket(context.Background(), testMarketA)
+
+			switch {
+			case !tt.wantErr && (err != nil || outcome != tt.outcome):
+				t.Errorf("verifyMarket() = %s, %v, want %s", outcome, err, tt.outcome)
+			case tt.wantErr && err == nil:
+				t.Errorf("verifyMarket() = %s, want an error", outcome)
+			case tt.errIs != nil && !errors.Is(err, tt.errIs):
+				t.Errorf("verifyMarket() error = %v, want %v", err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestVerifyMarketErrorAlerts(t *testing.T) {
+	body := `{"Outcome":1,"Timestamp":1700000000,"Verified":false}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	d := newTestReporterService(t, &ReporterConfig{VerifyOutcomeURI: server.URL}, nil)
+	d.alerter = newQueuedAlerter(&AlertingConfig{VerifyErrorThreshold: 1}, alertQueueSize)
+
+	// markets not verified yet are not errors of the API
+	if _, err := d.verifyMarket(context.Background(), testMarketA); !errors.Is(err, errMarketUnverified) {
+		t.Fatalf("verifyMarket() error = %v, want %v", err, errMarketUnverified)
+	}
+
+	if alerts := drainAlerts(d.alerter); len(alerts) != 0 {
+		t.Errorf("raised %+v for an unverified market, want no alert", alerts)
+	}
+
+	body = `{"Outcome":3,"Timestamp":1700000000}`
+
+	if _, err := d.verifyMarket(context.Background(), testMarketA); !errors.Is(err, errInvalidOutcome) {
+		t.Fatalf("verifyMarket() error = %v, want %v", err, errInvalidOutcome)
+	}
+
+	if alerts := drainAlerts(d.alerter); len(alerts) != 1 || alerts[0].Rule != AlertVerifyErrors {
+		t.Errorf("raised %+v, want a verify errors alert", alerts)
+	}
+}
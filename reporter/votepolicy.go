@@ -0,0 +1,475 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sx-network/sx-reporter/helper/types"
+)
+
+const (
+	// Default delay before the first verification retry of a vote.
+	defaultVoteRetryInitialBackoff = 10 * time.Second
+	// Default upper bound of the delay between verification retries of a vote.
+	defaultVoteRetryMaxBackoff = 5 * time.Minute
+	// Default fraction of the voting period after which verification of a vote is no longer retried.
+	defaultVoteRetryPeriodFraction = 0.5
+	// Maximum number of markets verified and voted on at once.
+	maxConcurrentVotes = 4
+)
+
+// Represents the policy applied to votes on markets that could not be verified.
+type VotePolicy string
+
+// Constants representing the supported vote policies.
+const (
+	// DelayVotePolicy retries verification with backoff until the configured fraction of the voting period
+	// has elapsed, then abstains [Default]
+	DelayVotePolicy VotePolicy = "delay"
+	// AbstainVotePolicy abstains from voting as soon as verification fails
+	AbstainVotePolicy VotePolicy = "abstain"
+)
+
+// Holds configuration options for the policy applied to votes on markets that could not be verified.
+type VotePolicyConfig struct {
+	Policy              VotePolicy    // Policy applied when verification fails, delay if empty.
+	InitialBackoff      time.Duration // Delay before the first verification retry, defaults if zero.
+	MaxBackoff          time.Duration // Upper bound of the delay between verification retries, defaults if zero.
+	RetryPeriodFraction float64       // Fraction of the voting period after which verification is no longer retried, defaults if zero.
+}
+
+// Checks that the vote policy is consistent.
+func (c *VotePolicyConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+
+	switch c.Policy {
+	case "", DelayVotePolicy, AbstainVotePolicy:
+	default:
+		return fmt.Errorf("reporter vote policy '%s' not found", c.Policy)
+	}
+
+	if c.InitialBackoff < 0 || c.MaxBackoff < 0 {
+		return fmt.Errorf("reporter vote retry backoff is negative")
+	}
+
+	if c.RetryPeriodFraction < 0 || c.RetryPeriodFraction > 1 {
+		return fmt.Errorf("reporter vote retry period fraction %v is not between 0 and 1", c.RetryPeriodFraction)
+	}
+
+	return nil
+}
+
+// Returns the policy applied when verification fails.
+func (c *VotePolicyConfig) policy() VotePolicy {
+	if c == nil || c.Policy == "" {
+		return DelayVotePolicy
+	}
+
+	return c.Policy
+}
+
+// Returns the delay before the next verification retry, doubling with every attempt up to the max backoff.
+func (c *VotePolicyConfig) backoff(attempts int) time.Duration {
+	initial, max := defaultVoteRetryInitialBackoff, defaultVoteRetryMaxBackoff
+
+	if c != nil && c.InitialBackoff > 0 {
+		initial = c.InitialBackoff
+	}
+
+	if c != nil && c.MaxBackoff > 0 {
+		max = c.MaxBackoff
+	}
+
+	backoff := initial
+	for i := 1; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		return max
+	}
+
+	return backoff
+}
+
+// Returns the fraction of the voting period after which verification is no longer retried.
+func (c *VotePolicyConfig) retryPeriodFraction() float64 {
+	if c == nil || c.RetryPeriodFraction <= 0 {
+		return defaultVoteRetryPeriodFraction
+	}
+
+	return c.RetryPeriodFraction
+}
+
+// Represents a proposed market waiting to be voted on.
+type pendingVote struct {
+	ctx            context.Context // Trace context of the proposal.
+	marketHash     string          // Hash of the proposed market.
+	reportTime     uint64          // Time the outcome was proposed, in unix seconds, the block timestamp until read.
+	reportTimeRead bool            // Whether the report time was read from the contract.
+	retryAt        time.Time       // Time of the next verification attempt.
+	attempts       int             // Verification attempts made so far.
+	inFlight       bool            // Whether the market is being verified.
+}
+
+// Returns the end of the market's voting period, in unix seconds.
+func (v *pendingVote) deadline(votingPeriod uint64) uint64 {
+	return v.reportTime + votingPeriod
+}
+
+// Verifies and votes on proposed markets, nearest voting deadline first.
+// Markets that could not be verified are retried with backoff, or abstained from, according to the vote policy.
+// Deadlines are the report time read from the contract plus the on-chain voting period, and are compared
+// against the chain time of the ReportScheduler, so that votes and reports agree on when the voting period ends.
+type VoteScheduler struct {
+	logger          hclog.Logger
+	reporterService *ReporterService
+	pending         map[string]*pendingVote // Markets waiting to be voted on by market hash.
+	inFlight        int                     // Number of markets being verified and voted on.
+	wakeCh          chan struct{}           // Signals the voting loop that its state changed.
+	sync.Mutex
+}
+
+// Creates a new VoteScheduler instance and starts its voting loop.
+func newVoteScheduler(logger hclog.Logger, reporterService *ReporterService) *VoteScheduler {
+	scheduler := &VoteScheduler{
+		logger:          logger.Named("votes"),
+		reporterService: reporterService,
+		pending:         make(map[string]*pendingVote),
+		wakeCh:          make(chan struct{}, 1),
+	}
+
+	go scheduler.startVotingLoop()
+
+	return scheduler
+}
+
+// Submits the proposed market to be voted on, without blocking the event listener.
+// Its report time is read from the contract by the voting loop, until then the timestamp
+// of the block the outcome was proposed in stands in for it.
+func (s *VoteScheduler) submit(ctx context.Context, marketHash string, blockTimestamp uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.pending[marketHash]; ok {
+		return
+	}
+
+	s.pending[marketHash] = &pendingVote{
+		ctx:        ctx,
+		marketHash: marketHash,
+		reportTime: blockTimestamp,
+		retryAt:    time.Now(),
+	}
+
+	s.logger.Debug("submitted vote", "marketHash", marketHash, "blockTimestamp", blockTimestamp)
+	s.updated()
+}
+
+// Cancels the pending vote on the market, once its outcome is reported.
+func (s *VoteScheduler) cancel(marketHash string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.pending[marketHash]; !ok {
+		return
+	}
+
+	delete(s.pending, marketHash)
+
+	s.logger.Debug("cancelled vote", "marketHash", marketHash)
+	s.updated()
+}
+
+// Updates the pending votes metric and notifies the voting loop without blocking. Must be called with the lock held.
+func (s *VoteScheduler) updated() {
+	s.reporterService.metrics.PendingVotes.Set(float64(len(s.pending)))
+
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Returns the vote due for verification whose voting deadline is nearest, marking it in flight,
+// or else the time the next vote is due, zero if none is pending. Must be called with the lock held.
+// Every market shares the same voting period, so the nearest deadline is that of the earliest report time.
+func (s *VoteScheduler) next(now time.Time) (*pendingVote, time.Time) {
+	var (
+		due     *pendingVote
+		nextDue time.Time
+	)
+
+	for _, vote := range s.pending {
+		switch {
+		case vote.inFlight:
+		case !vote.retryAt.After(now):
+			if due == nil || vote.reportTime < due.reportTime {
+				due = vote
+			}
+		case nextDue.IsZero() || vote.retryAt.Before(nextDue):
+			nextDue = vote.retryAt
+		}
+	}
+
+	if due != nil {
+		due.inFlight = true
+	}
+
+	return due, nextDue
+}
+
+// Starts the voting loop of the VoteScheduler.
+// Each iteration starts verifying and voting on the due market with the nearest voting deadline,
+// up to maxConcurrentVotes markets at once, so that a slow verification does not hold up the other markets.
+// Once no market is due, or too many are in flight, it sleeps until it is woken by a state change
+// or until the next retry is due.
+func (s *VoteScheduler) startVotingLoop() {
+	for {
+		var (
+			vote    *pendingVote
+			nextDue time.Time
+		)
+
+		s.Lock()
+		if s.inFlight < maxConcurrentVotes {
+			vote, nextDue = s.next(time.Now())
+		}
+
+		if vote != nil {
+			s.inFlight++
+		}
+		s.Unlock()
+
+		if vote != nil {
+			go func() {
+				s.vote(vote)
+
+				s.Lock()
+				defer s.Unlock()
+
+				s.inFlight--
+				s.updated()
+			}()
+
+			continue
+		}
+
+		var (
+			timer   *time.Timer
+			timerCh <-chan time.Time
+		)
+
+		if !nextDue.IsZero() {
+			timer = time.NewTimer(time.Until(nextDue))
+			timerCh = timer.C
+		}
+
+		select {
+		case <-s.wakeCh:
+		case <-timerCh:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Verifies the market and queues the vote on the verified outcome,
+// or applies the vote policy if the market could not be verified.
+// While no reporter key is permitted to vote, such as until its balance is topped up, the vote is delayed
+// with backoff until the retry period ends, then skipped.
+func (s *VoteScheduler) vote(vote *pendingVote) {
+	d := s.reporterService
+
+	if !vote.reportTimeRead {
+		s.readReportTime(vote)
+	}
+
+	vote.attempts++
+
+	if keys, reason := d.router.route(VoteOutcome, false); len(keys) == 0 {
+		s.noKeyPermitted(vote, reason)
+
+		return
+	}
+
+	outcome, err := d.verifyMarket(vote.ctx, vote.marketHash)
+	if err != nil {
+		s.verificationFailed(vote, err)
+
+		return
+	}
+
+	s.done(vote)
+
+	d.recordVerifiedOutcome(vote.marketHash, int32(outcome))
+	d.queueReportingTx(vote.ctx, VoteOutcome, vote.marketHash, int32(outcome))
+}
+
+// Retries verification of the vote with backoff, or abstains from it once the policy gives up.
+func (s *VoteScheduler) verificationFailed(vote *pendingVote, err error) {
+	config := s.reporterService.config.Load().VotePolicy
+
+	if config.policy() == AbstainVotePolicy {
+		s.abstain(vote, "market could not be verified", err)
+
+		return
+	}
+
+	retryAt, ok := s.retryAt(vote)
+	if !ok {
+		s.abstain(vote, fmt.Sprintf("market could not be verified within %v of the voting period", config.retryPeriodFraction()), err)
+
+		return
+	}
+
+	s.logger.Warn(
+		"market could not be verified, delaying vote",
+		"marketHash", vote.marketHash,
+		"attempt", vote.attempts,
+		"retryAt", retryAt,
+		"deadline", time.Unix(int64(vote.deadline(s.reporterService.VotingPeriod())), 0),
+		"err", err,
+	)
+	s.delay(vote, retryAt, &AuditEntry{
+		Reason: fmt.Sprintf("retrying verification at %s", retryAt.Format(time.RFC3339)),
+		Error:  err.Error(),
+	})
+}
+
+// Retries the vote with backoff while no reporter key is permitted to vote, or skips it once the retry period ends.
+func (s *VoteScheduler) noKeyPermitted(vote *pendingVote, reason string) {
+	d := s.reporterService
+
+	retryAt, ok := s.retryAt(vote)
+	if !ok {
+		s.logger.Warn("no reporter key is permitted to vote, skipping vote", "marketHash", vote.marketHash, "reason", reason)
+		d.audit(&AuditEntry{MarketHash: vote.marketHash, Event: AuditSkipped, Function: VoteOutcome, Reason: reason})
+		s.done(vote)
+
+		return
+	}
+
+	s.logger.Warn(
+		"no reporter key is permitted to vote, delaying vote",
+		"marketHash", vote.marketHash,
+		"attempt", vote.attempts,
+		"retryAt", retryAt,
+		"reason", reason,
+	)
+	s.delay(vote, retryAt, &AuditEntry{
+		Reason: fmt.Sprintf("%s, retrying at %s", reason, retryAt.Format(time.RFC3339)),
+	})
+}
+
+// Returns the time of the next attempt of the vote, backing off with every attempt,
+// or false once the policy's retry period of the voting period has ended in chain time.
+func (s *VoteScheduler) retryAt(vote *pendingVote) (time.Time, bool) {
+	d := s.reporterService
+	config := d.config.Load().VotePolicy
+
+	giveUpAt := vote.reportTime + uint64(config.retryPeriodFraction()*float64(d.VotingPeriod()))
+
+	chainTime := d.scheduler.currentChainTime()
+	if chainTime >= giveUpAt {
+		return time.Time{}, false
+	}
+
+	// the last attempt is made when the retry period ends in chain time
+	backoff := config.backoff(vote.attempts)
+	if remaining := time.Duration(giveUpAt-chainTime) * time.Second; backoff > remaining {
+		backoff = remaining
+	}
+
+	return time.Now().Add(backoff), true
+}
+
+// Delays the vote until the retry time, recording the delay in the audit trail.
+func (s *VoteScheduler) delay(vote *pendingVote, retryAt time.Time, entry *AuditEntry) {
+	d := s.reporterService
+
+	entry.MarketHash = vote.marketHash
+	entry.Event = AuditVoteDelayed
+	entry.Function = VoteOutcome
+
+	d.metrics.VoteRetries.Inc()
+	d.audit(entry)
+
+	s.Lock()
+	defer s.Unlock()
+
+	vote.inFlight = false
+	vote.retryAt = retryAt
+
+	s.updated()
+}
+
+// Reads the report time of the vote from the contract, keeping the proposal block timestamp if it cannot be read.
+func (s *VoteScheduler) readReportTime(vote *pendingVote) {
+	reportTime, err := s.reporterService.reportTime(vote.marketHash)
+	if err != nil {
+		s.logger.Warn("failed to read report time, using the proposal block timestamp", "marketHash", vote.marketHash, "err", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if err == nil {
+		vote.reportTime = reportTime
+	}
+
+	vote.reportTimeRead = true
+}
+
+// Abstains from voting on the market, recording why.
+func (s *VoteScheduler) abstain(vote *pendingVote, reason string, err error) {
+	d := s.reporterService
+
+	s.logger.Warn("abstaining from vote", "marketHash", vote.marketHash, "attempts", vote.attempts, "reason", reason, "err", err)
+	d.metrics.VotesAbstained.Inc()
+	d.audit(&AuditEntry{
+		MarketHash: vote.marketHash,
+		Event:      AuditAbstained,
+		Function:   VoteOutcome,
+		Reason:     reason,
+		Error:      err.Error(),
+	})
+
+	s.done(vote)
+}
+
+// Removes the vote from the pending votes, unless it was cancelled while in flight.
+func (s *VoteScheduler) done(vote *pendingVote) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.pending[vote.marketHash] == vote {
+		delete(s.pending, vote.marketHash)
+	}
+
+	s.updated()
+}
+
+// Returns the time the outcome of the market was proposed, read from the contract.
+func (d *ReporterService) reportTime(marketHash string) (uint64, error) {
+	result := d.sendCall(GetReportTime, types.StringToHash(marketHash))
+	if result == nil {
+		return 0, errors.New("report time returned nil")
+	}
+
+	reportTime, ok := result.(*big.Int)
+	if !ok || !reportTime.IsUint64() || reportTime.Sign() == 0 {
+		return 0, fmt.Errorf("unexpected report time %v", result)
+	}
+
+	return reportTime.Uint64(), nil
+}
@@ -0,0 +1,315 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Creates a vote scheduler without starting its voting loop.
+func newTestVoteScheduler(d *ReporterService) *VoteScheduler {
+	return &VoteScheduler{
+		logger:          hclog.NewNullLogger(),
+		reporterService: d,
+		pending:         make(map[string]*pendingVote),
+		wakeCh:          make(chan struct{}, 1),
+	}
+}
+
+func TestVoteSchedulerNext(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		pending []*pendingVote
+		due     string
+		nextDue time.Time
+	}{
+		{"no pending votes", nil, "", time.Time{}},
+		{
+			"nearest deadline first",
+			[]*pendingVote{
+				{marketHash: testMarketA, reportTime: 300, retryAt: now},
+				{marketHash: testMarketB, reportTime: 100, retryAt: now.Add(-time.Minute)},
+				{marketHash: testMarketC, reportTime: 200, retryAt: now},
+			},
+			testMarketB,
+			time.Time{},
+		},
+		{
+			"nearest deadline not due",
+			[]*pendingVote{
+				{marketHash: testMarketA, reportTime: 100, retryAt: now.Add(time.Minute)},
+				{marketHash: testMarketB, reportTime: 200, retryAt: now},
+			},
+			testMarketB,
+			now.Add(time.Minute),
+		},
+		{
+			"nearest deadline in flight",
+			[]*pendingVote{
+				{marketHash: testMarketA, reportTime: 100, retryAt: now, inFlight: true},
+				{marketHash: testMarketB, reportTime: 200, retryAt: now},
+			},
+			testMarketB,
+			time.Time{},
+		},
+		{
+			"earliest retry",
+			[]*pendingVote{
+				{marketHash: testMarketA, reportTime: 100, retryAt: now.Add(2 * time.Minute)},
+				{marketHash: testMarketB, reportTime: 200, retryAt: now.Add(time.Minute)},
+				{marketHash: testMarketC, reportTime: 300, retryAt: now.Add(3 * time.Minute)},
+			},
+			"",
+			now.Add(time.Minute),
+		},
+		{
+			"all in flight",
+			[]*pendingVote{
+				{marketHash: testMarketA, reportTime: 100, retryAt: now.Add(time.Minute), inFlight: true},
+			},
+			"",
+			time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestVoteScheduler(newTestReporterService(t, nil, nil))
+			for _, vote := range tt.pending {
+				s.pending[vote.marketHash] = vote
+			}
+
+			due, nextDue := s.next(now)
+
+			if tt.due == "" {
+				if due != nil {
+					t.Errorf("next() due = %s, want none", due.marketHash)
+				}
+			} else if due == nil || due.marketHash != tt.due || !due.inFlight {
+				t.Errorf("next() due = %+v, want %s marked in flight", due, tt.due)
+			}
+
+			if !nextDue.Equal(tt.nextDue) {
+				t.Errorf("next() next due = %s, want %s", nextDue, tt.nextDue)
+			}
+		})
+	}
+}
+
+func TestVotePolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *VotePolicyConfig
+		attempts int
+		backoff  time.Duration
+	}{
+		{"default first attempt", nil, 1, defaultVoteRetryInitialBackoff},
+		{"default doubling", nil, 3, 4 * defaultVoteRetryInitialBackoff},
+		{"default capped", nil, 20, defaultVoteRetryMaxBackoff},
+		{"no attempts", &VotePolicyConfig{InitialBackoff: time.Second}, 0, time.Second},
+		{"configured doubling", &VotePolicyConfig{InitialBackoff: time.Second, MaxBackoff: time.Minute}, 4, 8 * time.Second},
+		{"configured capped", &VotePolicyConfig{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, 5, 10 * time.Second},
+		{"initial above max", &VotePolicyConfig{InitialBackoff: time.Minute, MaxBackoff: time.Second}, 1, time.Second},
+		{"many attempts", &VotePolicyConfig{InitialBackoff: time.Second, MaxBackoff: time.Hour}, 1000, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if backoff := tt.config.backoff(tt.attempts); backoff != tt.backoff {
+				t.Errorf("backoff(%d) = %s, want %s", tt.attempts, backoff, tt.backoff)
+			}
+		})
+	}
+}
+
+func TestVotePolicyVerificationFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *VotePolicyConfig
+		attempts int
+		event    string // Event recorded in the audit trail.
+	}{
+		{"abstain on first failure", &VotePolicyConfig{Policy: AbstainVotePolicy}, 1, AuditAbstained},
+		{"abstain within retry period", &VotePolicyConfig{Policy: AbstainVotePolicy}, 5, AuditAbstained},
+		{"delay by default", nil, 1, AuditVoteDelayed},
+		{"delay", &VotePolicyConfig{Policy: DelayVotePolicy}, 3, AuditVoteDelayed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestReporterService(t, &ReporterConfig{VotePolicy: tt.policy}, nil)
+			d.auditLog = newAuditLog(t.TempDir())
+			d.votingPeriod.seconds.Store(1000)
+			d.scheduler = &ReportScheduler{chainTime: 10_000, wakeCh: make(chan struct{}, 1)}
+
+			s := newTestVoteScheduler(d)
+
+			vote := &pendingVote{marketHash: testMarketA, reportTime: 10_000, reportTimeRead: true, attempts: tt.attempts, inFlight: true}
+			s.pending[testMarketA] = vote
+
+			s.verificationFailed(vote, errMarketUnverified)
+
+			entries, err := d.AuditTrail(testMarketA)
+			if err != nil {
+				t.Fatalf("AuditTrail() error = %v", err)
+			}
+
+			if len(entries) != 1 || entries[0].Event != tt.event || entries[0].Error != errMarketUnverified.Error() {
+				t.Fatalf("audit trail = %+v, want %s", entries, tt.event)
+			}
+
+			// abstained votes are no longer pending, delayed ones wait for their retry
+			_, pending := s.pending[testMarketA]
+			if delayed := tt.event == AuditVoteDelayed; pending != delayed || (delayed && vote.inFlight) {
+				t.Errorf("vote pending = %v, in flight = %v, want pending %v", pending, vote.inFlight, delayed)
+			}
+		})
+	}
+}
+
+func TestVoteRetryPeriodInChainTime(t *testing.T) {
+	d := newTestReporterService(t, &ReporterConfig{VotePolicy: &VotePolicyConfig{InitialBackoff: time.Hour}}, nil)
+	d.votingPeriod.seconds.Store(1000)
+
+	// chain time lags far behind the wall clock, which must not end the retry period
+	d.scheduler = &ReportScheduler{chainTime: 10_400, wakeCh: make(chan struct{}, 1)}
+
+	s := newTestVoteScheduler(d)
+
+	vote := &pendingVote{marketHash: testMarketHash, reportTime: 10_000, reportTimeRead: true, attempts: 1, inFlight: true}
+	s.pending[testMarketHash] = vote
+
+	start := time.Now()
+	s.verificationFailed(vote, errors.New("verify outcome API unavailable"))
+
+	if s.pending[testMarketHash] != vote || vote.inFlight {
+		t.Fatal("vote was not delayed within the retry period")
+	}
+
+	// the backoff is capped by the 100s left of the retry period, which ends at half the voting period
+	if retryIn := vote.retryAt.Sub(start); retryIn < 100*time.Second || retryIn > 101*time.Second {
+		t.Errorf("vote retried in %s, want the 100s left of the retry period", retryIn)
+	}
+
+	d.scheduler.updateChainTime(10_500)
+	vote.inFlight = true
+
+	s.verificationFailed(vote, errors.New("verify outcome API unavailable"))
+
+	if _, ok := s.pending[testMarketHash]; ok {
+		t.Error("vote was not abstained from once the retry period ended in chain time")
+	}
+}
+
+func TestVoteDelayedWhileNoKeyPermitted(t *testing.T) {
+	d := newTestReporterService(t, &ReporterConfig{}, nil)
+	d.auditLog = newAuditLog(t.TempDir())
+	d.votingPeriod.seconds.Store(1000)
+	d.scheduler = &ReportScheduler{chainTime: 10_000, wakeCh: make(chan struct{}, 1)}
+	d.setActionStatus(d.keys[0], VoteOutcome, &ActionStatus{Allowed: false, Reason: "missing role"})
+
+	s := newTestVoteScheduler(d)
+
+	vote := &pendingVote{marketHash: testMarketA, reportTime: 10_000, reportTimeRead: true, inFlight: true}
+	s.pending[testMarketA] = vote
+
+	s.vote(vote)
+
+	if s.pending[testMarketA] != vote || vote.inFlight || !vote.retryAt.After(time.Now()) {
+		t.Fatal("vote was not delayed while no key is permitted to vote")
+	}
+
+	// the vote is skipped once the retry period ends in chain time
+	d.scheduler.updateChainTime(10_500)
+	vote.inFlight = true
+
+	s.vote(vote)
+
+	if _, ok := s.pending[testMarketA]; ok {
+		t.Error("vote was not skipped once the retry period ended")
+	}
+
+	entries, err := d.AuditTrail(testMarketA)
+	if err != nil {
+		t.Fatalf("AuditTrail() error = %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Event != AuditVoteDelayed || entries[1].Event != AuditSkipped {
+		t.Errorf("audit trail = %+v, want the vote delayed then skipped", entries)
+	}
+}
+
+func TestVotingLoopVerifiesConcurrently(t *testing.T) {
+	var (
+		lock         sync.Mutex
+		verifying    int
+		maxVerifying int
+	)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 8)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lock.Lock()
+		verifying++
+		maxVerifying = max(maxVerifying, verifying)
+		lock.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		lock.Lock()
+		verifying--
+		lock.Unlock()
+
+		_, _ = w.Write([]byte(`{"Outcome":1,"Timestamp":1700000000,"Verified":false}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	d := newTestReporterService(t, &ReporterConfig{
+		VerifyOutcomeURI: server.URL,
+		VotePolicy:       &VotePolicyConfig{InitialBackoff: time.Hour},
+	}, nil)
+	d.votingPeriod.seconds.Store(1000)
+	d.scheduler = &ReportScheduler{chainTime: 10_000, wakeCh: make(chan struct{}, 1)}
+
+	s := newTestVoteScheduler(d)
+
+	markets := []string{testMarketA, testMarketB, testMarketC, testMarketHash, "0x01", "0x02"}
+	for i, marketHash := range markets {
+		s.pending[marketHash] = &pendingVote{ctx: context.Background(), marketHash: marketHash, reportTime: uint64(10_000 + i), reportTimeRead: true}
+	}
+
+	go s.startVotingLoop()
+
+	for i := 0; i < maxConcurrentVotes; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("verified %d markets at once, want %d", i, maxConcurrentVotes)
+		}
+	}
+
+	// no other market is verified until one of the verifications completes
+	select {
+	case <-started:
+		t.Fatalf("verified more than %d markets at once", maxConcurrentVotes)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if maxVerifying != maxConcurrentVotes {
+		t.Errorf("verified %d markets at once, want %d", maxVerifying, maxConcurrentVotes)
+	}
+}
@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Default interval between scheduled refreshes of the on-chain voting period.
+	defaultVotingPeriodRefreshInterval = 10 * time.Minute
+)
+
+// Holds the last known on-chain outcome voting period.
+// It is safe for concurrent use by the event listener, the schedulers and the operator API.
+type votingPeriodCache struct {
+	seconds     atomic.Uint64 // Voting period in seconds.
+	lastRefresh atomic.Int64  // Unix timestamp of the last successful refresh.
+}
+
+// Returns the cached outcome voting period in seconds.
+func (d *ReporterService) VotingPeriod() uint64 {
+	return d.votingPeriod.seconds.Load()
+}
+
+// Returns the time the voting period was last successfully read from the chain.
+// The zero time is returned if the voting period was never loaded.
+func (d *ReporterService) VotingPeriodLastRefresh() time.Time {
+	lastRefresh := d.votingPeriod.lastRefresh.Load()
+	if lastRefresh == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(lastRefresh, 0)
+}
+
+// Retrieves the voting period from the chain and updates the cached value accordingly.
+// It sends a call to the "_votingPeriod" function on the chain to fetch the voting period value.
+// If the result is nil or cannot be converted to *big.Int, it returns an error and leaves the cached value untouched.
+func (d *ReporterService) syncVotingPeriod() error {
+	result := d.sendCall(VotingPeriod)
+	if result == nil {
+		return errors.New("voting period returned nil")
+	}
+
+	votingPeriodOnchain, ok := result.(*big.Int)
+	if !ok {
+		return errors.New("failed to convert voting period result to *big.Int")
+	}
+
+	previous := d.votingPeriod.seconds.Swap(votingPeriodOnchain.Uint64())
+	now := time.Now()
+	d.votingPeriod.lastRefresh.Store(now.Unix())
+
+	d.metrics.VotingPeriodSeconds.Set(float64(votingPeriodOnchain.Uint64()))
+	d.metrics.VotingPeriodLastRefresh.Set(float64(now.Unix()))
+
+	if previous != votingPeriodOnchain.Uint64() {
+		d.logger.Info("updated voting period", "previous", previous, "votingPeriod", votingPeriodOnchain.Uint64())
+
+		if d.scheduler != nil {
+			d.scheduler.reschedule(votingPeriodOnchain.Uint64())
+		}
+	} else {
+		d.logger.Debug("retrieved onchain voting period", "votingPeriod", votingPeriodOnchain.Uint64())
+	}
+
+	return nil
+}
+
+// Periodically refreshes the cached voting period using the configured refresh interval.
+// Failed refreshes are logged and retried on the next tick, keeping the last known value in the meantime.
+func (d *ReporterService) startVotingPeriodRefreshLoop() {
+	interval := d.config.Load().VotingPeriodRefreshInterval
+	if interval <= 0 {
+		interval = defaultVotingPeriodRefreshInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := d.syncVotingPeriod(); err != nil {
+			d.logger.Error("failed to refresh voting period", "err", err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package reporter
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestSyncVotingPeriod(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 0)
+	d := s.reporterService
+	d.config.Store(&ReporterConfig{OutcomeReporterAddress: testOutcomeReporterAddress})
+
+	if !d.VotingPeriodLastRefresh().IsZero() {
+		t.Fatal("voting period has a last refresh time before it was loaded")
+	}
+
+	chain := newFakeChain(t, d)
+	var votingPeriod interface{} = big.NewInt(100)
+	chain.handleCall(t, "function _votingPeriod() view returns (uint256)", func(map[string]interface{}) interface{} {
+		return votingPeriod
+	})
+
+	if err := d.syncVotingPeriod(); err != nil {
+		t.Fatalf("syncVotingPeriod() error = %v", err)
+	}
+
+	if d.VotingPeriod() != 100 || d.VotingPeriodLastRefresh().IsZero() {
+		t.Fatalf("voting period = %d refreshed at %s, want 100 with a refresh time", d.VotingPeriod(), d.VotingPeriodLastRefresh())
+	}
+
+	s.schedule(testMarketA, 500)
+
+	// an on-chain change reschedules the markets with the new voting period
+	votingPeriod = big.NewInt(300)
+
+	if err := d.syncVotingPeriod(); err != nil {
+		t.Fatalf("syncVotingPeriod() error = %v", err)
+	}
+
+	if market := s.markets[testMarketA]; d.VotingPeriod() != 300 || market.deadline != 800 {
+		t.Fatalf("voting period = %d with deadline %d, want 300 with deadline 800", d.VotingPeriod(), market.deadline)
+	}
+
+	// a failed call keeps the last known voting period
+	votingPeriod = nil
+
+	if err := d.syncVotingPeriod(); err == nil {
+		t.Fatal("syncVotingPeriod() error = nil, want an error for the reverted call")
+	}
+
+	if d.VotingPeriod() != 300 {
+		t.Errorf("voting period = %d after a failed refresh, want the last known 300", d.VotingPeriod())
+	}
+
+	if calls := chain.count(VotingPeriod); calls != 3 {
+		t.Errorf("_votingPeriod called %d times, want 3", calls)
+	}
+}
+
+func TestVotingPeriodCheckedOnNewHeads(t *testing.T) {
+	s := newTestReportScheduler(t, 1000, 100)
+	d := s.reporterService
+	d.config.Store(&ReporterConfig{OutcomeReporterAddress: testOutcomeReporterAddress})
+
+	chain := newFakeChain(t, d)
+	var votingPeriod atomic.Int64
+	chain.handleCall(t, "function _votingPeriod() view returns (uint256)", func(map[string]interface{}) interface{} {
+		return big.NewInt(votingPeriod.Load())
+	})
+
+	e := EventListener{
+		logger:             hclog.NewNullLogger(),
+		reporterService:    d,
+		votingPeriodChecks: make(chan struct{}, 1),
+	}
+
+	go e.startVotingPeriodCheckLoop()
+	t.Cleanup(func() { close(e.votingPeriodChecks) })
+
+	s.schedule(testMarketA, 500)
+
+	// a change made on-chain, e.g. through a proxy, is picked up by the next check
+	votingPeriod.Store(300)
+
+	// heads received while a check is pending are merged into it, without blocking the listening loop
+	for i := 0; i < 3; i++ {
+		e.requestVotingPeriodCheck()
+	}
+
+	deadline := func() uint64 {
+		s.Lock()
+		defer s.Unlock()
+
+		return s.markets[testMarketA].deadline
+	}
+
+	// the scheduled markets are rescheduled with the new voting period
+	for start := time.Now(); deadline() != 800; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("voting period = %d with deadline %d, want 300 with deadline 800", d.VotingPeriod(), deadline())
+		}
+	}
+}